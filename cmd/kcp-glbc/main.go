@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	certmanclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	certmaninformer "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
 
+	"k8s.io/apimachinery/pkg/labels"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -26,24 +29,34 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 
+	kcpclient "github.com/kcp-dev/kcp/pkg/client/clientset/versioned"
+	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
 	"github.com/kcp-dev/logicalcluster"
 
 	kuadrantv1 "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/clientset/versioned"
 	"github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/informers/externalversions"
+	azuredns "github.com/kuadrant/kcp-glbc/pkg/dns/azure"
+	googledns "github.com/kuadrant/kcp-glbc/pkg/dns/google"
+	rfc2136dns "github.com/kuadrant/kcp-glbc/pkg/dns/rfc2136"
 	"github.com/kuadrant/kcp-glbc/pkg/log"
 	"github.com/kuadrant/kcp-glbc/pkg/metrics"
 	"github.com/kuadrant/kcp-glbc/pkg/net"
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler/deployment"
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler/domainverification"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler/healthcheckpolicy"
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler/ingress"
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler/service"
 	"github.com/kuadrant/kcp-glbc/pkg/tls"
 	"github.com/kuadrant/kcp-glbc/pkg/util/env"
+	"github.com/kuadrant/kcp-glbc/pkg/util/workloadMigration"
 )
 
 const (
 	numThreads   = 2
 	resyncPeriod = 10 * time.Hour
+	// dnsPlanEndpoint serves the planned DNS record changes in dry run mode
+	dnsPlanEndpoint = "/debug/dns/plan"
 )
 
 var options struct {
@@ -63,12 +76,64 @@ var options struct {
 	TLSProvider string
 	// The base domain
 	Domain string
+	// The domains of the workspaces, overriding the base domain
+	DomainMapping string
 	// Whether custom hosts are permitted
 	EnableCustomHosts bool
 	// The DNS provider
 	DNSProvider string
+	// The DNS zones where records are published
+	DNSZoneIDs string
+	// The tags of the DNS zones where records are published
+	DNSZoneTags string
+	// The owner of the published DNS records
+	DNSOwnerID string
+	// The interval at which the published DNS records are checked for drift
+	DNSDriftCheckInterval time.Duration
+	// Whether the DNS record changes are planned without being applied
+	DNSDryRun bool
+	// The default policy routing the traffic between the clusters
+	DNSRoutingPolicy string
+	// Whether the health of the endpoints is probed by GLBC
+	DNSHealthProbe bool
+	// The interval between the probes of an endpoint
+	DNSHealthProbeInterval time.Duration
+	// The interval at which the orphaned health checks are collected
+	DNSHealthCheckGCInterval time.Duration
+	// The time a health check must remain orphaned before being deleted
+	DNSHealthCheckGCGracePeriod time.Duration
+	// Whether the orphaned health checks are only logged, rather than deleted
+	DNSHealthCheckGCDryRun bool
+	// The percentages of the weight the traffic of a leaving cluster is drained through
+	WorkloadDrainSchedule string
+	// The interval between the steps of the drain of a leaving cluster
+	WorkloadDrainStepInterval time.Duration
 	// The AWS Route53 region
 	Region string
+	// The Google Cloud DNS project
+	GoogleProject string
+	// The Google Cloud DNS API endpoint
+	GoogleEndpoint string
+	// The Azure DNS subscription
+	AzureSubscriptionID string
+	// The Azure DNS resource group
+	AzureResourceGroup string
+	// The Azure service principal tenant
+	AzureTenantID string
+	// The Azure service principal client
+	AzureClientID string
+	// The Azure Resource Manager endpoint
+	AzureEndpoint string
+	// The address the in-memory DNS provider records are served on
+	InMemoryDNSAddress string
+	// The name servers the hosts are resolved with
+	DNSNameservers string
+	// The RFC 2136 server address
+	RFC2136Server string
+	// The RFC 2136 TSIG key name
+	RFC2136TSIGKeyName string
+	// The RFC 2136 TSIG key algorithm
+	RFC2136TSIGAlgorithm string
 	// The port number of the metrics endpoint
 	MonitoringPort int
 }
@@ -79,7 +144,7 @@ func init() {
 	// KCP client options
 	flagSet.StringVar(&options.Kubeconfig, "kubeconfig", "", "Path to kubeconfig")
 	flagSet.StringVar(&options.Kubecontext, "context", env.GetEnvString("GLBC_KCP_CONTEXT", ""), "Context to use in the Kubeconfig file, instead of the current context")
-	flagSet.StringVar(&options.ComputeWorkspace, "compute-workspace", env.GetEnvString("GLBC_COMPUTE_WORKSPACE", "root:default:kcp-glbc-user-compute"), "The user compute workspace")
+	flagSet.StringVar(&options.ComputeWorkspace, "compute-workspace", env.GetEnvString("GLBC_COMPUTE_WORKSPACE", "root:default:kcp-glbc-user-compute"), "The user compute workspace, where the SyncTargets and Locations are listed and watched to locate the clusters with the aws DNS provider")
 	flagSet.StringVar(&options.GLBCWorkspace, "glbc-workspace", env.GetEnvString("GLBC_WORKSPACE", "root:default:kcp-glbc"), "The GLBC workspace")
 	flagSet.StringVar(&options.LogicalClusterTarget, "logical-cluster", env.GetEnvString("GLBC_LOGICAL_CLUSTER_TARGET", "*"), "set the target logical cluster")
 	// TLS certificate issuance options
@@ -87,10 +152,40 @@ func init() {
 	flagSet.StringVar(&options.TLSProvider, "glbc-tls-provider", env.GetEnvString("GLBC_TLS_PROVIDER", "glbc-ca"), "The TLS certificate issuer, one of [glbc-ca, le-staging, le-production]")
 	// DNS management options
 	flagSet.StringVar(&options.Domain, "domain", env.GetEnvString("GLBC_DOMAIN", "dev.hcpapps.net"), "The domain to use to expose ingresses")
-	flagSet.BoolVar(&options.EnableCustomHosts, "enable-custom-hosts", env.GetEnvBool("GLBC_ENABLE_CUSTOM_HOSTS", false), "Flag to enable hosts to be custom")
-	flag.StringVar(&options.DNSProvider, "dns-provider", env.GetEnvString("GLBC_DNS_PROVIDER", "fake"), "The DNS provider being used [aws, fake]")
+	flagSet.StringVar(&options.DomainMapping, "domain-mapping", env.GetEnvString("GLBC_DOMAIN_MAPPING", ""), "Comma separated workspace=domain pairs assigning domains to workspaces and their descendants, e.g. root:org:eu=eu.apps.example.com")
+	flagSet.BoolVar(&options.EnableCustomHosts, "enable-custom-hosts", env.GetEnvBool("GLBC_ENABLE_CUSTOM_HOSTS", false), "Flag to enable hosts to be custom, once the ownership of their domain is verified")
+	flag.StringVar(&options.DNSProvider, "dns-provider", env.GetEnvString("GLBC_DNS_PROVIDER", "fake"), "The DNS provider being used [aws, google, azure, rfc2136, inmemory, fake]")
+	flagSet.StringVar(&options.DNSZoneIDs, "dns-zone-id", env.GetEnvString("GLBC_DNS_ZONE_ID", env.GetEnvString("AWS_DNS_PUBLIC_ZONE_ID", "")), "Comma separated identifiers of the DNS zones where records are published, i.e., the hosted zone id on AWS, the managed zone name on Google, the zone name on Azure, or the zone apex for RFC 2136")
+	flagSet.StringVar(&options.DNSZoneTags, "dns-zone-tags", env.GetEnvString("GLBC_DNS_ZONE_TAGS", ""), "Comma separated key=value tags of the DNS zones where records are published, e.g. kuadrant.dev/glbc=true")
+	flagSet.StringVar(&options.DNSOwnerID, "dns-owner-id", env.GetEnvString("GLBC_DNS_OWNER_ID", ""), "The owner recorded in the TXT records published alongside the DNS records, so that records owned by others are not modified, not recorded if empty")
+	flagSet.DurationVar(&options.DNSDriftCheckInterval, "dns-drift-check-interval", env.GetEnvDuration("GLBC_DNS_DRIFT_CHECK_INTERVAL", 5*time.Minute), "The interval at which the published DNS records are compared with the DNSRecords, and published again if they differ, not checked if 0")
+	flagSet.BoolVar(&options.DNSDryRun, "dns-dry-run", env.GetEnvBool("GLBC_DNS_DRY_RUN", false), "Plan the DNS record changes, and report them in the DNSRecord status, events and logs, and on the /debug/dns/plan endpoint of the metrics server, without applying them")
+	flagSet.StringVar(&options.DNSRoutingPolicy, "dns-routing-policy", env.GetEnvString("GLBC_DNS_ROUTING_POLICY", string(ingress.RoutingPolicyWeighted)), "The policy routing the traffic between the clusters of the Ingresses not selecting one with the kuadrant.dev/routing-policy annotation, one of [weighted, latency, geo, failover], all but weighted are only supported by the aws provider")
+	flagSet.BoolVar(&options.DNSHealthProbe, "dns-health-probe", env.GetEnvBool("GLBC_DNS_HEALTH_PROBE", false), "Probe the health of the endpoints of the Ingresses configuring health checks from within GLBC, rather than by the DNS provider, and do not route to the unhealthy endpoints")
+	flagSet.DurationVar(&options.DNSHealthProbeInterval, "dns-health-probe-interval", env.GetEnvDuration("GLBC_DNS_HEALTH_PROBE_INTERVAL", 30*time.Second), "The interval between the health probes of an endpoint")
+	flagSet.DurationVar(&options.DNSHealthCheckGCInterval, "dns-health-check-gc-interval", env.GetEnvDuration("GLBC_DNS_HEALTH_CHECK_GC_INTERVAL", time.Hour), "The interval at which the health checks created by GLBC, and no longer referenced by any DNSRecord, are deleted, not deleted if 0")
+	flagSet.DurationVar(&options.DNSHealthCheckGCGracePeriod, "dns-health-check-gc-grace-period", env.GetEnvDuration("GLBC_DNS_HEALTH_CHECK_GC_GRACE_PERIOD", time.Hour), "The time a health check must remain unreferenced by any DNSRecord before being deleted")
+	flagSet.BoolVar(&options.DNSHealthCheckGCDryRun, "dns-health-check-gc-dry-run", env.GetEnvBool("GLBC_DNS_HEALTH_CHECK_GC_DRY_RUN", false), "Log the orphaned health checks that would be deleted, without deleting them, always the case if the DNS owner ID is not set")
+	flagSet.StringVar(&options.WorkloadDrainSchedule, "workload-drain-schedule", env.GetEnvString("GLBC_WORKLOAD_DRAIN_SCHEDULE", "75,50,25"), "Comma separated decreasing percentages of its weight the traffic of a cluster the workloads are migrated away from is stepped down through, before it is drained, e.g. 75,50,25, drained at once if empty")
+	flagSet.DurationVar(&options.WorkloadDrainStepInterval, "workload-drain-step-interval", env.GetEnvDuration("GLBC_WORKLOAD_DRAIN_STEP_INTERVAL", 30*time.Second), "The interval between the steps of the drain of the traffic of a cluster the workloads are migrated away from")
 	// // AWS Route53 options
 	flag.StringVar(&options.Region, "region", env.GetEnvString("AWS_REGION", "eu-central-1"), "the region we should target with AWS clients")
+	// Google Cloud DNS options
+	flagSet.StringVar(&options.GoogleProject, "google-project", env.GetEnvString("GOOGLE_PROJECT", ""), "The GCP project of the Cloud DNS managed zones")
+	flagSet.StringVar(&options.GoogleEndpoint, "google-dns-endpoint", env.GetEnvString("GOOGLE_DNS_ENDPOINT", ""), "Override the Cloud DNS API endpoint, e.g. to target a local stand-in (requests are not authenticated)")
+	// Azure DNS options
+	flagSet.StringVar(&options.AzureSubscriptionID, "azure-subscription-id", env.GetEnvString("AZURE_SUBSCRIPTION_ID", ""), "The Azure subscription of the DNS zones")
+	flagSet.StringVar(&options.AzureResourceGroup, "azure-resource-group", env.GetEnvString("AZURE_RESOURCE_GROUP", ""), "The Azure resource group of the DNS zones")
+	flagSet.StringVar(&options.AzureTenantID, "azure-tenant-id", env.GetEnvString("AZURE_TENANT_ID", ""), "The tenant of the Azure service principal")
+	flagSet.StringVar(&options.AzureClientID, "azure-client-id", env.GetEnvString("AZURE_CLIENT_ID", ""), "The client id of the Azure service principal, whose secret is read from AZURE_CLIENT_SECRET")
+	flagSet.StringVar(&options.AzureEndpoint, "azure-dns-endpoint", env.GetEnvString("AZURE_DNS_ENDPOINT", ""), "Override the Azure Resource Manager endpoint, e.g. to target a local stand-in (requests are not authenticated)")
+	// In-memory DNS options
+	flagSet.StringVar(&options.InMemoryDNSAddress, "inmemory-dns-address", env.GetEnvString("GLBC_INMEMORY_DNS_ADDRESS", ""), "The address the in-memory DNS provider records are served on over UDP and TCP, e.g. 127.0.0.1:1053, not served if empty")
+	flagSet.StringVar(&options.DNSNameservers, "dns-nameservers", env.GetEnvString("GLBC_DNS_NAMESERVERS", ""), "Comma separated addresses of the name servers the hosts of the Ingresses and the custom domains are resolved with, e.g. 127.0.0.1:1053 to resolve the records served by the in-memory DNS provider, the ones of /etc/resolv.conf if empty")
+	// RFC 2136 options
+	flagSet.StringVar(&options.RFC2136Server, "rfc2136-server", env.GetEnvString("RFC2136_SERVER", ""), "The address of the authoritative server DNS updates are sent to, e.g. ns1.example.com:53")
+	flagSet.StringVar(&options.RFC2136TSIGKeyName, "rfc2136-tsig-key-name", env.GetEnvString("RFC2136_TSIG_KEY_NAME", ""), "The name of the TSIG key signing the DNS updates, whose base64 encoded secret is read from RFC2136_TSIG_SECRET")
+	flagSet.StringVar(&options.RFC2136TSIGAlgorithm, "rfc2136-tsig-algorithm", env.GetEnvString("RFC2136_TSIG_ALGORITHM", "hmac-sha256"), "The algorithm of the TSIG key, one of [hmac-sha1, hmac-sha256, hmac-sha512]")
 	//  Observability options
 	flagSet.IntVar(&options.MonitoringPort, "monitoring-port", 8080, "The port of the metrics endpoint (can be set to \"0\" to disable the metrics serving)")
 
@@ -180,6 +275,13 @@ func main() {
 
 	certificateInformerFactory := certmaninformer.NewSharedInformerFactoryWithOptions(certClient, resyncPeriod, certmaninformer.WithNamespace(namespace))
 
+	workspaceDomains, err := parseDomainMapping(options.DomainMapping)
+	exitOnError(err, "Failed to parse domain mapping")
+	domains := ingress.DomainMapping{
+		Default:    options.Domain,
+		Workspaces: workspaceDomains,
+	}
+
 	var certProvider tls.Provider
 	if options.TLSProviderEnabled {
 
@@ -198,7 +300,7 @@ func main() {
 			CertProvider:  tlsCertProvider,
 			Region:        options.Region,
 			K8sClient:     defaultKubeClient,
-			ValidDomains:  []string{options.Domain},
+			ValidDomains:  domains.Domains(),
 			CertificateNS: namespace,
 		})
 		exitOnError(err, "Failed to create cert provider")
@@ -207,10 +309,42 @@ func main() {
 
 	}
 
+	// kcp client to the compute workspace, providing the locations of the sync
+	// targets, which only the aws provider routes by
+	var syncTargetInformerFactory kcpinformers.SharedInformerFactory
+	if options.DNSProvider == "aws" {
+		kcpClient, err := kcpclient.NewClusterForConfig(kcpClientConfig)
+		exitOnError(err, "Failed to create KCP client")
+		syncTargetInformerFactory = kcpinformers.NewSharedInformerFactory(kcpClient.Cluster(logicalcluster.New(options.ComputeWorkspace)), resyncPeriod)
+	}
+
+	routingPolicy, err := ingress.ParseRoutingPolicy(options.DNSRoutingPolicy)
+	exitOnError(err, "Failed to parse DNS routing policy")
+
+	drainSchedule, err := workloadMigration.ParseDrainSchedule(options.WorkloadDrainSchedule)
+	exitOnError(err, "Failed to parse workload drain schedule")
+	drainStrategy := &workloadMigration.DrainStrategy{
+		Schedule:     drainSchedule,
+		StepInterval: options.WorkloadDrainStepInterval,
+	}
+
 	glbcKubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(defaultKubeClient, time.Minute, informers.WithNamespace(namespace))
 
 	exitOnError(err, "Failed to create TLS certificate controller")
 
+	// The custom hosts are trusted from the domains verified by GLBC, recorded in the GLBC namespace
+	var verifiedDomains *domainverification.VerifiedDomains
+	verificationSecret := os.Getenv("GLBC_DOMAIN_VERIFICATION_SECRET")
+	if options.EnableCustomHosts {
+		if verificationSecret == "" {
+			exitOnError(errors.New("GLBC_DOMAIN_VERIFICATION_SECRET must be set"), "Failed to enable custom hosts")
+		}
+		verifiedDomains = domainverification.NewVerifiedDomains(defaultKubeClient, glbcKubeInformerFactory, namespace)
+	}
+
+	hostResolver := net.NewDefaultHostResolver()
+	hostResolver.Nameservers = splitList(options.DNSNameservers)
+
 	ingressController := ingress.NewController(&ingress.ControllerConfig{
 		KubeClient:               kcpKubeClient,
 		DnsRecordClient:          kcpKuadrantClient,
@@ -218,33 +352,95 @@ func main() {
 		KCPSharedInformerFactory: kcpKubeInformerFactory,
 		CertificateInformer:      certificateInformerFactory,
 		GlbcInformerFactory:      glbcKubeInformerFactory,
-		Domain:                   options.Domain,
+		Domains:                  domains,
 		CertProvider:             certProvider,
-		HostResolver:             net.NewDefaultHostResolver(),
+		HostResolver:             hostResolver,
 		// For testing. TODO: Make configurable through flags/env variable
 		// HostResolver: &net.ConfigMapHostResolver{
 		// 	Name:      "hosts",
 		// 	Namespace: "default",
 		// },
 		CustomHostsEnabled: options.EnableCustomHosts,
+		VerifiedDomains:    verifiedDomains,
+		SyncTargetInformer: syncTargetInformerFactory,
+		RoutingPolicy:      routingPolicy,
+		DrainStrategy:      drainStrategy,
 	})
 
+	dnsZoneTags, err := labels.ConvertSelectorToLabelsMap(options.DNSZoneTags)
+	exitOnError(err, "Failed to parse DNS zone tags")
+
 	dnsRecordController, err := dns.NewController(&dns.ControllerConfig{
-		DnsRecordClient:       kcpKuadrantClient,
-		SharedInformerFactory: kcpKuadrantInformerFactory,
-		DNSProvider:           options.DNSProvider,
+		DnsRecordClient:           kcpKuadrantClient,
+		SharedInformerFactory:     kcpKuadrantInformerFactory,
+		DNSProvider:               options.DNSProvider,
+		DNSZoneIDs:                splitList(options.DNSZoneIDs),
+		DNSZoneTags:               dnsZoneTags,
+		DNSOwnerID:                options.DNSOwnerID,
+		DriftCheckInterval:        options.DNSDriftCheckInterval,
+		DryRun:                    options.DNSDryRun,
+		HealthProbe:               options.DNSHealthProbe,
+		HealthProbeInterval:       options.DNSHealthProbeInterval,
+		HealthCheckGCInterval:     options.DNSHealthCheckGCInterval,
+		HealthCheckGCGracePeriod:  options.DNSHealthCheckGCGracePeriod,
+		HealthCheckGCDryRun:       options.DNSHealthCheckGCDryRun,
+		KubeClient:                kcpKubeClient,
+		KubeSharedInformerFactory: kcpKubeInformerFactory,
+		Google: googledns.Config{
+			Project:  options.GoogleProject,
+			Endpoint: options.GoogleEndpoint,
+		},
+		Azure: azuredns.Config{
+			SubscriptionID: options.AzureSubscriptionID,
+			ResourceGroup:  options.AzureResourceGroup,
+			TenantID:       options.AzureTenantID,
+			ClientID:       options.AzureClientID,
+			ClientSecret:   os.Getenv("AZURE_CLIENT_SECRET"),
+			Endpoint:       options.AzureEndpoint,
+		},
+		InMemoryDNSAddress: options.InMemoryDNSAddress,
+		RFC2136: rfc2136dns.Config{
+			Server:        options.RFC2136Server,
+			TSIGKeyName:   options.RFC2136TSIGKeyName,
+			TSIGSecret:    os.Getenv("RFC2136_TSIG_SECRET"),
+			TSIGAlgorithm: options.RFC2136TSIGAlgorithm,
+		},
 	})
 	exitOnError(err, "Failed to create DNSRecord controller")
+	if handler := dnsRecordController.PlanHandler(); handler != nil {
+		metricsServer.Handle(dnsPlanEndpoint, handler)
+	}
+
+	var domainVerificationController *domainverification.Controller
+	if options.EnableCustomHosts {
+		domainVerificationController, err = domainverification.NewController(&domainverification.ControllerConfig{
+			DomainVerificationClient: kcpKuadrantClient,
+			SharedInformerFactory:    kcpKuadrantInformerFactory,
+			HostResolver:             hostResolver,
+			VerifiedDomains:          verifiedDomains,
+			Secret:                   verificationSecret,
+		})
+		exitOnError(err, "Failed to create DomainVerification controller")
+	}
+
+	healthCheckPolicyController, err := healthcheckpolicy.NewController(&healthcheckpolicy.ControllerConfig{
+		HealthCheckPolicyClient:   kcpKuadrantClient,
+		SharedInformerFactory:     kcpKuadrantInformerFactory,
+		KubeSharedInformerFactory: kcpKubeInformerFactory,
+	})
+	exitOnError(err, "Failed to create HealthCheckPolicy controller")
 
 	serviceController, err := service.NewController(&service.ControllerConfig{
 		ServicesClient:        kcpKubeClient,
 		SharedInformerFactory: kcpKubeInformerFactory,
+		DrainStrategy:         drainStrategy,
 	})
 	exitOnError(err, "Failed to create Service controller")
 
 	deploymentController, err := deployment.NewController(&deployment.ControllerConfig{
 		DeploymentClient:      kcpKubeClient,
 		SharedInformerFactory: kcpKubeInformerFactory,
+		DrainStrategy:         drainStrategy,
 	})
 	exitOnError(err, "Failed to create Deployment controller")
 
@@ -254,16 +450,27 @@ func main() {
 	kcpKuadrantInformerFactory.Start(ctx.Done())
 	kcpKuadrantInformerFactory.WaitForCacheSync(ctx.Done())
 
+	if syncTargetInformerFactory != nil {
+		syncTargetInformerFactory.Start(ctx.Done())
+		syncTargetInformerFactory.WaitForCacheSync(ctx.Done())
+	}
+
 	if options.TLSProviderEnabled {
 		certificateInformerFactory.Start(ctx.Done())
 		certificateInformerFactory.WaitForCacheSync(ctx.Done())
+	}
+	if options.TLSProviderEnabled || options.EnableCustomHosts {
 		glbcKubeInformerFactory.Start(ctx.Done())
 		glbcKubeInformerFactory.WaitForCacheSync(ctx.Done())
 	}
 
 	start(gCtx, ingressController)
 	start(gCtx, dnsRecordController)
+	if domainVerificationController != nil {
+		start(gCtx, domainVerificationController)
+	}
 
+	start(gCtx, healthCheckPolicyController)
 	start(gCtx, serviceController)
 	start(gCtx, deploymentController)
 
@@ -295,6 +502,34 @@ func exitOnError(err error, msg string) {
 	}
 }
 
+// splitList returns the non-empty elements of a comma separated list.
+func splitList(list string) []string {
+	var elements []string
+	for _, element := range strings.Split(list, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// parseDomainMapping parses comma separated workspace=domain pairs.
+func parseDomainMapping(mapping string) (map[string]string, error) {
+	domains := map[string]string{}
+	for _, pair := range splitList(mapping) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid domain mapping %q, expected workspace=domain", pair)
+		}
+		workspace, domain := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if _, valid := logicalcluster.NewValidated(workspace); !valid {
+			return nil, fmt.Errorf("invalid workspace %q in domain mapping", workspace)
+		}
+		domains[workspace] = domain
+	}
+	return domains, nil
+}
+
 func getAPIExportVirtualWorkspaceURL(config *rest.Config, exportName, exportPath string) string {
 	cfg := rest.CopyConfig(config)
 	u, err := url.Parse(cfg.Host)
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domain`
+// +kubebuilder:printcolumn:name="Verified",type=boolean,JSONPath=`.status.verified`
+
+// DomainVerification proves the ownership of a custom domain by a workspace,
+// so that the domain and its sub-domains can be used as Ingress hosts.
+type DomainVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the specification of the domain to verify.
+	Spec DomainVerificationSpec `json:"spec"`
+	// status is the most recently observed status of the verification.
+	Status DomainVerificationStatus `json:"status,omitempty"`
+}
+
+// DomainVerificationSpec contains the domain to verify.
+type DomainVerificationSpec struct {
+	// domain is the custom domain whose ownership is verified, e.g. `example.com`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Domain string `json:"domain"`
+}
+
+// DomainVerificationStatus is the most recently observed status of the
+// verification.
+type DomainVerificationStatus struct {
+	// token is the value of the TXT record that must be published, in the
+	// domain DNS zone, to prove its ownership.
+	// +optional
+	Token string `json:"token,omitempty"`
+
+	// recordName is the name of the TXT record holding the token, e.g.
+	// `_kuadrant-verification.example.com`.
+	// +optional
+	RecordName string `json:"recordName,omitempty"`
+
+	// verified is whether the ownership of the domain has been verified.
+	// The ownership of a verified domain is checked again periodically.
+	Verified bool `json:"verified"`
+
+	// message describes the outcome of the last verification.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// lastChecked is the time of the last verification.
+	// +optional
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+
+	// nextCheck is the time of the next verification.
+	// +optional
+	NextCheck *metav1.Time `json:"nextCheck,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DomainVerificationList contains a list of domain verifications.
+type DomainVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DomainVerification `json:"items"`
+}
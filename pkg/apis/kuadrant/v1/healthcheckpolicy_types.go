@@ -0,0 +1,148 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+// +kubebuilder:printcolumn:name="Enforced",type=string,JSONPath=`.status.conditions[?(@.type=="Enforced")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// HealthCheckPolicy configures the health checks of the endpoints of the
+// Ingresses it targets, within its namespace. It takes precedence over the
+// health check annotations of the Ingresses.
+type HealthCheckPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the specification of the health checks, and of the Ingresses they
+	// apply to.
+	Spec HealthCheckPolicySpec `json:"spec"`
+	// status is the most recently observed status of the policy.
+	Status HealthCheckPolicyStatus `json:"status,omitempty"`
+}
+
+// HealthCheckProtocol is the protocol the endpoints are checked with.
+// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP
+type HealthCheckProtocol string
+
+const (
+	HealthCheckProtocolHTTP  HealthCheckProtocol = "HTTP"
+	HealthCheckProtocolHTTPS HealthCheckProtocol = "HTTPS"
+	HealthCheckProtocolTCP   HealthCheckProtocol = "TCP"
+)
+
+// HealthCheckPolicySpec contains the Ingresses the policy applies to, and the
+// configuration of their health checks. Exactly one of targetRef and selector
+// must be set.
+type HealthCheckPolicySpec struct {
+	// targetRef is the reference to the Ingress the policy applies to, within
+	// the namespace of the policy.
+	// +optional
+	TargetRef *HealthCheckPolicyTargetReference `json:"targetRef,omitempty"`
+
+	// selector selects the Ingresses the policy applies to by their labels,
+	// within the namespace of the policy.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// path is the path of the health endpoint of the service, requested by the
+	// HTTP and HTTPS health checks. Defaults to `/`.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// port is the port the endpoints are checked on. Defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// protocol is the protocol the endpoints are checked with. Defaults to
+	// `HTTP`.
+	// +optional
+	Protocol *HealthCheckProtocol `json:"protocol,omitempty"`
+
+	// failureThreshold is the number of consecutive checks an endpoint must
+	// fail to be considered unhealthy, or pass to be considered healthy again.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	FailureThreshold *int64 `json:"failureThreshold,omitempty"`
+
+	// searchString is the string the response body of the HTTP and HTTPS
+	// health checks must contain for the endpoint to be healthy.
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	SearchString string `json:"searchString,omitempty"`
+
+	// requestInterval is the number of seconds between two checks of an
+	// endpoint, either 10 or 30. Defaults to 30.
+	// +kubebuilder:validation:Enum=10;30
+	// +optional
+	RequestInterval *int64 `json:"requestInterval,omitempty"`
+
+	// regions are the regions the endpoints are checked from. Defaults to all
+	// the regions of the DNS provider.
+	// +kubebuilder:validation:MinItems=3
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// enableSNI is whether the host name of the endpoints is sent as the TLS
+	// server name by the HTTPS health checks. Defaults to true.
+	// +optional
+	EnableSNI *bool `json:"enableSNI,omitempty"`
+
+	// inverted is whether the endpoints are considered unhealthy when they
+	// pass the health checks, and healthy when they fail them.
+	// +optional
+	Inverted bool `json:"inverted,omitempty"`
+}
+
+// HealthCheckPolicyTargetReference identifies an Ingress within the namespace
+// of the policy.
+type HealthCheckPolicyTargetReference struct {
+	// name is the name of the Ingress.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+}
+
+// HealthCheckPolicyStatus is the most recently observed status of the policy.
+type HealthCheckPolicyStatus struct {
+	// observedGeneration is the most recently observed generation of the
+	// policy.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions are the Accepted condition, reporting whether the spec is
+	// valid, and the Enforced condition, reporting whether the policy applies
+	// to any Ingress.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// HealthCheckPolicyAcceptedConditionType means the spec of the policy is
+	// valid.
+	HealthCheckPolicyAcceptedConditionType = "Accepted"
+
+	// HealthCheckPolicyEnforcedConditionType means the policy is the
+	// effective policy of at least one Ingress, i.e. it targets the Ingress
+	// and is not overridden by a policy of higher precedence.
+	HealthCheckPolicyEnforcedConditionType = "Enforced"
+)
+
+// +kubebuilder:object:root=true
+
+// HealthCheckPolicyList contains a list of health check policies.
+type HealthCheckPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HealthCheckPolicy `json:"items"`
+}
@@ -27,6 +27,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&DNSRecord{},
 		&DNSRecordList{},
+		&DomainVerification{},
+		&DomainVerificationList{},
+		&HealthCheckPolicy{},
+		&HealthCheckPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -53,6 +53,14 @@ type TTL int64
 // it is then stored in a persistent storage via serialization
 type Labels map[string]string
 
+// SyncTargetsLabel is the label of the endpoints holding the comma separated
+// names of the sync targets they route the traffic to.
+const SyncTargetsLabel = "kuadrant.dev/sync-targets"
+
+// CustomHostLabel is the label of the endpoints of the custom hosts, which are
+// only published to the zones of their domain.
+const CustomHostLabel = "kuadrant.dev/custom-host"
+
 // ProviderSpecific holds configuration which is specific to individual DNS providers
 type ProviderSpecific []ProviderSpecificProperty
 
@@ -94,6 +102,58 @@ type DNSRecordStatus struct {
 	// needs to retry the update for that specific zone.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// healthChecks are the most recently observed health of the endpoints
+	// with a health check.
+	// +optional
+	HealthChecks []EndpointHealthStatus `json:"healthChecks,omitempty"`
+}
+
+// EndpointHealthStatus is the health of an endpoint, as observed by its health
+// check.
+type EndpointHealthStatus struct {
+	// dnsName is the hostname of the endpoint.
+	DNSName string `json:"dnsName"`
+	// setIdentifier is the identifier of the endpoint within its record set.
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// healthCheckID is the identifier of the health check of the endpoint, if
+	// it is checked by the DNS provider.
+	// +optional
+	HealthCheckID string `json:"healthCheckID,omitempty"`
+	// healthy is whether the endpoint is healthy. An endpoint that has not
+	// been checked yet is considered healthy.
+	Healthy bool `json:"healthy"`
+	// message describes why the endpoint is unhealthy.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// lastCheckedTime is the time of the latest check of the endpoint.
+	// +optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
+	// observations are the latest observations of each of the checkers of the
+	// endpoint, e.g. the Route53 health checkers in each region.
+	// +optional
+	Observations []HealthCheckObservation `json:"observations,omitempty"`
+}
+
+// HealthCheckObservation is the latest observation of an endpoint by one of the
+// checkers of its health check.
+type HealthCheckObservation struct {
+	// region is the region of the checker.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// ipAddress is the IP address of the checker.
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+	// healthy is whether the checker observed the endpoint as healthy.
+	Healthy bool `json:"healthy"`
+	// status is the status reported by the checker, e.g. the status code of
+	// the endpoint, or the reason the check failed.
+	// +optional
+	Status string `json:"status,omitempty"`
+	// lastCheckedTime is the time of the observation.
+	// +optional
+	LastCheckedTime *metav1.Time `json:"lastCheckedTime,omitempty"`
 }
 
 // DNSZone is used to define a DNS hosted zone.
@@ -114,10 +174,19 @@ type DNSZone struct {
 	// tags can be used to query the DNS hosted zone.
 	//
 	// on AWS, resourcegroupstaggingapi [1] can be used to fetch a zone using `Tags` as tag-filters,
+	// on Azure, zones can be fetched using `Tags` as the zone tags,
+	// on GCP, zones can be fetched using `Tags` as the managed zone labels.
 	//
 	// [1]: https://docs.aws.amazon.com/cli/latest/reference/resourcegroupstaggingapi/get-resources.html#options
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// dnsName is the domain of the DNS hosted zone, e.g. `example.com`.
+	//
+	// Records are only published to the zone(s) whose domain is the longest
+	// suffix of their DNS name. A zone without a domain is used for all records.
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
 }
 
 // DNSZoneStatus is the status of a record within a specific zone.
@@ -142,6 +211,33 @@ type DNSZoneStatus struct {
 var (
 	// Failed means the record is not available within a zone.
 	DNSRecordFailedConditionType = "Failed"
+
+	// Drifted means the records published within a zone were found to differ
+	// from the record, and have been published again.
+	DNSRecordDriftedConditionType = "Drifted"
+
+	// Propagated means the changes to the records within a zone have propagated
+	// to all the name servers of the zone. It is only reported by the providers
+	// tracking the propagation of the changes.
+	DNSRecordPropagatedConditionType = "Propagated"
+
+	// Planned means the changes to the records within a zone have been planned,
+	// but not applied, as the controller runs in dry run mode.
+	DNSRecordPlannedConditionType = "Planned"
+
+	// FailedOver means the primary failover records within a zone are
+	// unhealthy, so that the traffic is served by the secondary ones.
+	DNSRecordFailedOverConditionType = "FailedOver"
+
+	// Healthy means the endpoints probed by GLBC within a zone are healthy.
+	// It is only reported when the endpoints are probed by GLBC.
+	DNSRecordHealthyConditionType = "Healthy"
+
+	// HealthChecksConfigured means the health checks of the endpoints within
+	// a zone are configured as per the HealthCheckPolicy of the Ingress of the
+	// record, or its annotations. It is only reported when the record
+	// configures health checks.
+	DNSRecordHealthChecksConfiguredConditionType = "HealthChecksConfigured"
 )
 
 // DNSZoneCondition is just the standard condition fields.
@@ -160,7 +256,7 @@ type DNSZoneCondition struct {
 }
 
 // DNSRecordType is a DNS resource record type.
-// +kubebuilder:validation:Enum=CNAME;A
+// +kubebuilder:validation:Enum=CNAME;A;AAAA;TXT
 type DNSRecordType string
 
 const (
@@ -169,6 +265,12 @@ const (
 
 	// ARecordType is an RFC 1035 A record.
 	ARecordType DNSRecordType = "A"
+
+	// AAAARecordType is an RFC 3596 AAAA record.
+	AAAARecordType DNSRecordType = "AAAA"
+
+	// TXTRecordType is an RFC 1035 TXT record.
+	TXTRecordType DNSRecordType = "TXT"
 )
 
 // +kubebuilder:object:root=true
@@ -187,9 +289,9 @@ func (endpoint *Endpoint) SetProviderSpecific(name, value string) {
 		endpoint.ProviderSpecific = ProviderSpecific{}
 	}
 
-	for _, pair := range endpoint.ProviderSpecific {
-		if pair.Name == name {
-			property = &pair
+	for i := range endpoint.ProviderSpecific {
+		if endpoint.ProviderSpecific[i].Name == name {
+			property = &endpoint.ProviderSpecific[i]
 		}
 	}
 
@@ -234,7 +336,8 @@ func (endpoint *Endpoint) DeleteProviderSpecific(name string) bool {
 }
 
 func (endpoint *Endpoint) GetAddress() (string, bool) {
-	if endpoint.SetIdentifier == "" || len(endpoint.Targets) == 0 {
+	// The targets of CNAME records are names, not addresses
+	if endpoint.SetIdentifier == "" || len(endpoint.Targets) == 0 || endpoint.RecordType == string(CNAMERecordType) {
 		return "", false
 	}
 
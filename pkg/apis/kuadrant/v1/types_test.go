@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetProviderSpecific(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *Endpoint
+		want     ProviderSpecific
+	}{
+		{
+			name:     "no properties",
+			endpoint: &Endpoint{},
+			want:     ProviderSpecific{{Name: "aws/weight", Value: "120"}},
+		},
+		{
+			name:     "other property",
+			endpoint: &Endpoint{ProviderSpecific: ProviderSpecific{{Name: "aws/region", Value: "us-east-1"}}},
+			want:     ProviderSpecific{{Name: "aws/region", Value: "us-east-1"}, {Name: "aws/weight", Value: "120"}},
+		},
+		{
+			name:     "existing property",
+			endpoint: &Endpoint{ProviderSpecific: ProviderSpecific{{Name: "aws/weight", Value: "0"}, {Name: "aws/region", Value: "us-east-1"}}},
+			want:     ProviderSpecific{{Name: "aws/weight", Value: "120"}, {Name: "aws/region", Value: "us-east-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.endpoint.SetProviderSpecific("aws/weight", "120")
+			if !reflect.DeepEqual(tt.endpoint.ProviderSpecific, tt.want) {
+				t.Errorf("SetProviderSpecific() = %v, want %v", tt.endpoint.ProviderSpecific, tt.want)
+			}
+		})
+	}
+}
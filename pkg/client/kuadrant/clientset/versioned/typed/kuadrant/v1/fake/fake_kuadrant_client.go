@@ -16,6 +16,14 @@ func (c *FakeKuadrantV1) DNSRecords(namespace string) v1.DNSRecordInterface {
 	return &FakeDNSRecords{c, namespace}
 }
 
+func (c *FakeKuadrantV1) DomainVerifications() v1.DomainVerificationInterface {
+	return &FakeDomainVerifications{c}
+}
+
+func (c *FakeKuadrantV1) HealthCheckPolicies(namespace string) v1.HealthCheckPolicyInterface {
+	return &FakeHealthCheckPolicies{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKuadrantV1) RESTClient() rest.Interface {
@@ -3,3 +3,7 @@
 package v1
 
 type DNSRecordExpansion interface{}
+
+type DomainVerificationExpansion interface{}
+
+type HealthCheckPolicyExpansion interface{}
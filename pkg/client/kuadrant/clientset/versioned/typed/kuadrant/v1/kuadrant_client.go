@@ -14,6 +14,8 @@ import (
 type KuadrantV1Interface interface {
 	RESTClient() rest.Interface
 	DNSRecordsGetter
+	DomainVerificationsGetter
+	HealthCheckPoliciesGetter
 }
 
 // KuadrantV1Client is used to interact with features provided by the kuadrant.dev group.
@@ -26,6 +28,14 @@ func (c *KuadrantV1Client) DNSRecords(namespace string) DNSRecordInterface {
 	return newDNSRecords(c, namespace)
 }
 
+func (c *KuadrantV1Client) DomainVerifications() DomainVerificationInterface {
+	return newDomainVerifications(c)
+}
+
+func (c *KuadrantV1Client) HealthCheckPolicies(namespace string) HealthCheckPolicyInterface {
+	return newHealthCheckPolicies(c, namespace)
+}
+
 // NewForConfig creates a new KuadrantV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
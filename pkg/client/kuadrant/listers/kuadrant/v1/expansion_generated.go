@@ -9,3 +9,15 @@ type DNSRecordListerExpansion interface{}
 // DNSRecordNamespaceListerExpansion allows custom methods to be added to
 // DNSRecordNamespaceLister.
 type DNSRecordNamespaceListerExpansion interface{}
+
+// DomainVerificationListerExpansion allows custom methods to be added to
+// DomainVerificationLister.
+type DomainVerificationListerExpansion interface{}
+
+// HealthCheckPolicyListerExpansion allows custom methods to be added to
+// HealthCheckPolicyLister.
+type HealthCheckPolicyListerExpansion interface{}
+
+// HealthCheckPolicyNamespaceListerExpansion allows custom methods to be added to
+// HealthCheckPolicyNamespaceLister.
+type HealthCheckPolicyNamespaceListerExpansion interface{}
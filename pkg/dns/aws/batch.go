@@ -0,0 +1,197 @@
+package aws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+const (
+	// defaultChangeBatchWindow is the duration the changes to the records of a
+	// hosted zone are collected for, before being applied in batches.
+	defaultChangeBatchWindow = 500 * time.Millisecond
+
+	// See https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/DNSLimitations.html#limits-api-requests-changeresourcerecordsets
+	// The UPSERT changes count twice towards these limits.
+
+	// maxBatchRecords is the maximum number of resource records in a batch.
+	maxBatchRecords = 1000
+	// maxBatchValueLength is the maximum number of characters of the values of
+	// the resource records in a batch.
+	maxBatchValueLength = 32000
+)
+
+// changeSubmitter applies a batch of changes to the records of a hosted zone.
+type changeSubmitter interface {
+	ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+}
+
+type changeResult struct {
+	info *route53.ChangeInfo
+	err  error
+}
+
+// changeRequest are the changes to the records of a hosted zone, on behalf of
+// a DNSRecord, that are applied atomically.
+type changeRequest struct {
+	changes []*route53.Change
+	done    chan changeResult
+}
+
+// changeBatcher merges the changes to the records of each hosted zone that are
+// submitted within a short window, so that they are applied with as few
+// requests to Route53 as possible.
+type changeBatcher struct {
+	client  changeSubmitter
+	window  time.Duration
+	logger  logr.Logger
+	lock    sync.Mutex
+	pending map[string][]*changeRequest
+}
+
+func newChangeBatcher(client changeSubmitter, window time.Duration, logger logr.Logger) *changeBatcher {
+	return &changeBatcher{
+		client:  client,
+		window:  window,
+		logger:  logger,
+		pending: map[string][]*changeRequest{},
+	}
+}
+
+// Submit applies the changes to the records of the hosted zone, along with the
+// other changes submitted within the batch window, and returns the change info
+// of the batch they were applied with.
+func (b *changeBatcher) Submit(zoneID string, changes []*route53.Change) (*route53.ChangeInfo, error) {
+	request := &changeRequest{changes: changes, done: make(chan changeResult, 1)}
+
+	b.lock.Lock()
+	first := len(b.pending[zoneID]) == 0
+	b.pending[zoneID] = append(b.pending[zoneID], request)
+	b.lock.Unlock()
+
+	if first {
+		time.AfterFunc(b.window, func() { b.flush(zoneID) })
+	}
+
+	result := <-request.done
+	return result.info, result.err
+}
+
+func (b *changeBatcher) flush(zoneID string) {
+	b.lock.Lock()
+	requests := b.pending[zoneID]
+	delete(b.pending, zoneID)
+	b.lock.Unlock()
+
+	for _, batch := range packChangeRequests(requests) {
+		b.apply(zoneID, batch)
+	}
+}
+
+// apply applies the changes of the requests in a single batch. If the batch is
+// rejected, it is split, so that the requests with valid changes are applied,
+// and the error is only reported for the faulty ones.
+func (b *changeBatcher) apply(zoneID string, requests []*changeRequest) {
+	var changes []*route53.Change
+	for _, request := range requests {
+		changes = append(changes, request.changes...)
+	}
+
+	output, err := b.client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+	})
+	if err != nil && len(requests) > 1 && isInvalidChangeBatch(err) {
+		b.logger.Info("Splitting rejected batch of changes", "zone", zoneID, "requests", len(requests), "error", err)
+		b.apply(zoneID, requests[:len(requests)/2])
+		b.apply(zoneID, requests[len(requests)/2:])
+		return
+	}
+	route53ChangeBatchSize.Observe(float64(len(changes)))
+
+	result := changeResult{err: err}
+	if err == nil {
+		result.info = output.ChangeInfo
+		b.logger.V(1).Info("Applied batch of changes", "zone", zoneID, "requests", len(requests), "changes", len(changes), "change", aws.StringValue(output.ChangeInfo.Id))
+	}
+	for _, request := range requests {
+		request.done <- result
+	}
+}
+
+type changeBatch struct {
+	requests    []*changeRequest
+	records     int
+	valueLength int
+	recordSets  map[string]bool
+}
+
+// packChangeRequests packs the requests into batches within the Route53
+// limits, in their submission order. The changes of a request are never split
+// across batches, and a batch never changes the same record set twice, which
+// Route53 would reject.
+func packChangeRequests(requests []*changeRequest) [][]*changeRequest {
+	var batches [][]*changeRequest
+	current := &changeBatch{recordSets: map[string]bool{}}
+	for _, request := range requests {
+		records, valueLength := changesSize(request.changes)
+		conflicts := false
+		for _, change := range request.changes {
+			if current.recordSets[recordSetKey(change.ResourceRecordSet)] {
+				conflicts = true
+			}
+		}
+		if len(current.requests) > 0 && (conflicts ||
+			current.records+records > maxBatchRecords ||
+			current.valueLength+valueLength > maxBatchValueLength) {
+			batches = append(batches, current.requests)
+			current = &changeBatch{recordSets: map[string]bool{}}
+		}
+		current.requests = append(current.requests, request)
+		current.records += records
+		current.valueLength += valueLength
+		for _, change := range request.changes {
+			current.recordSets[recordSetKey(change.ResourceRecordSet)] = true
+		}
+	}
+	if len(current.requests) > 0 {
+		batches = append(batches, current.requests)
+	}
+	return batches
+}
+
+// changesSize returns the number of resource records, and the length of their
+// values, counted towards the Route53 limits.
+func changesSize(changes []*route53.Change) (int, int) {
+	records, valueLength := 0, 0
+	for _, change := range changes {
+		weight := 1
+		if aws.StringValue(change.Action) == route53.ChangeActionUpsert {
+			weight = 2
+		}
+		for _, record := range change.ResourceRecordSet.ResourceRecords {
+			records += weight
+			valueLength += weight * len(aws.StringValue(record.Value))
+		}
+	}
+	return records, valueLength
+}
+
+func recordSetKey(recordSet *route53.ResourceRecordSet) string {
+	return aws.StringValue(recordSet.Name) + "/" + aws.StringValue(recordSet.Type) + "/" + aws.StringValue(recordSet.SetIdentifier)
+}
+
+func isInvalidChangeBatch(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case route53.ErrCodeInvalidChangeBatch, route53.ErrCodeInvalidInput:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+)
+
+// recordingSubmitter records the batches of changes, and rejects the ones
+// changing the records of invalid.example.com.
+type recordingSubmitter struct {
+	lock    sync.Mutex
+	batches [][]string
+}
+
+func (s *recordingSubmitter) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	var names []string
+	for _, change := range input.ChangeBatch.Changes {
+		if aws.StringValue(change.ResourceRecordSet.Name) == "invalid.example.com" {
+			return nil, awserr.New(route53.ErrCodeInvalidChangeBatch, "invalid change", nil)
+		}
+		names = append(names, aws.StringValue(change.ResourceRecordSet.Name))
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.batches = append(s.batches, names)
+	return &route53.ChangeResourceRecordSetsOutput{
+		ChangeInfo: &route53.ChangeInfo{Id: aws.String(fmt.Sprintf("/change/C%d", len(s.batches)))},
+	}, nil
+}
+
+func upsert(name string, values ...string) *route53.Change {
+	var records []*route53.ResourceRecord
+	for _, value := range values {
+		records = append(records, &route53.ResourceRecord{Value: aws.String(value)})
+	}
+	return &route53.Change{
+		Action: aws.String(route53.ChangeActionUpsert),
+		ResourceRecordSet: &route53.ResourceRecordSet{
+			Name:            aws.String(name),
+			Type:            aws.String(route53.RRTypeA),
+			ResourceRecords: records,
+		},
+	}
+}
+
+func TestChangeBatcher(t *testing.T) {
+	submitter := &recordingSubmitter{}
+	batcher := newChangeBatcher(submitter, 50*time.Millisecond, logr.Discard())
+
+	names := []string{"a.example.com", "b.example.com", "invalid.example.com", "c.example.com"}
+	results := make([]changeResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			info, err := batcher.Submit("Z1", []*route53.Change{upsert(name, "1.1.1.1")})
+			results[i] = changeResult{info: info, err: err}
+		}(i, name)
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		if name == "invalid.example.com" {
+			if results[i].err == nil {
+				t.Errorf("expected the invalid change to fail")
+			}
+			continue
+		}
+		if results[i].err != nil || results[i].info == nil {
+			t.Errorf("expected the change to %s to be applied, got %v", name, results[i].err)
+		}
+	}
+
+	applied := 0
+	for _, batch := range submitter.batches {
+		applied += len(batch)
+	}
+	if applied != 3 {
+		t.Errorf("expected 3 changes to be applied, got %v", submitter.batches)
+	}
+	if len(submitter.batches) >= 3 {
+		t.Errorf("expected the valid changes to be batched, got %v", submitter.batches)
+	}
+}
+
+func TestPackChangeRequests(t *testing.T) {
+	request := func(changes ...*route53.Change) *changeRequest {
+		return &changeRequest{changes: changes}
+	}
+	values := func(n int) []string {
+		var values []string
+		for i := 0; i < n; i++ {
+			values = append(values, fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+		}
+		return values
+	}
+	longValues := func(n int) []string {
+		var values []string
+		for i := 0; i < n; i++ {
+			values = append(values, fmt.Sprintf("%d%s", i, strings.Repeat("a", 999)))
+		}
+		return values
+	}
+
+	tests := []struct {
+		name     string
+		requests []*changeRequest
+		expected []int
+	}{
+		{
+			name: "merged",
+			requests: []*changeRequest{
+				request(upsert("a.example.com", "1.1.1.1")),
+				request(upsert("b.example.com", "1.1.1.1"), upsert("c.example.com", "1.1.1.1")),
+			},
+			expected: []int{2},
+		},
+		{
+			name: "same record set",
+			requests: []*changeRequest{
+				request(upsert("a.example.com", "1.1.1.1")),
+				request(upsert("a.example.com", "2.2.2.2")),
+			},
+			expected: []int{1, 1},
+		},
+		{
+			name: "record limit",
+			requests: []*changeRequest{
+				request(upsert("a.example.com", values(300)...)),
+				request(upsert("b.example.com", values(300)...)),
+				request(upsert("c.example.com", values(100)...)),
+			},
+			expected: []int{1, 2},
+		},
+		{
+			name: "value length limit",
+			requests: []*changeRequest{
+				request(upsert("a.example.com", longValues(10)...)),
+				request(upsert("b.example.com", longValues(5)...)),
+				request(upsert("c.example.com", longValues(5)...)),
+			},
+			expected: []int{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := packChangeRequests(tt.requests)
+			var sizes []int
+			for _, batch := range batches {
+				sizes = append(sizes, len(batch))
+			}
+			if fmt.Sprint(sizes) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected batches of %v requests, got %v", tt.expected, sizes)
+			}
+		})
+	}
+}
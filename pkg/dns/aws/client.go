@@ -26,6 +26,18 @@ import (
 
 type InstrumentedRoute53 struct {
 	route53 *route53.Route53
+	limiter *adaptiveRateLimiter
+}
+
+// observe waits for the rate limiter before sending a request, and records its
+// outcome, in addition to its metrics.
+func (c *InstrumentedRoute53) observe(operation string, f func() error) {
+	c.limiter.wait(operation)
+	observe(operation, func() error {
+		err := f()
+		c.limiter.record(operation, err)
+		return err
+	})
 }
 
 func observe(operation string, f func() error) {
@@ -52,23 +64,47 @@ func observe(operation string, f func() error) {
 }
 
 func (c *InstrumentedRoute53) ListHostedZones(input *route53.ListHostedZonesInput) (output *route53.ListHostedZonesOutput, err error) {
-	observe("ListHostedZones", func() error {
+	c.observe("ListHostedZones", func() error {
 		output, err = c.route53.ListHostedZones(input)
 		return err
 	})
 	return
 }
 
+func (c *InstrumentedRoute53) GetHostedZone(input *route53.GetHostedZoneInput) (output *route53.GetHostedZoneOutput, err error) {
+	c.observe("GetHostedZone", func() error {
+		output, err = c.route53.GetHostedZone(input)
+		return err
+	})
+	return
+}
+
+func (c *InstrumentedRoute53) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) (err error) {
+	c.observe("ListResourceRecordSetsPages", func() error {
+		err = c.route53.ListResourceRecordSetsPages(input, fn)
+		return err
+	})
+	return
+}
+
 func (c *InstrumentedRoute53) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (output *route53.ChangeResourceRecordSetsOutput, err error) {
-	observe("ChangeResourceRecordSets", func() error {
+	c.observe("ChangeResourceRecordSets", func() error {
 		output, err = c.route53.ChangeResourceRecordSets(input)
 		return err
 	})
 	return
 }
 
+func (c *InstrumentedRoute53) GetChange(input *route53.GetChangeInput) (output *route53.GetChangeOutput, err error) {
+	c.observe("GetChange", func() error {
+		output, err = c.route53.GetChange(input)
+		return err
+	})
+	return
+}
+
 func (c *InstrumentedRoute53) CreateHealthCheck(input *route53.CreateHealthCheckInput) (output *route53.CreateHealthCheckOutput, err error) {
-	observe("CreateHealthCheck", func() error {
+	c.observe("CreateHealthCheck", func() error {
 		output, err = c.route53.CreateHealthCheck(input)
 		return err
 	})
@@ -76,7 +112,7 @@ func (c *InstrumentedRoute53) CreateHealthCheck(input *route53.CreateHealthCheck
 }
 
 func (c *InstrumentedRoute53) GetHealthCheckWithContext(ctx aws.Context, input *route53.GetHealthCheckInput, opts ...request.Option) (output *route53.GetHealthCheckOutput, err error) {
-	observe("GetHealthCheckWithContext", func() error {
+	c.observe("GetHealthCheckWithContext", func() error {
 		output, err = c.route53.GetHealthCheckWithContext(ctx, input, opts...)
 		return err
 	})
@@ -84,7 +120,7 @@ func (c *InstrumentedRoute53) GetHealthCheckWithContext(ctx aws.Context, input *
 }
 
 func (c *InstrumentedRoute53) UpdateHealthCheckWithContext(ctx aws.Context, input *route53.UpdateHealthCheckInput, opts ...request.Option) (output *route53.UpdateHealthCheckOutput, err error) {
-	observe("UpdateHealthCheckWithContext", func() error {
+	c.observe("UpdateHealthCheckWithContext", func() error {
 		output, err = c.route53.UpdateHealthCheckWithContext(ctx, input, opts...)
 		return err
 	})
@@ -92,17 +128,41 @@ func (c *InstrumentedRoute53) UpdateHealthCheckWithContext(ctx aws.Context, inpu
 }
 
 func (c *InstrumentedRoute53) DeleteHealthCheckWithContext(ctx aws.Context, input *route53.DeleteHealthCheckInput, opts ...request.Option) (output *route53.DeleteHealthCheckOutput, err error) {
-	observe("DeleteHealthCheckWithContext", func() error {
+	c.observe("DeleteHealthCheckWithContext", func() error {
 		output, err = c.route53.DeleteHealthCheckWithContext(ctx, input, opts...)
 		return err
 	})
 	return
 }
 
+func (c *InstrumentedRoute53) GetHealthCheckStatusWithContext(ctx aws.Context, input *route53.GetHealthCheckStatusInput, opts ...request.Option) (output *route53.GetHealthCheckStatusOutput, err error) {
+	c.observe("GetHealthCheckStatusWithContext", func() error {
+		output, err = c.route53.GetHealthCheckStatusWithContext(ctx, input, opts...)
+		return err
+	})
+	return
+}
+
 func (c *InstrumentedRoute53) ChangeTagsForResourceWithContext(ctx aws.Context, input *route53.ChangeTagsForResourceInput, opts ...request.Option) (output *route53.ChangeTagsForResourceOutput, err error) {
-	observe("ChangeTagsForResourceWithContext", func() error {
+	c.observe("ChangeTagsForResourceWithContext", func() error {
 		output, err = c.route53.ChangeTagsForResourceWithContext(ctx, input, opts...)
 		return err
 	})
 	return
 }
+
+func (c *InstrumentedRoute53) ListHealthChecksPagesWithContext(ctx aws.Context, input *route53.ListHealthChecksInput, fn func(*route53.ListHealthChecksOutput, bool) bool, opts ...request.Option) (err error) {
+	c.observe("ListHealthChecksPagesWithContext", func() error {
+		err = c.route53.ListHealthChecksPagesWithContext(ctx, input, fn, opts...)
+		return err
+	})
+	return
+}
+
+func (c *InstrumentedRoute53) ListTagsForResourcesWithContext(ctx aws.Context, input *route53.ListTagsForResourcesInput, opts ...request.Option) (output *route53.ListTagsForResourcesOutput, err error) {
+	c.observe("ListTagsForResourcesWithContext", func() error {
+		output, err = c.route53.ListTagsForResourcesWithContext(ctx, input, opts...)
+		return err
+	})
+	return
+}
@@ -3,12 +3,14 @@ package aws
 import (
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/go-logr/logr"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/route53"
 
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -22,22 +24,32 @@ const (
 	// chinaRoute53Endpoint is the Route 53 service endpoint used for AWS China regions.
 	chinaRoute53Endpoint = "https://route53.amazonaws.com.cn"
 
-	ProviderSpecificEvaluateTargetHealth = "aws/evaluate-target-health"
-	ProviderSpecificWeight               = "aws/weight"
-	ProviderSpecificRegion               = "aws/region"
-	ProviderSpecificFailover             = "aws/failover"
-	ProviderSpecificMultiValueAnswer     = "aws/multi-value-answer"
-	ProviderSpecificHealthCheckID        = "aws/health-check-id"
+	ProviderSpecificEvaluateTargetHealth     = dns.ProviderSpecificEvaluateTargetHealth
+	ProviderSpecificWeight                   = dns.ProviderSpecificWeight
+	ProviderSpecificRegion                   = dns.ProviderSpecificRegion
+	ProviderSpecificFailover                 = dns.ProviderSpecificFailover
+	ProviderSpecificMultiValueAnswer         = dns.ProviderSpecificMultiValueAnswer
+	ProviderSpecificHealthCheckID            = dns.ProviderSpecificHealthCheckID
+	ProviderSpecificGeolocationContinentCode = dns.ProviderSpecificGeolocationContinentCode
+	ProviderSpecificGeolocationCountryCode   = dns.ProviderSpecificGeolocationCountryCode
 )
 
-var _ dns.Provider = &Provider{}
+var (
+	_ dns.Provider           = &Provider{}
+	_ dns.PropagationTracker = &Provider{}
+)
 
 // Inspired by https://github.com/openshift/cluster-ingress-operator/blob/master/pkg/dns/aws/dns.go
 type Provider struct {
 	route53               *InstrumentedRoute53
+	batcher               *changeBatcher
+	tagging               *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
 	healthCheckReconciler *Route53HealthCheckReconciler
 	config                Config
 	logger                logr.Logger
+	// pendingChanges are the changes not yet in sync, by DNSRecord and zone
+	pendingChanges     map[changeKey]pendingChange
+	pendingChangesLock sync.Mutex
 }
 
 // Config is the necessary input to configure the manager.
@@ -80,10 +92,17 @@ func NewProvider(config Config) (*Provider, error) {
 	}
 
 	p := &Provider{
-		route53: &InstrumentedRoute53{route53.New(sess, r53Config)},
-		config:  config,
-		logger:  log.Logger.WithName("aws-route53").WithValues("region", r53Config.Region),
+		route53: &InstrumentedRoute53{
+			route53: route53.New(sess, r53Config),
+			limiter: newAdaptiveRateLimiter(defaultRequestRate),
+		},
+		// Hosted zones are global resources, tagged in the same region as the Route 53 API
+		tagging:        resourcegroupstaggingapi.New(sess, aws.NewConfig().WithRegion(*r53Config.Region)),
+		config:         config,
+		pendingChanges: map[changeKey]pendingChange{},
+		logger:         log.Logger.WithName("aws-route53").WithValues("region", r53Config.Region),
 	}
+	p.batcher = newChangeBatcher(p.route53, defaultChangeBatchWindow, p.logger)
 	if err := validateServiceEndpoints(p); err != nil {
 		return nil, fmt.Errorf("failed to validate AWS provider service endpoints: %v", err)
 	}
@@ -104,8 +123,8 @@ func validateServiceEndpoints(provider *Provider) error {
 type action string
 
 const (
-	upsertAction action = "UPSERT"
-	deleteAction action = "DELETE"
+	upsertAction action = dns.UpsertAction
+	deleteAction action = dns.DeleteAction
 )
 
 func (p *Provider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
@@ -141,49 +160,41 @@ func (p *Provider) change(record *v1.DNSRecord, zone v1.DNSZone, action action)
 }
 
 func (p *Provider) updateRecord(record *v1.DNSRecord, zoneID, action string) error {
-	input := route53.ChangeResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)}
-
-	expectedEndpointsMap := make(map[string]struct{})
-	var changes []*route53.Change
-	for _, endpoint := range record.Spec.Endpoints {
-		expectedEndpointsMap[endpoint.SetID()] = struct{}{}
-		change, err := p.changeForEndpoint(endpoint, action)
-		if err != nil {
-			return err
+	if action == string(upsertAction) {
+		if err := validateRoutingPolicies(record.Spec.Endpoints); err != nil {
+			return fmt.Errorf("invalid routing policies of DNS record %s: %w", record.Name, err)
 		}
-		changes = append(changes, change)
 	}
 
 	// Delete any previously published records that are no longer present in record.Spec.Endpoints
-	if action != string(deleteAction) {
-		lastPublishedEndpoints, err := p.endpointsFromZoneStatus(record, zoneID)
+	var changes []*route53.Change
+	for _, planned := range dns.PlanChanges(record, zoneID, action) {
+		change, err := p.changeForEndpoint(planned.Endpoint, planned.Action)
 		if err != nil {
 			return err
 		}
-		for _, endpoint := range lastPublishedEndpoints {
-			if _, found := expectedEndpointsMap[endpoint.SetID()]; !found {
-				change, err := p.changeForEndpoint(endpoint, string(deleteAction))
-				if err != nil {
-					return err
-				}
-				changes = append(changes, change)
-			}
-		}
+		changes = append(changes, change)
 	}
 
-	input.ChangeBatch = &route53.ChangeBatch{
-		Changes: changes,
-	}
-	resp, err := p.route53.ChangeResourceRecordSets(&input)
+	// The changes are applied along with the changes to other records of the
+	// zone, submitted within the batch window
+	info, err := p.batcher.Submit(zoneID, changes)
 	if err != nil {
-		return fmt.Errorf("couldn't update DNS record %s in zone %s: %v", record.Name, zoneID, err)
+		return p.route53.retryable(fmt.Errorf("couldn't update DNS record %s in zone %s: %w", record.Name, zoneID, err))
+	}
+	p.logger.Info("Updated DNS record", "record", record, "zone", zoneID, "change", info)
+	if action == string(deleteAction) {
+		p.forgetChange(record, zoneID)
+	} else {
+		p.trackChange(record, zoneID, info)
 	}
-	p.logger.Info("Updated DNS record", "record", record, "zone", zoneID, "response", resp)
 	return nil
 }
 
 func (p *Provider) changeForEndpoint(endpoint *v1.Endpoint, action string) (*route53.Change, error) {
-	if endpoint.RecordType != string(v1.ARecordType) {
+	switch endpoint.RecordType {
+	case string(v1.ARecordType), string(v1.AAAARecordType), string(v1.CNAMERecordType), string(v1.TXTRecordType):
+	default:
 		return nil, fmt.Errorf("unsupported record type %s", endpoint.RecordType)
 	}
 	domain, targets := endpoint.DNSName, endpoint.Targets
@@ -193,15 +204,21 @@ func (p *Provider) changeForEndpoint(endpoint *v1.Endpoint, action string) (*rou
 	if len(targets) == 0 {
 		return nil, fmt.Errorf("targets is required")
 	}
+	if endpoint.RecordType == string(v1.CNAMERecordType) && len(targets) > 1 {
+		return nil, fmt.Errorf("CNAME record %s must have a single target", domain)
+	}
 
 	var resourceRecords []*route53.ResourceRecord
 	for _, target := range endpoint.Targets {
+		if endpoint.RecordType == string(v1.TXTRecordType) {
+			target = strconv.Quote(target)
+		}
 		resourceRecords = append(resourceRecords, &route53.ResourceRecord{Value: aws.String(target)})
 	}
 
 	resourceRecordSet := &route53.ResourceRecordSet{
 		Name:            aws.String(endpoint.DNSName),
-		Type:            aws.String(route53.RRTypeA),
+		Type:            aws.String(endpoint.RecordType),
 		TTL:             aws.Int64(int64(endpoint.RecordTTL)),
 		ResourceRecords: resourceRecords,
 	}
@@ -223,6 +240,15 @@ func (p *Provider) changeForEndpoint(endpoint *v1.Endpoint, action string) (*rou
 	if prop, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificFailover); ok {
 		resourceRecordSet.Failover = aws.String(prop.Value)
 	}
+	if prop, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificGeolocationContinentCode); ok {
+		resourceRecordSet.GeoLocation = &route53.GeoLocation{ContinentCode: aws.String(prop.Value)}
+	}
+	if prop, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificGeolocationCountryCode); ok {
+		if resourceRecordSet.GeoLocation == nil {
+			resourceRecordSet.GeoLocation = &route53.GeoLocation{}
+		}
+		resourceRecordSet.GeoLocation.CountryCode = aws.String(prop.Value)
+	}
 	if _, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificMultiValueAnswer); ok {
 		resourceRecordSet.MultiValueAnswer = aws.Bool(true)
 	}
@@ -236,12 +262,3 @@ func (p *Provider) changeForEndpoint(endpoint *v1.Endpoint, action string) (*rou
 	}
 	return change, nil
 }
-
-func (p *Provider) endpointsFromZoneStatus(record *v1.DNSRecord, zoneID string) ([]*v1.Endpoint, error) {
-	for _, zoneStatus := range record.Status.Zones {
-		if zoneStatus.DNSZone.ID == zoneID {
-			return zoneStatus.Endpoints, nil
-		}
-	}
-	return []*v1.Endpoint{}, nil
-}
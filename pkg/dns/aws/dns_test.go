@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+func TestChangeForEndpoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoint      *v1.Endpoint
+		wantType      string
+		wantContinent string
+		wantErr       bool
+	}{
+		{
+			name: "A record",
+			endpoint: &v1.Endpoint{
+				DNSName:       "abc.example.com",
+				RecordType:    string(v1.ARecordType),
+				Targets:       []string{"1.1.1.1"},
+				SetIdentifier: "1.1.1.1",
+				RecordTTL:     60,
+			},
+			wantType: route53.RRTypeA,
+		},
+		{
+			name: "AAAA record",
+			endpoint: &v1.Endpoint{
+				DNSName:       "abc.example.com",
+				RecordType:    string(v1.AAAARecordType),
+				Targets:       []string{"2001:db8::1"},
+				SetIdentifier: "2001:db8::1",
+				RecordTTL:     60,
+			},
+			wantType: route53.RRTypeAaaa,
+		},
+		{
+			name: "CNAME record",
+			endpoint: &v1.Endpoint{
+				DNSName:    "api.example.net",
+				RecordType: string(v1.CNAMERecordType),
+				Targets:    []string{"abc.example.com"},
+				RecordTTL:  60,
+			},
+			wantType: route53.RRTypeCname,
+		},
+		{
+			name: "geolocation CNAME record",
+			endpoint: &v1.Endpoint{
+				DNSName:       "abc.example.com",
+				RecordType:    string(v1.CNAMERecordType),
+				Targets:       []string{"continent-eu.abc.example.com"},
+				SetIdentifier: "continent-eu",
+				RecordTTL:     60,
+				ProviderSpecific: v1.ProviderSpecific{
+					{Name: ProviderSpecificGeolocationContinentCode, Value: "EU"},
+				},
+			},
+			wantType:      route53.RRTypeCname,
+			wantContinent: "EU",
+		},
+		{
+			name: "CNAME record with multiple targets",
+			endpoint: &v1.Endpoint{
+				DNSName:    "api.example.net",
+				RecordType: string(v1.CNAMERecordType),
+				Targets:    []string{"abc.example.com", "def.example.com"},
+				RecordTTL:  60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported record type",
+			endpoint: &v1.Endpoint{
+				DNSName:    "abc.example.com",
+				RecordType: "MX",
+				Targets:    []string{"mail.example.com"},
+			},
+			wantErr: true,
+		},
+	}
+	p := &Provider{logger: logr.Discard()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, err := p.changeForEndpoint(tt.endpoint, route53.ChangeActionUpsert)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := *change.ResourceRecordSet.Type; got != tt.wantType {
+				t.Errorf("expected record type %s, got %s", tt.wantType, got)
+			}
+			if tt.wantContinent != "" {
+				geo := change.ResourceRecordSet.GeoLocation
+				if geo == nil || aws.StringValue(geo.ContinentCode) != tt.wantContinent {
+					t.Errorf("expected geolocation continent %s, got %v", tt.wantContinent, geo)
+				}
+			}
+		})
+	}
+}
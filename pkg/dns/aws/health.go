@@ -2,20 +2,43 @@ package aws
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/rs/xid"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/route53"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
 	"github.com/kuadrant/kcp-glbc/pkg/dns"
 )
 
 const (
-	idTag = "kuadrant.dev/healthcheck"
+	idTag    = "kuadrant.dev/healthcheck"
+	ownerTag = "kuadrant.dev/owner"
+
+	// healthyCheckersRatio is the ratio of the Route53 health checkers that
+	// must report an endpoint as healthy for Route53 to consider it healthy.
+	// See https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/dns-failover-determining-health-of-endpoints.html
+	healthyCheckersRatio = 0.18
+
+	// maxTagsForResources is the maximum number of health checks whose tags
+	// can be listed at once.
+	maxTagsForResources = 10
+
+	// minHealthCheckRegions is the minimum number of regions a health check
+	// can be checked from, if they are set.
+	minHealthCheckRegions = 3
+	// defaultRequestInterval is the number of seconds between the checks of
+	// an endpoint, if not set.
+	defaultRequestInterval = 30
 )
 
 var (
@@ -27,7 +50,13 @@ type Route53HealthCheckReconciler struct {
 	logger logr.Logger
 }
 
-var _ dns.HealthCheckReconciler = &Route53HealthCheckReconciler{}
+var (
+	_ dns.HealthCheckReconciler     = &Route53HealthCheckReconciler{}
+	_ dns.HealthCheckStatusReader   = &Route53HealthCheckReconciler{}
+	_ dns.HealthCheckStatusReporter = &Route53HealthCheckReconciler{}
+	_ dns.HealthCheckLister         = &Route53HealthCheckReconciler{}
+	_ dns.HealthCheckValidator      = &Route53HealthCheckReconciler{}
+)
 
 func newRoute53HealthCheckReconciler(c *InstrumentedRoute53, l logr.Logger) *Route53HealthCheckReconciler {
 	return &Route53HealthCheckReconciler{
@@ -37,6 +66,204 @@ func newRoute53HealthCheckReconciler(c *InstrumentedRoute53, l logr.Logger) *Rou
 }
 
 func (r *Route53HealthCheckReconciler) Reconcile(ctx context.Context, spec dns.HealthCheckSpec, endpoint *v1.Endpoint) error {
+	return r.client.retryable(r.reconcile(ctx, spec, endpoint))
+}
+
+func (r *Route53HealthCheckReconciler) Delete(ctx context.Context, endpoint *v1.Endpoint) error {
+	return r.client.retryable(r.delete(ctx, endpoint))
+}
+
+func (r *Route53HealthCheckReconciler) Healthy(ctx context.Context, endpoint *v1.Endpoint) (bool, bool, error) {
+	status, err := r.Status(ctx, endpoint)
+	if err != nil {
+		return false, true, err
+	}
+	if status == nil {
+		return false, false, nil
+	}
+	return status.Healthy, true, nil
+}
+
+func (r *Route53HealthCheckReconciler) Status(ctx context.Context, endpoint *v1.Endpoint) (*v1.EndpointHealthStatus, error) {
+	id, hasId := getHealthCheckId(endpoint)
+	if !hasId {
+		return nil, nil
+	}
+
+	var status *v1.EndpointHealthStatus
+	var err error
+	if len(endpoint.Targets) > 1 {
+		status, err = r.targetsStatus(ctx, id)
+	} else {
+		status, err = r.healthCheckStatus(ctx, id)
+	}
+	if err != nil {
+		return nil, r.client.retryable(err)
+	}
+	status.DNSName = endpoint.DNSName
+	status.SetIdentifier = endpoint.SetIdentifier
+	status.HealthCheckID = id
+	return status, nil
+}
+
+func (r *Route53HealthCheckReconciler) healthCheckStatus(ctx context.Context, id string) (*v1.EndpointHealthStatus, error) {
+	output, err := r.client.GetHealthCheckStatusWithContext(ctx, &route53.GetHealthCheckStatusInput{
+		HealthCheckId: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return healthStatus(output.HealthCheckObservations), nil
+}
+
+// targetsStatus returns the health of the targets checked by the children of
+// the CALCULATED health check, as Route53 does not report the status of the
+// CALCULATED health checks.
+func (r *Route53HealthCheckReconciler) targetsStatus(ctx context.Context, id string) (*v1.EndpointHealthStatus, error) {
+	output, err := r.client.GetHealthCheckWithContext(ctx, &route53.GetHealthCheckInput{
+		HealthCheckId: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isCalculated(output.HealthCheck) {
+		// The health check of the targets has not been reconciled yet
+		return r.healthCheckStatus(ctx, id)
+	}
+
+	statuses := map[string]*v1.EndpointHealthStatus{}
+	for _, childID := range aws.StringValueSlice(output.HealthCheck.HealthCheckConfig.ChildHealthChecks) {
+		child, err := r.client.GetHealthCheckWithContext(ctx, &route53.GetHealthCheckInput{
+			HealthCheckId: &childID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		status, err := r.healthCheckStatus(ctx, childID)
+		if err != nil {
+			return nil, err
+		}
+		statuses[aws.StringValue(child.HealthCheck.HealthCheckConfig.IPAddress)] = status
+	}
+	return targetsHealthStatus(statuses), nil
+}
+
+// ListHealthChecks returns the health checks tagged with their GLBC identifier.
+// The health checks of the targets of the CALCULATED health checks are left
+// out, so that they are not collected while their parent is referenced, and
+// the name of a CALCULATED health check is the one of its children.
+func (r *Route53HealthCheckReconciler) ListHealthChecks(ctx context.Context) ([]dns.HealthCheck, error) {
+	var healthChecks []*route53.HealthCheck
+	err := r.client.ListHealthChecksPagesWithContext(ctx, &route53.ListHealthChecksInput{}, func(output *route53.ListHealthChecksOutput, _ bool) bool {
+		healthChecks = append(healthChecks, output.HealthChecks...)
+		return true
+	})
+	if err != nil {
+		return nil, r.client.retryable(err)
+	}
+
+	byID := map[string]*route53.HealthCheck{}
+	children := map[string]bool{}
+	for _, healthCheck := range healthChecks {
+		byID[aws.StringValue(healthCheck.Id)] = healthCheck
+		if isCalculated(healthCheck) {
+			for _, childID := range aws.StringValueSlice(healthCheck.HealthCheckConfig.ChildHealthChecks) {
+				children[childID] = true
+			}
+		}
+	}
+
+	var result []dns.HealthCheck
+	for start := 0; start < len(healthChecks); start += maxTagsForResources {
+		end := start + maxTagsForResources
+		if end > len(healthChecks) {
+			end = len(healthChecks)
+		}
+		ids := make([]*string, 0, end-start)
+		for _, healthCheck := range healthChecks[start:end] {
+			ids = append(ids, healthCheck.Id)
+		}
+		output, err := r.client.ListTagsForResourcesWithContext(ctx, &route53.ListTagsForResourcesInput{
+			ResourceIds:  ids,
+			ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
+		})
+		if err != nil {
+			return nil, r.client.retryable(err)
+		}
+
+		tagged := map[string]bool{}
+		owners := map[string]string{}
+		for _, tagSet := range output.ResourceTagSets {
+			for _, tag := range tagSet.Tags {
+				switch aws.StringValue(tag.Key) {
+				case idTag:
+					tagged[aws.StringValue(tagSet.ResourceId)] = true
+				case ownerTag:
+					owners[aws.StringValue(tagSet.ResourceId)] = aws.StringValue(tag.Value)
+				}
+			}
+		}
+		for _, healthCheck := range healthChecks[start:end] {
+			if !tagged[aws.StringValue(healthCheck.Id)] || children[aws.StringValue(healthCheck.Id)] {
+				continue
+			}
+			dnsName := fullyQualifiedDomainName(healthCheck)
+			if isCalculated(healthCheck) {
+				for _, childID := range aws.StringValueSlice(healthCheck.HealthCheckConfig.ChildHealthChecks) {
+					if child, ok := byID[childID]; ok {
+						dnsName = fullyQualifiedDomainName(child)
+						break
+					}
+				}
+			}
+			result = append(result, dns.HealthCheck{
+				ID:      aws.StringValue(healthCheck.Id),
+				DNSName: dnsName,
+				OwnerID: owners[aws.StringValue(healthCheck.Id)],
+			})
+		}
+	}
+	return result, nil
+}
+
+// DeleteHealthCheck deletes the health check by ID, and its children if it is a
+// CALCULATED health check.
+func (r *Route53HealthCheckReconciler) DeleteHealthCheck(ctx context.Context, id string) error {
+	output, err := r.client.GetHealthCheckWithContext(ctx, &route53.GetHealthCheckInput{
+		HealthCheckId: &id,
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHealthCheck {
+		return nil
+	}
+	if err != nil {
+		return r.client.retryable(err)
+	}
+
+	_, err = r.client.DeleteHealthCheckWithContext(ctx, &route53.DeleteHealthCheckInput{
+		HealthCheckId: &id,
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHealthCheck {
+		return nil
+	}
+	if err != nil {
+		return r.client.retryable(err)
+	}
+
+	if isCalculated(output.HealthCheck) {
+		for _, childID := range aws.StringValueSlice(output.HealthCheck.HealthCheckConfig.ChildHealthChecks) {
+			if err := r.DeleteHealthCheck(ctx, childID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Route53HealthCheckReconciler) reconcile(ctx context.Context, spec dns.HealthCheckSpec, endpoint *v1.Endpoint) error {
+	if len(endpoint.Targets) > 1 {
+		return r.reconcileTargets(ctx, spec, endpoint)
+	}
+
 	healthCheck, exists, err := r.findHealthCheck(ctx, endpoint)
 	if err != nil {
 		return err
@@ -48,16 +275,126 @@ func (r *Route53HealthCheckReconciler) Reconcile(ctx context.Context, spec dns.H
 		}
 	}()
 
-	if exists {
+	if exists && !needsReplacement(healthCheck, spec) {
 		return r.updateHealthCheck(ctx, spec, endpoint, healthCheck)
 	}
 
-	healthCheck, err = r.createHealthCheck(ctx, spec, endpoint)
+	reference := spec.Id
+	if exists {
+		// The type and request interval of a health check cannot be updated, so
+		// it is replaced, and deleted once no longer referenced by the published
+		// records
+		r.logger.Info("Replacing health check", "id", *healthCheck.Id, "type", aws.StringValue(healthCheckType(spec)), "requestInterval", aws.Int64Value(spec.RequestInterval))
+		reference = replacementReference(spec)
+	}
+	healthCheck, err = r.createHealthCheck(ctx, reference, spec, endpoint)
 	return err
 }
 
-func (r *Route53HealthCheckReconciler) Delete(ctx context.Context, endpoint *v1.Endpoint) error {
+// reconcileTargets reconciles a health check per target of the endpoint, as a
+// health check only checks a single IP address, and the CALCULATED health
+// check referenced by the endpoint, healthy while any of its children is.
+func (r *Route53HealthCheckReconciler) reconcileTargets(ctx context.Context, spec dns.HealthCheckSpec, endpoint *v1.Endpoint) error {
+	parent, exists, err := r.findHealthCheck(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if exists && !isCalculated(parent) {
+		// The health check of the single target is replaced, and deleted once
+		// no longer referenced by the published records
+		r.logger.Info("Replacing health check of the targets", "id", *parent.Id, "targets", endpoint.Targets)
+		exists = false
+	}
+
+	// The current children by target
+	children := map[string]*route53.HealthCheck{}
+	if exists {
+		for _, childID := range aws.StringValueSlice(parent.HealthCheckConfig.ChildHealthChecks) {
+			output, err := r.client.GetHealthCheckWithContext(ctx, &route53.GetHealthCheckInput{
+				HealthCheckId: &childID,
+			})
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHealthCheck {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			children[aws.StringValue(output.HealthCheck.HealthCheckConfig.IPAddress)] = output.HealthCheck
+		}
+	}
+
+	var childIDs []string
+	for _, target := range endpoint.Targets {
+		targetEndpoint := endpoint.DeepCopy()
+		targetEndpoint.Targets = v1.Targets{target}
+		child, ok := children[target]
+		if ok && !needsReplacement(child, spec) {
+			delete(children, target)
+			if err := r.updateHealthCheck(ctx, spec, targetEndpoint, child); err != nil {
+				return err
+			}
+		} else {
+			targetSpec := spec
+			targetSpec.Name = fmt.Sprintf("%s-%s", spec.Name, target)
+			if child, err = r.createHealthCheck(ctx, targetReference(spec, target), targetSpec, targetEndpoint); err != nil {
+				return err
+			}
+		}
+		childIDs = append(childIDs, aws.StringValue(child.Id))
+	}
+	sort.Strings(childIDs)
+
+	if !exists {
+		if parent, err = r.createCalculatedHealthCheck(ctx, spec, childIDs); err != nil {
+			return err
+		}
+	} else if !unorderedEqual(aws.StringValueSlice(parent.HealthCheckConfig.ChildHealthChecks), childIDs) {
+		r.logger.Info("Updating health checks of the targets", "id", *parent.Id, "children", childIDs)
+		_, err := r.client.UpdateHealthCheckWithContext(ctx, &route53.UpdateHealthCheckInput{
+			HealthCheckId:     parent.Id,
+			ChildHealthChecks: aws.StringSlice(childIDs),
+			HealthThreshold:   aws.Int64(1),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	endpoint.SetProviderSpecific(ProviderSpecificHealthCheckID, *parent.Id)
+
+	// Delete the health checks of the former targets, now that they are no
+	// longer children of the CALCULATED health check
+	for _, child := range children {
+		if err := r.DeleteHealthCheck(ctx, aws.StringValue(child.Id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate returns why the health checks of the spec cannot be created by
+// Route53, if so.
+func (r *Route53HealthCheckReconciler) Validate(spec dns.HealthCheckSpec) error {
+	if len(spec.Regions) == 0 {
+		return nil
+	}
+	if len(spec.Regions) < minHealthCheckRegions {
+		return fmt.Errorf("at least %d regions are required, got %d", minHealthCheckRegions, len(spec.Regions))
+	}
+	for _, region := range spec.Regions {
+		if !isHealthCheckRegion(region) {
+			return fmt.Errorf("unknown region %s, must be one of [%s]", region, strings.Join(route53.HealthCheckRegion_Values(), ", "))
+		}
+	}
+	return nil
+}
+
+func (r *Route53HealthCheckReconciler) delete(ctx context.Context, endpoint *v1.Endpoint) error {
 	healthCheck, found, err := r.findHealthCheck(ctx, endpoint)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHealthCheck {
+		// Already deleted, e.g. as a published endpoint and an endpoint of the
+		// spec
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -73,6 +410,15 @@ func (r *Route53HealthCheckReconciler) Delete(ctx context.Context, endpoint *v1.
 		return err
 	}
 
+	// The children can only be deleted once their CALCULATED parent is
+	if isCalculated(healthCheck) {
+		for _, childID := range aws.StringValueSlice(healthCheck.HealthCheckConfig.ChildHealthChecks) {
+			if err := r.DeleteHealthCheck(ctx, childID); err != nil {
+				return err
+			}
+		}
+	}
+
 	endpoint.DeleteProviderSpecific(ProviderSpecificHealthCheckID)
 	return err
 }
@@ -94,48 +440,85 @@ func (r *Route53HealthCheckReconciler) findHealthCheck(ctx context.Context, endp
 
 }
 
-func (r *Route53HealthCheckReconciler) createHealthCheck(ctx context.Context, spec dns.HealthCheckSpec, endpoint *v1.Endpoint) (*route53.HealthCheck, error) {
+func (r *Route53HealthCheckReconciler) createHealthCheck(ctx context.Context, reference string, spec dns.HealthCheckSpec, endpoint *v1.Endpoint) (*route53.HealthCheck, error) {
 	address, _ := endpoint.GetAddress()
 	host := endpoint.DNSName
 
 	// Create the health check
 	output, err := r.client.CreateHealthCheck(&route53.CreateHealthCheckInput{
-		CallerReference: callerReference(spec.Id),
+		CallerReference: callerReference(reference),
 		HealthCheckConfig: &route53.HealthCheckConfig{
 			IPAddress:                &address,
 			FullyQualifiedDomainName: &host,
 			Port:                     spec.Port,
-			ResourcePath:             &spec.Path,
-			Type:                     healthCheckType(spec.Protocol),
+			ResourcePath:             resourcePath(spec),
+			Type:                     healthCheckType(spec),
 			FailureThreshold:         spec.FailureThreshold,
+			SearchString:             searchString(spec),
+			RequestInterval:          spec.RequestInterval,
+			Regions:                  aws.StringSlice(spec.Regions),
+			EnableSNI:                spec.EnableSNI,
+			Inverted:                 aws.Bool(spec.Inverted),
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Add the tag to identify it
-	_, err = r.client.ChangeTagsForResourceWithContext(ctx, &route53.ChangeTagsForResourceInput{
-		AddTags: []*route53.Tag{
-			{
-				Key:   aws.String(idTag),
-				Value: aws.String(spec.Id),
-			},
-			{
-				Key:   aws.String("Name"),
-				Value: &spec.Name,
-			},
+	if err := r.tagHealthCheck(ctx, output.HealthCheck, spec); err != nil {
+		return nil, err
+	}
+	return output.HealthCheck, nil
+}
+
+// createCalculatedHealthCheck creates the CALCULATED health check of the health
+// checks of the targets of an endpoint, healthy while any of them is.
+func (r *Route53HealthCheckReconciler) createCalculatedHealthCheck(ctx context.Context, spec dns.HealthCheckSpec, childIDs []string) (*route53.HealthCheck, error) {
+	output, err := r.client.CreateHealthCheck(&route53.CreateHealthCheckInput{
+		CallerReference: callerReference(calculatedReference(spec, childIDs)),
+		HealthCheckConfig: &route53.HealthCheckConfig{
+			Type:              aws.String(route53.HealthCheckTypeCalculated),
+			ChildHealthChecks: aws.StringSlice(childIDs),
+			HealthThreshold:   aws.Int64(1),
 		},
-		ResourceId:   output.HealthCheck.Id,
-		ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := r.tagHealthCheck(ctx, output.HealthCheck, spec); err != nil {
+		return nil, err
+	}
 	return output.HealthCheck, nil
 }
 
+// tagHealthCheck adds the tags identifying the health check and its owner, and
+// its name.
+func (r *Route53HealthCheckReconciler) tagHealthCheck(ctx context.Context, healthCheck *route53.HealthCheck, spec dns.HealthCheckSpec) error {
+	tags := []*route53.Tag{
+		{
+			Key:   aws.String(idTag),
+			Value: aws.String(spec.Id),
+		},
+		{
+			Key:   aws.String("Name"),
+			Value: &spec.Name,
+		},
+	}
+	if spec.OwnerID != "" {
+		tags = append(tags, &route53.Tag{
+			Key:   aws.String(ownerTag),
+			Value: aws.String(spec.OwnerID),
+		})
+	}
+	_, err := r.client.ChangeTagsForResourceWithContext(ctx, &route53.ChangeTagsForResourceInput{
+		AddTags:      tags,
+		ResourceId:   healthCheck.Id,
+		ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
+	})
+	return err
+}
+
 func (r *Route53HealthCheckReconciler) updateHealthCheck(ctx context.Context, spec dns.HealthCheckSpec, endpoint *v1.Endpoint, healthCheck *route53.HealthCheck) error {
 	diff := healthCheckDiff(healthCheck, spec, endpoint)
 	if diff == nil {
@@ -176,8 +559,8 @@ func healthCheckDiff(healthCheck *route53.HealthCheck, spec dns.HealthCheckSpec,
 	if !strValuesEqual(&address, healthCheck.HealthCheckConfig.IPAddress) {
 		diff().IPAddress = &address
 	}
-	if !strValuesEqual(&spec.Path, healthCheck.HealthCheckConfig.ResourcePath) {
-		diff().ResourcePath = &spec.Path
+	if path := resourcePath(spec); path != nil && !strValuesEqual(path, healthCheck.HealthCheckConfig.ResourcePath) {
+		diff().ResourcePath = path
 	}
 	if !intValuesEqual(spec.Port, healthCheck.HealthCheckConfig.Port) {
 		diff().Port = spec.Port
@@ -185,10 +568,162 @@ func healthCheckDiff(healthCheck *route53.HealthCheck, spec dns.HealthCheckSpec,
 	if !intValuesEqual(spec.FailureThreshold, healthCheck.HealthCheckConfig.FailureThreshold) {
 		diff().FailureThreshold = spec.FailureThreshold
 	}
+	if search := searchString(spec); search != nil && !strValuesEqual(search, healthCheck.HealthCheckConfig.SearchString) {
+		diff().SearchString = search
+	}
+	if regions := aws.StringValueSlice(healthCheck.HealthCheckConfig.Regions); len(spec.Regions) == 0 {
+		// Reset to the default regions, i.e., all of them
+		if len(regions) > 0 && !unorderedEqual(regions, route53.HealthCheckRegion_Values()) {
+			diff().ResetElements = aws.StringSlice([]string{route53.ResettableElementNameRegions})
+		}
+	} else if !unorderedEqual(spec.Regions, regions) {
+		diff().Regions = aws.StringSlice(spec.Regions)
+	}
+	if spec.EnableSNI != nil && *spec.EnableSNI != aws.BoolValue(healthCheck.HealthCheckConfig.EnableSNI) {
+		diff().EnableSNI = spec.EnableSNI
+	}
+	if spec.Inverted != aws.BoolValue(healthCheck.HealthCheckConfig.Inverted) {
+		diff().Inverted = aws.Bool(spec.Inverted)
+	}
 
 	return result
 }
 
+// needsReplacement returns whether the type or the request interval of the
+// health check differ from the spec, as they cannot be updated.
+func needsReplacement(healthCheck *route53.HealthCheck, spec dns.HealthCheckSpec) bool {
+	if isCalculated(healthCheck) {
+		// The endpoint no longer has several targets
+		return true
+	}
+	if checkType := healthCheckType(spec); checkType != nil && !strValuesEqual(checkType, healthCheck.HealthCheckConfig.Type) {
+		return true
+	}
+	requestInterval := int64(defaultRequestInterval)
+	if spec.RequestInterval != nil {
+		requestInterval = *spec.RequestInterval
+	}
+	current := int64(defaultRequestInterval)
+	if healthCheck.HealthCheckConfig.RequestInterval != nil {
+		current = *healthCheck.HealthCheckConfig.RequestInterval
+	}
+	return requestInterval != current
+}
+
+// replacementReference returns the identifier of the replacement of a health
+// check, so that it is created with a caller reference of its own.
+func replacementReference(spec dns.HealthCheckSpec) string {
+	hash := md5.Sum([]byte(fmt.Sprintf("%s/%s/%d", spec.Id, aws.StringValue(healthCheckType(spec)), aws.Int64Value(spec.RequestInterval))))
+	return fmt.Sprintf("%x", hash)
+}
+
+// targetReference returns the identifier of the health check of a target of an
+// endpoint, so that it is created with a caller reference of its own.
+func targetReference(spec dns.HealthCheckSpec, target string) string {
+	hash := md5.Sum([]byte(fmt.Sprintf("%s/%s/%d/%s", spec.Id, aws.StringValue(healthCheckType(spec)), aws.Int64Value(spec.RequestInterval), target)))
+	return fmt.Sprintf("%x", hash)
+}
+
+// calculatedReference returns the identifier of the CALCULATED health check of
+// the children, so that it is created with a caller reference of its own.
+func calculatedReference(spec dns.HealthCheckSpec, childIDs []string) string {
+	hash := md5.Sum([]byte(fmt.Sprintf("%s/%s/%s", spec.Id, route53.HealthCheckTypeCalculated, strings.Join(childIDs, ","))))
+	return fmt.Sprintf("%x", hash)
+}
+
+func isCalculated(healthCheck *route53.HealthCheck) bool {
+	return healthCheck.HealthCheckConfig != nil && aws.StringValue(healthCheck.HealthCheckConfig.Type) == route53.HealthCheckTypeCalculated
+}
+
+// isHealthy returns whether enough health checkers report the endpoint as
+// healthy. An endpoint not observed yet is considered healthy, as by Route53.
+func isHealthy(observations []*route53.HealthCheckObservation) bool {
+	if len(observations) == 0 {
+		return true
+	}
+	healthy := 0
+	for _, observation := range observations {
+		if observation.StatusReport != nil && strings.HasPrefix(aws.StringValue(observation.StatusReport.Status), "Success") {
+			healthy++
+		}
+	}
+	return float64(healthy)/float64(len(observations)) > healthyCheckersRatio
+}
+
+// healthStatus returns the health of an endpoint as per the observations of
+// the Route53 health checkers, sorted by region.
+func healthStatus(observations []*route53.HealthCheckObservation) *v1.EndpointHealthStatus {
+	status := &v1.EndpointHealthStatus{
+		Healthy: isHealthy(observations),
+	}
+	unhealthy := 0
+	for _, observation := range observations {
+		result := v1.HealthCheckObservation{
+			Region:    aws.StringValue(observation.Region),
+			IPAddress: aws.StringValue(observation.IPAddress),
+		}
+		if report := observation.StatusReport; report != nil {
+			result.Status = aws.StringValue(report.Status)
+			result.Healthy = strings.HasPrefix(result.Status, "Success")
+			if report.CheckedTime != nil {
+				checked := metav1.NewTime(*report.CheckedTime)
+				result.LastCheckedTime = &checked
+				if status.LastCheckedTime == nil || status.LastCheckedTime.Before(&checked) {
+					status.LastCheckedTime = result.LastCheckedTime.DeepCopy()
+				}
+			}
+		}
+		if !result.Healthy {
+			unhealthy++
+		}
+		status.Observations = append(status.Observations, result)
+	}
+	sort.Slice(status.Observations, func(i, j int) bool {
+		if status.Observations[i].Region != status.Observations[j].Region {
+			return status.Observations[i].Region < status.Observations[j].Region
+		}
+		return status.Observations[i].IPAddress < status.Observations[j].IPAddress
+	})
+	if unhealthy > 0 {
+		status.Message = fmt.Sprintf("%d of the %d health checkers observe the endpoint as unhealthy", unhealthy, len(observations))
+	}
+	return status
+}
+
+// targetsHealthStatus returns the health of an endpoint as per the health of
+// each of its targets. The endpoint is healthy while any of its targets is, as
+// is the CALCULATED health check of its targets.
+func targetsHealthStatus(statuses map[string]*v1.EndpointHealthStatus) *v1.EndpointHealthStatus {
+	status := &v1.EndpointHealthStatus{}
+	var unhealthy []string
+	for target, targetStatus := range statuses {
+		if targetStatus.Healthy {
+			status.Healthy = true
+		} else {
+			unhealthy = append(unhealthy, target)
+		}
+		if targetStatus.LastCheckedTime != nil && (status.LastCheckedTime == nil || status.LastCheckedTime.Before(targetStatus.LastCheckedTime)) {
+			status.LastCheckedTime = targetStatus.LastCheckedTime.DeepCopy()
+		}
+		status.Observations = append(status.Observations, targetStatus.Observations...)
+	}
+	if len(statuses) == 0 {
+		// Not observed yet, as by Route53
+		status.Healthy = true
+	}
+	sort.Slice(status.Observations, func(i, j int) bool {
+		if status.Observations[i].Region != status.Observations[j].Region {
+			return status.Observations[i].Region < status.Observations[j].Region
+		}
+		return status.Observations[i].IPAddress < status.Observations[j].IPAddress
+	})
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		status.Message = fmt.Sprintf("%d of the %d targets are unhealthy: [%s]", len(unhealthy), len(statuses), strings.Join(unhealthy, ", "))
+	}
+	return status
+}
+
 func init() {
 	sid := xid.New()
 	callerReference = func(s string) *string {
@@ -196,22 +731,76 @@ func init() {
 	}
 }
 
-func healthCheckType(protocol *dns.HealthCheckProtocol) *string {
-	if protocol == nil {
+func healthCheckType(spec dns.HealthCheckSpec) *string {
+	if spec.Protocol == nil {
 		return nil
 	}
 
-	switch *protocol {
+	switch *spec.Protocol {
 	case dns.HealthCheckProtocolHTTP:
+		if spec.SearchString != "" {
+			return aws.String(route53.HealthCheckTypeHttpStrMatch)
+		}
 		return aws.String(route53.HealthCheckTypeHttp)
 
 	case dns.HealthCheckProtocolHTTPS:
+		if spec.SearchString != "" {
+			return aws.String(route53.HealthCheckTypeHttpsStrMatch)
+		}
 		return aws.String(route53.HealthCheckTypeHttps)
+
+	case dns.HealthCheckProtocolTCP:
+		return aws.String(route53.HealthCheckTypeTcp)
 	}
 
 	return nil
 }
 
+// resourcePath returns the path of the health check, if any, as TCP health
+// checks have none.
+func resourcePath(spec dns.HealthCheckSpec) *string {
+	if spec.Protocol != nil && *spec.Protocol == dns.HealthCheckProtocolTCP {
+		return nil
+	}
+	return &spec.Path
+}
+
+// searchString returns the search string of the health check, if any, as only
+// the HTTP(S) health checks have one.
+func searchString(spec dns.HealthCheckSpec) *string {
+	if spec.SearchString == "" || (spec.Protocol != nil && *spec.Protocol == dns.HealthCheckProtocolTCP) {
+		return nil
+	}
+	return &spec.SearchString
+}
+
+func isHealthCheckRegion(region string) bool {
+	for _, value := range route53.HealthCheckRegion_Values() {
+		if region == value {
+			return true
+		}
+	}
+	return false
+}
+
+// unorderedEqual returns whether the values, e.g. regions, are the same,
+// whatever their order.
+func unorderedEqual(values1, values2 []string) bool {
+	if len(values1) != len(values2) {
+		return false
+	}
+	sorted1 := append([]string(nil), values1...)
+	sorted2 := append([]string(nil), values2...)
+	sort.Strings(sorted1)
+	sort.Strings(sorted2)
+	for i := range sorted1 {
+		if sorted1[i] != sorted2[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func strValuesEqual(str1, str2 *string) bool {
 	if str1 == nil && str2 != nil {
 		return false
@@ -238,6 +827,13 @@ func intValuesEqual(int1, int2 *int64) bool {
 	return *int1 == *int2
 }
 
+func fullyQualifiedDomainName(healthCheck *route53.HealthCheck) string {
+	if healthCheck.HealthCheckConfig == nil {
+		return ""
+	}
+	return aws.StringValue(healthCheck.HealthCheckConfig.FullyQualifiedDomainName)
+}
+
 func getHealthCheckId(endpoint *v1.Endpoint) (string, bool) {
 	return endpoint.GetProviderSpecific(ProviderSpecificHealthCheckID)
 }
@@ -0,0 +1,182 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+func Test_isHealthy(t *testing.T) {
+	observation := func(status string) *route53.HealthCheckObservation {
+		return &route53.HealthCheckObservation{
+			StatusReport: &route53.StatusReport{Status: aws.String(status)},
+		}
+	}
+	success := observation("Success: HTTP Status Code 200, OK")
+	failure := observation("Failure: Connection timed out.")
+
+	tests := []struct {
+		name         string
+		observations []*route53.HealthCheckObservation
+		want         bool
+	}{
+		{name: "no observations", want: true},
+		{name: "all successful", observations: []*route53.HealthCheckObservation{success, success}, want: true},
+		{name: "all failed", observations: []*route53.HealthCheckObservation{failure, failure}, want: false},
+		{name: "one of five successful", observations: []*route53.HealthCheckObservation{success, failure, failure, failure, failure}, want: true},
+		{name: "one of six successful", observations: []*route53.HealthCheckObservation{success, failure, failure, failure, failure, failure}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isHealthy(tt.observations); got != tt.want {
+				t.Errorf("isHealthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_healthStatus(t *testing.T) {
+	earlier := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(10 * time.Second)
+	observations := []*route53.HealthCheckObservation{
+		{
+			Region:       aws.String("us-west-1"),
+			IPAddress:    aws.String("15.177.2.1"),
+			StatusReport: &route53.StatusReport{Status: aws.String("Failure: Connection timed out."), CheckedTime: &later},
+		},
+		{
+			Region:       aws.String("eu-west-1"),
+			IPAddress:    aws.String("15.177.62.1"),
+			StatusReport: &route53.StatusReport{Status: aws.String("Success: HTTP Status Code 200, OK"), CheckedTime: &earlier},
+		},
+	}
+
+	status := healthStatus(observations)
+	if !status.Healthy {
+		t.Errorf("healthStatus().Healthy = false, want true")
+	}
+	if status.LastCheckedTime == nil || !status.LastCheckedTime.Time.Equal(later) {
+		t.Errorf("healthStatus().LastCheckedTime = %v, want %v", status.LastCheckedTime, later)
+	}
+	if status.Message != "1 of the 2 health checkers observe the endpoint as unhealthy" {
+		t.Errorf("healthStatus().Message = %q", status.Message)
+	}
+	if len(status.Observations) != 2 || status.Observations[0].Region != "eu-west-1" || !status.Observations[0].Healthy || status.Observations[1].Healthy {
+		t.Errorf("healthStatus().Observations = %+v, want sorted by region", status.Observations)
+	}
+}
+
+func Test_targetsHealthStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(10 * time.Second))
+	healthy := &v1.EndpointHealthStatus{
+		Healthy:         true,
+		LastCheckedTime: &earlier,
+		Observations:    []v1.HealthCheckObservation{{Region: "us-west-1", Healthy: true}},
+	}
+	unhealthy := &v1.EndpointHealthStatus{
+		LastCheckedTime: &later,
+		Observations:    []v1.HealthCheckObservation{{Region: "eu-west-1"}},
+	}
+
+	tests := []struct {
+		name        string
+		statuses    map[string]*v1.EndpointHealthStatus
+		wantHealthy bool
+		wantMessage string
+	}{
+		{name: "not observed", wantHealthy: true},
+		{name: "all healthy", statuses: map[string]*v1.EndpointHealthStatus{"1.1.1.1": healthy, "2.2.2.2": healthy}, wantHealthy: true},
+		{
+			name:        "one unhealthy",
+			statuses:    map[string]*v1.EndpointHealthStatus{"1.1.1.1": healthy, "2.2.2.2": unhealthy},
+			wantHealthy: true,
+			wantMessage: "1 of the 2 targets are unhealthy: [2.2.2.2]",
+		},
+		{
+			name:        "all unhealthy",
+			statuses:    map[string]*v1.EndpointHealthStatus{"1.1.1.1": unhealthy, "2.2.2.2": unhealthy},
+			wantHealthy: false,
+			wantMessage: "2 of the 2 targets are unhealthy: [1.1.1.1, 2.2.2.2]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := targetsHealthStatus(tt.statuses)
+			if status.Healthy != tt.wantHealthy {
+				t.Errorf("targetsHealthStatus().Healthy = %v, want %v", status.Healthy, tt.wantHealthy)
+			}
+			if status.Message != tt.wantMessage {
+				t.Errorf("targetsHealthStatus().Message = %q, want %q", status.Message, tt.wantMessage)
+			}
+		})
+	}
+
+	status := targetsHealthStatus(map[string]*v1.EndpointHealthStatus{"1.1.1.1": healthy, "2.2.2.2": unhealthy})
+	if status.LastCheckedTime == nil || !status.LastCheckedTime.Equal(&later) {
+		t.Errorf("targetsHealthStatus().LastCheckedTime = %v, want %v", status.LastCheckedTime, later)
+	}
+	if len(status.Observations) != 2 || status.Observations[0].Region != "eu-west-1" {
+		t.Errorf("targetsHealthStatus().Observations = %+v, want sorted by region", status.Observations)
+	}
+}
+
+func Test_needsReplacement(t *testing.T) {
+	https := dns.HealthCheckProtocolHTTPS
+	tcp := dns.HealthCheckProtocolTCP
+	healthCheck := func(checkType string, requestInterval *int64) *route53.HealthCheck {
+		return &route53.HealthCheck{
+			HealthCheckConfig: &route53.HealthCheckConfig{Type: aws.String(checkType), RequestInterval: requestInterval},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		healthCheck *route53.HealthCheck
+		spec        dns.HealthCheckSpec
+		want        bool
+	}{
+		{name: "unchanged", healthCheck: healthCheck("HTTPS", aws.Int64(30)), spec: dns.HealthCheckSpec{Protocol: &https}, want: false},
+		{name: "default request interval", healthCheck: healthCheck("HTTPS", nil), spec: dns.HealthCheckSpec{Protocol: &https, RequestInterval: aws.Int64(30)}, want: false},
+		{name: "request interval changed", healthCheck: healthCheck("HTTPS", aws.Int64(30)), spec: dns.HealthCheckSpec{Protocol: &https, RequestInterval: aws.Int64(10)}, want: true},
+		{name: "protocol changed", healthCheck: healthCheck("HTTPS", aws.Int64(30)), spec: dns.HealthCheckSpec{Protocol: &tcp}, want: true},
+		{name: "search string set", healthCheck: healthCheck("HTTPS", aws.Int64(30)), spec: dns.HealthCheckSpec{Protocol: &https, SearchString: "ok"}, want: true},
+		{name: "search string unchanged", healthCheck: healthCheck("HTTPS_STR_MATCH", aws.Int64(30)), spec: dns.HealthCheckSpec{Protocol: &https, SearchString: "ok"}, want: false},
+		{name: "health check of several targets", healthCheck: healthCheck("CALCULATED", nil), spec: dns.HealthCheckSpec{}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsReplacement(tt.healthCheck, tt.spec); got != tt.want {
+				t.Errorf("needsReplacement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoute53HealthCheckReconciler_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions []string
+		wantErr bool
+	}{
+		{name: "default regions", wantErr: false},
+		{name: "valid regions", regions: []string{"us-east-1", "eu-west-1", "ap-southeast-1"}, wantErr: false},
+		{name: "too few regions", regions: []string{"us-east-1", "eu-west-1"}, wantErr: true},
+		{name: "unknown region", regions: []string{"us-east-1", "eu-west-1", "eu-north-1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Route53HealthCheckReconciler{}).Validate(dns.HealthCheckSpec{Regions: tt.regions})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -75,6 +75,69 @@ var (
 		},
 		[]string{operationLabel, returnCodeLabel},
 	)
+
+	// route53ChangePropagationDuration is a prometheus metric which records
+	// the duration of the propagation of the changes to the records, from
+	// their submission until they are in sync on all the Route53 name servers.
+	route53ChangePropagationDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name: "glbc_aws_route53_change_propagation_duration_seconds",
+			Help: "GLBC AWS Route53 change propagation duration",
+			Buckets: []float64{
+				1, 2.5, 5, 10, 15, 20, 30, 45, 60,
+				90, 120, 180, 240, 300, 450, 600,
+			},
+		},
+	)
+
+	// route53ChangeBatchSize is a prometheus metric which records the number
+	// of changes in the batches of changes applied to the records.
+	route53ChangeBatchSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "glbc_aws_route53_change_batch_size",
+			Help:    "GLBC AWS Route53 number of changes per batch",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+	)
+
+	// route53RateLimit is a prometheus metric which holds the current rate
+	// limit of the requests to Route53.
+	route53RateLimit = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "glbc_aws_route53_rate_limit",
+			Help: "GLBC AWS Route53 request rate limit, in requests per second",
+		},
+	)
+
+	// route53ThrottlingBackoff is a prometheus metric which holds the delay
+	// after which the throttled requests to Route53 are retried.
+	route53ThrottlingBackoff = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "glbc_aws_route53_throttling_backoff_seconds",
+			Help: "GLBC AWS Route53 delay before retrying throttled requests",
+		},
+	)
+
+	// route53ThrottledTotal is a prometheus counter metrics which holds the
+	// total number of requests to Route53 that were throttled.
+	route53ThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "glbc_aws_route53_throttled_total",
+			Help: "GLBC AWS Route53 total number of throttled requests",
+		},
+		[]string{operationLabel},
+	)
+
+	// route53RateLimiterWaitDuration is a prometheus metric which records the
+	// duration the requests to Route53 wait for the rate limiter.
+	route53RateLimiterWaitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "glbc_aws_route53_rate_limiter_wait_duration_seconds",
+			Help:    "GLBC AWS Route53 duration requests wait for the rate limiter",
+			Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.2, 0.5, 1, 2, 5, 10, 30, 60},
+		},
+		[]string{operationLabel},
+	)
 )
 
 var operationLabelValues []string
@@ -86,6 +149,12 @@ func init() {
 		route53RequestTotal,
 		route53RequestErrors,
 		route53RequestDuration,
+		route53ChangePropagationDuration,
+		route53ChangeBatchSize,
+		route53RateLimit,
+		route53ThrottlingBackoff,
+		route53ThrottledTotal,
+		route53RateLimiterWaitDuration,
 	)
 
 	monitoredRoute53 := reflect.PtrTo(reflect.TypeOf(InstrumentedRoute53{}))
@@ -98,5 +167,6 @@ func init() {
 		route53RequestCount.WithLabelValues(operation).Set(0)
 		route53RequestTotal.WithLabelValues(operation, returnCodeLabelDefault).Add(0)
 		route53RequestErrors.WithLabelValues(operation, returnCodeLabelDefault).Add(0)
+		route53ThrottledTotal.WithLabelValues(operation).Add(0)
 	}
 }
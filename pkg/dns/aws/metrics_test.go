@@ -24,11 +24,17 @@ func TestRegister(t *testing.T) {
 	g := gomega.NewWithT(t)
 	g.Expect(operationLabelValues).To(gomega.ConsistOf(
 		"ListHostedZones",
+		"GetHostedZone",
+		"ListResourceRecordSetsPages",
 		"ChangeResourceRecordSets",
+		"GetChange",
 		"CreateHealthCheck",
 		"GetHealthCheckWithContext",
 		"UpdateHealthCheckWithContext",
 		"DeleteHealthCheckWithContext",
+		"GetHealthCheckStatusWithContext",
 		"ChangeTagsForResourceWithContext",
+		"ListHealthChecksPagesWithContext",
+		"ListTagsForResourcesWithContext",
 	))
 }
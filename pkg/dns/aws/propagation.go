@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+type changeKey struct {
+	record string
+	zoneID string
+}
+
+type pendingChange struct {
+	id          string
+	submittedAt time.Time
+}
+
+func changeKeyFor(record *v1.DNSRecord, zoneID string) (changeKey, bool) {
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		return changeKey{}, false
+	}
+	return changeKey{record: key, zoneID: zoneID}, true
+}
+
+// trackChange records the change last applied to the records of the DNSRecord
+// in the zone, until it is in sync.
+func (p *Provider) trackChange(record *v1.DNSRecord, zoneID string, info *route53.ChangeInfo) {
+	key, ok := changeKeyFor(record, zoneID)
+	if !ok {
+		return
+	}
+	p.pendingChangesLock.Lock()
+	defer p.pendingChangesLock.Unlock()
+	if info == nil || aws.StringValue(info.Status) == route53.ChangeStatusInsync {
+		delete(p.pendingChanges, key)
+		return
+	}
+	submittedAt := time.Now()
+	if info.SubmittedAt != nil {
+		submittedAt = *info.SubmittedAt
+	}
+	p.pendingChanges[key] = pendingChange{id: aws.StringValue(info.Id), submittedAt: submittedAt}
+}
+
+func (p *Provider) forgetChange(record *v1.DNSRecord, zoneID string) {
+	if key, ok := changeKeyFor(record, zoneID); ok {
+		p.pendingChangesLock.Lock()
+		defer p.pendingChangesLock.Unlock()
+		delete(p.pendingChanges, key)
+	}
+}
+
+// Propagated returns whether the change last applied to the records of the
+// DNSRecord in the zone is in sync on all the Route53 name servers, as reported
+// by GetChange. As the changes are only tracked in memory, the records are
+// considered propagated if no change is pending, e.g., after a restart.
+func (p *Provider) Propagated(record *v1.DNSRecord, zone v1.DNSZone) (bool, error) {
+	key, ok := changeKeyFor(record, zone.ID)
+	if !ok {
+		return true, nil
+	}
+	p.pendingChangesLock.Lock()
+	change, pending := p.pendingChanges[key]
+	p.pendingChangesLock.Unlock()
+	if !pending {
+		return true, nil
+	}
+
+	output, err := p.route53.GetChange(&route53.GetChangeInput{Id: aws.String(change.id)})
+	if err != nil {
+		return false, fmt.Errorf("failed to get change %s: %v", change.id, err)
+	}
+	if aws.StringValue(output.ChangeInfo.Status) != route53.ChangeStatusInsync {
+		return false, nil
+	}
+
+	p.pendingChangesLock.Lock()
+	// The change may have been superseded in the meantime
+	superseded := p.pendingChanges[key] != change
+	if !superseded {
+		delete(p.pendingChanges, key)
+	}
+	p.pendingChangesLock.Unlock()
+	route53ChangePropagationDuration.Observe(time.Since(change.submittedAt).Seconds())
+	p.logger.Info("DNS record change propagated", "record", key.record, "zone", zone.ID, "change", change.id)
+	return !superseded, nil
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+func TestPropagated(t *testing.T) {
+	status := route53.ChangeStatusPending
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<GetChangeResponse><ChangeInfo><Id>/change/C1</Id><Status>%s</Status></ChangeInfo></GetChangeResponse>`, status)
+	}))
+	defer server.Close()
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: credentials.AnonymousCredentials,
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-east-1"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	p := &Provider{
+		route53:        &InstrumentedRoute53{route53: route53.New(sess)},
+		pendingChanges: map[changeKey]pendingChange{},
+		logger:         logr.Discard(),
+	}
+	record := &v1.DNSRecord{ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"}}
+	zone := v1.DNSZone{ID: "Z1"}
+
+	if propagated, err := p.Propagated(record, zone); err != nil || !propagated {
+		t.Errorf("expected untracked change to be propagated, got %v, %v", propagated, err)
+	}
+
+	p.trackChange(record, zone.ID, &route53.ChangeInfo{Id: aws.String("/change/C1"), Status: aws.String(status), SubmittedAt: aws.Time(time.Now())})
+	if propagated, err := p.Propagated(record, zone); err != nil || propagated {
+		t.Errorf("expected pending change not to be propagated, got %v, %v", propagated, err)
+	}
+
+	status = route53.ChangeStatusInsync
+	if propagated, err := p.Propagated(record, zone); err != nil || !propagated {
+		t.Errorf("expected in sync change to be propagated, got %v, %v", propagated, err)
+	}
+	if len(p.pendingChanges) != 0 {
+		t.Errorf("expected the propagated change to be forgotten, got %v", p.pendingChanges)
+	}
+}
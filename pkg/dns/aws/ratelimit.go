@@ -0,0 +1,140 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"golang.org/x/time/rate"
+
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const (
+	// defaultRequestRate is the Route53 API request rate limit per account, in
+	// requests per second.
+	defaultRequestRate = 5
+	// minRequestRate is the request rate the limiter does not back off below.
+	minRequestRate = 0.25
+	// requestRateIncrease is the request rate the limiter recovers by, after
+	// each successful request.
+	requestRateIncrease = 0.1
+
+	minThrottlingBackoff = time.Second
+	maxThrottlingBackoff = 2 * time.Minute
+
+	// See https://docs.aws.amazon.com/Route53/latest/APIReference/API_ChangeResourceRecordSets.html#API_ChangeResourceRecordSets_Errors
+	errCodeThrottling               = "Throttling"
+	errCodePriorRequestNotComplete  = "PriorRequestNotComplete"
+	errCodeThrottlingException      = "ThrottlingException"
+	errCodeRequestLimitExceeded     = "RequestLimitExceeded"
+	errCodeTooManyRequestsException = "TooManyRequestsException"
+)
+
+// adaptiveRateLimiter is a token bucket rate limiter for the requests to the
+// Route53 API, which backs off multiplicatively when the requests are
+// throttled, and recovers additively as the requests succeed.
+type adaptiveRateLimiter struct {
+	limiter *rate.Limiter
+	maxRate rate.Limit
+	lock    sync.Mutex
+	backoff time.Duration
+}
+
+func newAdaptiveRateLimiter(requestRate float64) *adaptiveRateLimiter {
+	l := &adaptiveRateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(requestRate), 1),
+		maxRate: rate.Limit(requestRate),
+	}
+	route53RateLimit.Set(requestRate)
+	return l
+}
+
+// wait blocks until a request can be sent.
+func (l *adaptiveRateLimiter) wait(operation string) {
+	if l == nil {
+		return
+	}
+	start := time.Now()
+	// The limiter burst is 1, and the context is never done, so it cannot fail
+	_ = l.limiter.Wait(context.Background())
+	route53RateLimiterWaitDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// record adapts the request rate to the outcome of a request.
+func (l *adaptiveRateLimiter) record(operation string, err error) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limit := l.limiter.Limit()
+	switch {
+	case isThrottling(err):
+		route53ThrottledTotal.WithLabelValues(operation).Inc()
+		limit /= 2
+		if limit < minRequestRate {
+			limit = minRequestRate
+		}
+		l.backoff *= 2
+		if l.backoff < minThrottlingBackoff {
+			l.backoff = minThrottlingBackoff
+		}
+		if l.backoff > maxThrottlingBackoff {
+			l.backoff = maxThrottlingBackoff
+		}
+	case err == nil:
+		limit += requestRateIncrease
+		if limit > l.maxRate {
+			limit = l.maxRate
+		}
+		l.backoff = 0
+	default:
+		return
+	}
+	l.limiter.SetLimit(limit)
+	route53RateLimit.Set(float64(limit))
+	route53ThrottlingBackoff.Set(l.backoff.Seconds())
+}
+
+// retryAfter returns the delay after which the throttled requests should be
+// retried.
+func (l *adaptiveRateLimiter) retryAfter() time.Duration {
+	if l == nil {
+		return minThrottlingBackoff
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.backoff < minThrottlingBackoff {
+		return minThrottlingBackoff
+	}
+	return l.backoff
+}
+
+// retryable returns a dns.RetryableError for the errors returned when the
+// requests are throttled, or the error unchanged.
+func (c *InstrumentedRoute53) retryable(err error) error {
+	if !isThrottling(err) {
+		return err
+	}
+	return &dns.RetryableError{
+		Err:        fmt.Errorf("route53 requests are throttled: %w", err),
+		RetryAfter: c.limiter.retryAfter(),
+	}
+}
+
+func isThrottling(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		switch awsErr.Code() {
+		case errCodeThrottling, errCodePriorRequestNotComplete, errCodeThrottlingException,
+			errCodeRequestLimitExceeded, errCodeTooManyRequestsException:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"golang.org/x/time/rate"
+
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	l := newAdaptiveRateLimiter(defaultRequestRate)
+	throttled := awserr.New(errCodeThrottling, "Rate exceeded", nil)
+
+	l.record("ChangeResourceRecordSets", throttled)
+	l.record("ChangeResourceRecordSets", awserr.New(errCodePriorRequestNotComplete, "The request was rejected", nil))
+	if limit := l.limiter.Limit(); limit != rate.Limit(defaultRequestRate)/4 {
+		t.Errorf("expected the rate limit to back off to %v, got %v", rate.Limit(defaultRequestRate)/4, limit)
+	}
+	if retryAfter := l.retryAfter(); retryAfter != 2*minThrottlingBackoff {
+		t.Errorf("expected to retry after %v, got %v", 2*minThrottlingBackoff, retryAfter)
+	}
+
+	// Other errors do not change the rate limit
+	l.record("ChangeResourceRecordSets", errors.New("connection refused"))
+	if limit := l.limiter.Limit(); limit != rate.Limit(defaultRequestRate)/4 {
+		t.Errorf("expected the rate limit to remain %v, got %v", rate.Limit(defaultRequestRate)/4, limit)
+	}
+
+	for i := 0; i < 100; i++ {
+		l.record("ChangeResourceRecordSets", nil)
+	}
+	if limit := l.limiter.Limit(); limit != defaultRequestRate {
+		t.Errorf("expected the rate limit to recover to %v, got %v", defaultRequestRate, limit)
+	}
+	if retryAfter := l.retryAfter(); retryAfter != minThrottlingBackoff {
+		t.Errorf("expected to retry after %v, got %v", minThrottlingBackoff, retryAfter)
+	}
+
+	client := &InstrumentedRoute53{limiter: l}
+	retryable, ok := dns.AsRetryableError(client.retryable(throttled))
+	if !ok || retryable.RetryAfter < time.Second {
+		t.Errorf("expected a retryable error, got %v", retryable)
+	}
+	if _, ok := dns.AsRetryableError(client.retryable(errors.New("connection refused"))); ok {
+		t.Errorf("expected a non retryable error")
+	}
+}
@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ dns.RecordReader = &Provider{}
+
+// Records returns the records of the hosted zone. Alias records are left out,
+// as they cannot be published from endpoints.
+func (p *Provider) Records(zone v1.DNSZone) ([]*v1.Endpoint, error) {
+	var endpoints []*v1.Endpoint
+	input := &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zone.ID)}
+	err := p.route53.ListResourceRecordSetsPages(input, func(output *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		for _, recordSet := range output.ResourceRecordSets {
+			if recordSet.AliasTarget != nil {
+				continue
+			}
+			endpoints = append(endpoints, endpointForRecordSet(recordSet))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, p.route53.retryable(fmt.Errorf("failed to list records of hosted zone %s: %w", zone.ID, err))
+	}
+	return endpoints, nil
+}
+
+func endpointForRecordSet(recordSet *route53.ResourceRecordSet) *v1.Endpoint {
+	endpoint := &v1.Endpoint{
+		// Route53 escapes the wildcard character
+		DNSName:       strings.Replace(strings.TrimSuffix(aws.StringValue(recordSet.Name), "."), `\052`, "*", 1),
+		RecordType:    aws.StringValue(recordSet.Type),
+		SetIdentifier: aws.StringValue(recordSet.SetIdentifier),
+		RecordTTL:     v1.TTL(aws.Int64Value(recordSet.TTL)),
+	}
+	for _, resourceRecord := range recordSet.ResourceRecords {
+		target := aws.StringValue(resourceRecord.Value)
+		if endpoint.RecordType == route53.RRTypeTxt {
+			if unquoted, err := strconv.Unquote(target); err == nil {
+				target = unquoted
+			}
+		}
+		endpoint.Targets = append(endpoint.Targets, target)
+	}
+
+	if recordSet.Weight != nil {
+		endpoint.SetProviderSpecific(ProviderSpecificWeight, strconv.FormatInt(aws.Int64Value(recordSet.Weight), 10))
+	}
+	if recordSet.Region != nil {
+		endpoint.SetProviderSpecific(ProviderSpecificRegion, aws.StringValue(recordSet.Region))
+	}
+	if recordSet.Failover != nil {
+		endpoint.SetProviderSpecific(ProviderSpecificFailover, aws.StringValue(recordSet.Failover))
+	}
+	if recordSet.GeoLocation != nil {
+		if recordSet.GeoLocation.ContinentCode != nil {
+			endpoint.SetProviderSpecific(ProviderSpecificGeolocationContinentCode, aws.StringValue(recordSet.GeoLocation.ContinentCode))
+		}
+		if recordSet.GeoLocation.CountryCode != nil {
+			endpoint.SetProviderSpecific(ProviderSpecificGeolocationCountryCode, aws.StringValue(recordSet.GeoLocation.CountryCode))
+		}
+	}
+	if aws.BoolValue(recordSet.MultiValueAnswer) {
+		endpoint.SetProviderSpecific(ProviderSpecificMultiValueAnswer, "true")
+	}
+	if recordSet.HealthCheckId != nil {
+		endpoint.SetProviderSpecific(ProviderSpecificHealthCheckID, aws.StringValue(recordSet.HealthCheckId))
+	}
+	return endpoint
+}
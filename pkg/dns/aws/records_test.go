@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+func TestEndpointForRecordSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		recordSet   *route53.ResourceRecordSet
+		wantDNSName string
+		wantTarget  string
+		wantWeight  string
+	}{
+		{
+			name: "weighted A record",
+			recordSet: &route53.ResourceRecordSet{
+				Name:            aws.String("abc.example.com."),
+				Type:            aws.String(route53.RRTypeA),
+				SetIdentifier:   aws.String("1.1.1.1"),
+				Weight:          aws.Int64(120),
+				TTL:             aws.Int64(60),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("1.1.1.1")}},
+			},
+			wantDNSName: "abc.example.com",
+			wantTarget:  "1.1.1.1",
+			wantWeight:  "120",
+		},
+		{
+			name: "wildcard record",
+			recordSet: &route53.ResourceRecordSet{
+				Name:            aws.String(`\052.example.com.`),
+				Type:            aws.String(route53.RRTypeA),
+				TTL:             aws.Int64(60),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("1.1.1.1")}},
+			},
+			wantDNSName: "*.example.com",
+			wantTarget:  "1.1.1.1",
+		},
+		{
+			name: "wildcard ownership TXT record",
+			recordSet: &route53.ResourceRecordSet{
+				Name:            aws.String("_glbc-a-wildcard.example.com."),
+				Type:            aws.String(route53.RRTypeTxt),
+				TTL:             aws.Int64(60),
+				ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(`"heritage=kcp-glbc"`)}},
+			},
+			wantDNSName: "_glbc-a-wildcard.example.com",
+			wantTarget:  "heritage=kcp-glbc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := endpointForRecordSet(tt.recordSet)
+			if endpoint.DNSName != tt.wantDNSName {
+				t.Errorf("expected DNS name %s, got %s", tt.wantDNSName, endpoint.DNSName)
+			}
+			if len(endpoint.Targets) != 1 || endpoint.Targets[0] != tt.wantTarget {
+				t.Errorf("expected target %s, got %v", tt.wantTarget, endpoint.Targets)
+			}
+			weight, _ := endpoint.GetProviderSpecificProperty(ProviderSpecificWeight)
+			if weight.Value != tt.wantWeight {
+				t.Errorf("expected weight %q, got %q", tt.wantWeight, weight.Value)
+			}
+		})
+	}
+}
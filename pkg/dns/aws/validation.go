@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+const (
+	simpleRoutingPolicy      = "simple"
+	weightedRoutingPolicy    = "weighted"
+	latencyRoutingPolicy     = "latency"
+	geolocationRoutingPolicy = "geolocation"
+	failoverRoutingPolicy    = "failover"
+	multiValueRoutingPolicy  = "multivalue"
+)
+
+// validateRoutingPolicies validates that the endpoints of each record set, i.e.
+// with the same name and type, use the same routing policy, and that they are
+// consistent with it, as Route53 would otherwise reject the changes, or route
+// the traffic unexpectedly, e.g.:
+//   - the endpoints of a routing policy other than simple have a set
+//     identifier, that is unique within the record set,
+//   - latency endpoints are in a known AWS region, that is unique within the
+//     record set,
+//   - geolocation endpoints have a location that is unique within the record
+//     set,
+//   - failover endpoints are either PRIMARY or SECONDARY, and unique within the
+//     record set.
+func validateRoutingPolicies(endpoints []*v1.Endpoint) error {
+	type recordSet struct {
+		policy         string
+		setIdentifiers map[string]bool
+		values         map[string]string
+	}
+	recordSets := map[string]*recordSet{}
+
+	var errs []error
+	for _, endpoint := range endpoints {
+		name := endpoint.DNSName + "/" + endpoint.RecordType
+		policy, value := routingPolicyOf(endpoint)
+		set, ok := recordSets[name]
+		if !ok {
+			set = &recordSet{policy: policy, setIdentifiers: map[string]bool{}, values: map[string]string{}}
+			recordSets[name] = set
+		}
+
+		if policy != set.policy {
+			errs = append(errs, fmt.Errorf("%s record %s mixes the %s and %s routing policies", endpoint.RecordType, endpoint.DNSName, set.policy, policy))
+			continue
+		}
+		if policy == simpleRoutingPolicy {
+			continue
+		}
+		if endpoint.SetIdentifier == "" {
+			errs = append(errs, fmt.Errorf("%s record %s has no set identifier, required by the %s routing policy", endpoint.RecordType, endpoint.DNSName, policy))
+			continue
+		}
+		if set.setIdentifiers[endpoint.SetIdentifier] {
+			errs = append(errs, fmt.Errorf("%s record %s has duplicate set identifier %s", endpoint.RecordType, endpoint.DNSName, endpoint.SetIdentifier))
+		}
+		set.setIdentifiers[endpoint.SetIdentifier] = true
+
+		switch policy {
+		case latencyRoutingPolicy:
+			if !isKnownRegion(value) {
+				errs = append(errs, fmt.Errorf("%s record %s with set identifier %s has unknown region %q", endpoint.RecordType, endpoint.DNSName, endpoint.SetIdentifier, value))
+			}
+		case failoverRoutingPolicy:
+			if value != "PRIMARY" && value != "SECONDARY" {
+				errs = append(errs, fmt.Errorf("%s record %s with set identifier %s has invalid failover %q, must be PRIMARY or SECONDARY", endpoint.RecordType, endpoint.DNSName, endpoint.SetIdentifier, value))
+			}
+		}
+		switch policy {
+		case latencyRoutingPolicy, geolocationRoutingPolicy, failoverRoutingPolicy:
+			if other, found := set.values[value]; found {
+				errs = append(errs, fmt.Errorf("%s records %s with set identifiers %s and %s have the same %s %s", endpoint.RecordType, endpoint.DNSName, other, endpoint.SetIdentifier, policy, value))
+			}
+			set.values[value] = endpoint.SetIdentifier
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// routingPolicyOf returns the routing policy of the endpoint, and the value
+// the traffic is routed by, i.e., the region, location, or failover.
+func routingPolicyOf(endpoint *v1.Endpoint) (string, string) {
+	if prop, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificRegion); ok {
+		return latencyRoutingPolicy, prop.Value
+	}
+	continent, hasContinent := endpoint.GetProviderSpecificProperty(ProviderSpecificGeolocationContinentCode)
+	country, hasCountry := endpoint.GetProviderSpecificProperty(ProviderSpecificGeolocationCountryCode)
+	if hasContinent || hasCountry {
+		return geolocationRoutingPolicy, continent.Value + "/" + country.Value
+	}
+	if prop, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificFailover); ok {
+		return failoverRoutingPolicy, prop.Value
+	}
+	if _, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificWeight); ok {
+		return weightedRoutingPolicy, ""
+	}
+	if _, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificMultiValueAnswer); ok {
+		return multiValueRoutingPolicy, ""
+	}
+	return simpleRoutingPolicy, ""
+}
+
+func isKnownRegion(region string) bool {
+	for _, partition := range endpoints.DefaultPartitions() {
+		if _, ok := partition.Regions()[region]; ok {
+			return true
+		}
+	}
+	return false
+}
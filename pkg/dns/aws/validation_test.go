@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"testing"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+func TestValidateRoutingPolicies(t *testing.T) {
+	endpoint := func(name, setIdentifier, property, value string) *v1.Endpoint {
+		e := &v1.Endpoint{
+			DNSName:       name,
+			RecordType:    string(v1.CNAMERecordType),
+			SetIdentifier: setIdentifier,
+			Targets:       []string{setIdentifier + "." + name},
+		}
+		if property != "" {
+			e.SetProviderSpecific(property, value)
+		}
+		return e
+	}
+
+	tests := []struct {
+		name      string
+		endpoints []*v1.Endpoint
+		wantErr   bool
+	}{
+		{
+			name: "latency",
+			endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com", "eu-west-1", ProviderSpecificRegion, "eu-west-1"),
+				endpoint("abc.example.com", "us-east-1", ProviderSpecificRegion, "us-east-1"),
+			},
+		},
+		{
+			name: "unknown region",
+			endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com", "mars-north-1", ProviderSpecificRegion, "mars-north-1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate region",
+			endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com", "a", ProviderSpecificRegion, "eu-west-1"),
+				endpoint("abc.example.com", "b", ProviderSpecificRegion, "eu-west-1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate set identifier",
+			endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com", "a", ProviderSpecificRegion, "eu-west-1"),
+				endpoint("abc.example.com", "a", ProviderSpecificRegion, "us-east-1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing set identifier",
+			endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com", "", ProviderSpecificRegion, "eu-west-1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "mixed routing policies",
+			endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com", "eu-west-1", ProviderSpecificRegion, "eu-west-1"),
+				endpoint("abc.example.com", "default", ProviderSpecificGeolocationCountryCode, "*"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "same set identifier across names",
+			endpoints: []*v1.Endpoint{
+				endpoint("eu-west-1.abc.example.com", "1.1.1.1", ProviderSpecificWeight, "120"),
+				endpoint("us-east-1.abc.example.com", "1.1.1.1", ProviderSpecificWeight, "120"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRoutingPolicies(tt.endpoints); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const hostedZoneResourceType = "route53:hostedzone"
+
+var _ dns.ZoneDiscoverer = &Provider{}
+
+func (p *Provider) GetZone(id string) (v1.DNSZone, error) {
+	output, err := p.route53.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(id)})
+	if err != nil {
+		return v1.DNSZone{}, fmt.Errorf("failed to get hosted zone %s: %v", id, err)
+	}
+	return v1.DNSZone{
+		ID:      id,
+		DNSName: strings.TrimSuffix(aws.StringValue(output.HostedZone.Name), "."),
+	}, nil
+}
+
+// DiscoverZones returns the hosted zones with all the given tags, using the
+// resource groups tagging API.
+func (p *Provider) DiscoverZones(tags map[string]string) ([]v1.DNSZone, error) {
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: []*string{aws.String(hostedZoneResourceType)},
+	}
+	for key, value := range tags {
+		input.TagFilters = append(input.TagFilters, &resourcegroupstaggingapi.TagFilter{
+			Key:    aws.String(key),
+			Values: []*string{aws.String(value)},
+		})
+	}
+
+	var ids []string
+	err := p.tagging.GetResourcesPages(input, func(output *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, resource := range output.ResourceTagMappingList {
+			id, err := zoneIDFromARN(aws.StringValue(resource.ResourceARN))
+			if err != nil {
+				p.logger.Error(err, "Ignoring tagged resource")
+				continue
+			}
+			ids = append(ids, id)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tagged hosted zones: %v", err)
+	}
+	sort.Strings(ids)
+
+	zones := make([]v1.DNSZone, 0, len(ids))
+	for _, id := range ids {
+		zone, err := p.GetZone(id)
+		if err != nil {
+			return nil, err
+		}
+		zone.Tags = tags
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+// zoneIDFromARN returns the ID of the hosted zone with the given ARN, e.g.
+// `arn:aws:route53:::hostedzone/Z08652651232L9P84LRSB`.
+func zoneIDFromARN(zoneARN string) (string, error) {
+	parsed, err := arn.Parse(zoneARN)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse hosted zone ARN %s: %v", zoneARN, err)
+	}
+	id := strings.TrimPrefix(parsed.Resource, "hostedzone/")
+	if id == parsed.Resource {
+		return "", fmt.Errorf("ARN %s is not a hosted zone", zoneARN)
+	}
+	return id, nil
+}
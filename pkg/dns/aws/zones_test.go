@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestZoneIDFromARN(t *testing.T) {
+	tests := []struct {
+		name    string
+		arn     string
+		id      string
+		wantErr bool
+	}{
+		{
+			name: "hosted zone",
+			arn:  "arn:aws:route53:::hostedzone/Z08652651232L9P84LRSB",
+			id:   "Z08652651232L9P84LRSB",
+		},
+		{
+			name:    "health check",
+			arn:     "arn:aws:route53:::healthcheck/abcdef",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			arn:     "Z08652651232L9P84LRSB",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := zoneIDFromARN(tt.arn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if id != tt.id {
+				t.Errorf("expected zone ID %s, got %s", tt.id, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/log"
+)
+
+var _ glbcdns.Provider = &Provider{}
+
+// Provider manages records in Azure DNS zones.
+//
+// Azure DNS record sets group every record sharing a name and a type, and do
+// not support weighted routing. Endpoints sharing the same DNS name are
+// therefore published as a single record set holding the union of their
+// targets, which Azure DNS serves in a round-robin fashion. Endpoints with an
+// `aws/weight` of 0 are left out, unless all of them are, so that a cluster
+// can still be drained.
+type Provider struct {
+	recordSets            dns.RecordSetsClient
+	zones                 dns.ZonesClient
+	healthCheckReconciler *healthCheckReconciler
+	config                Config
+	logger                logr.Logger
+}
+
+// Config is the necessary input to configure the manager.
+type Config struct {
+	// SubscriptionID is the Azure subscription the DNS zones belong to.
+	SubscriptionID string
+	// ResourceGroup is the resource group the DNS zones belong to.
+	ResourceGroup string
+	// TenantID, ClientID and ClientSecret are the credentials of the service
+	// principal used to authenticate to Azure.
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// Endpoint overrides the Azure Resource Manager endpoint. It is meant to
+	// target a local stand-in of the API, hence requests to it are not authenticated.
+	// +optional
+	Endpoint string
+}
+
+func NewProvider(config Config) (*Provider, error) {
+	if len(config.SubscriptionID) == 0 || len(config.ResourceGroup) == 0 {
+		return nil, fmt.Errorf("an Azure subscription and resource group are required")
+	}
+
+	endpoint := azure.PublicCloud.ResourceManagerEndpoint
+	var authorizer autorest.Authorizer = autorest.NullAuthorizer{}
+	if len(config.Endpoint) > 0 {
+		endpoint = config.Endpoint
+	} else {
+		oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, config.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create Azure OAuth config: %v", err)
+		}
+		token, err := adal.NewServicePrincipalToken(*oauthConfig, config.ClientID, config.ClientSecret, azure.PublicCloud.ResourceManagerEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create Azure service principal token: %v", err)
+		}
+		authorizer = autorest.NewBearerAuthorizer(token)
+	}
+
+	p := &Provider{
+		recordSets: dns.NewRecordSetsClientWithBaseURI(endpoint, config.SubscriptionID),
+		zones:      dns.NewZonesClientWithBaseURI(endpoint, config.SubscriptionID),
+		config:     config,
+		logger:     log.Logger.WithName("azure-dns").WithValues("resourceGroup", config.ResourceGroup),
+	}
+	p.recordSets.Authorizer = authorizer
+	p.zones.Authorizer = authorizer
+
+	if err := validateServiceEndpoints(p); err != nil {
+		return nil, fmt.Errorf("failed to validate Azure provider service endpoints: %v", err)
+	}
+	return p, nil
+}
+
+// validateServiceEndpoints validates that provider clients can communicate with
+// associated API endpoints by having each client make a list call.
+func validateServiceEndpoints(provider *Provider) error {
+	var errs []error
+	if _, err := provider.zones.ListByResourceGroup(context.Background(), provider.config.ResourceGroup, to.Int32Ptr(1)); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list DNS zones: %v", err))
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func (p *Provider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	ctx := context.Background()
+
+	desired, err := recordSetsForEndpoints(record.Spec.Endpoints, zone.ID)
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(desired) {
+		if _, err := p.recordSets.CreateOrUpdate(ctx, p.config.ResourceGroup, zone.ID, key.name, key.recordType, desired[key], "", ""); err != nil {
+			return fmt.Errorf("couldn't update DNS record %s in zone %s: %v", record.Name, zone.ID, err)
+		}
+	}
+
+	// Delete any previously published record sets that are no longer present in record.Spec.Endpoints
+	published, err := recordSetsForEndpoints(glbcdns.EndpointsFromZoneStatus(record, zone.ID), zone.ID)
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(published) {
+		if _, found := desired[key]; found {
+			continue
+		}
+		if err := p.deleteRecordSet(ctx, zone.ID, key); err != nil {
+			return fmt.Errorf("couldn't delete stale DNS record %s in zone %s: %v", key.name, zone.ID, err)
+		}
+	}
+
+	p.logger.Info("Upserted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	ctx := context.Background()
+
+	recordSets, err := recordSetsForEndpoints(record.Spec.Endpoints, zone.ID)
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(recordSets) {
+		if err := p.deleteRecordSet(ctx, zone.ID, key); err != nil {
+			return fmt.Errorf("couldn't delete DNS record %s in zone %s: %v", record.Name, zone.ID, err)
+		}
+	}
+
+	p.logger.Info("Deleted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) HealthCheckReconciler() glbcdns.HealthCheckReconciler {
+	if p.healthCheckReconciler == nil {
+		p.healthCheckReconciler = newHealthCheckReconciler(p.logger)
+	}
+
+	return p.healthCheckReconciler
+}
+
+func (p *Provider) deleteRecordSet(ctx context.Context, zoneName string, key recordSetKey) error {
+	response, err := p.recordSets.Delete(ctx, p.config.ResourceGroup, zoneName, key.name, key.recordType, "")
+	if err != nil && response.Response != nil && response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+type recordSetKey struct {
+	name       string
+	recordType dns.RecordType
+}
+
+// recordSetsForEndpoints groups the endpoints sharing the same name and type
+// into Azure DNS record sets, keyed by their name relative to the zone.
+func recordSetsForEndpoints(endpoints []*v1.Endpoint, zoneName string) (map[recordSetKey]dns.RecordSet, error) {
+	grouped := map[recordSetKey][]*v1.Endpoint{}
+	for _, endpoint := range endpoints {
+		name, err := relativeRecordSetName(endpoint.DNSName, zoneName)
+		if err != nil {
+			return nil, err
+		}
+		key := recordSetKey{
+			name:       name,
+			recordType: dns.RecordType(endpoint.RecordType),
+		}
+		grouped[key] = append(grouped[key], endpoint)
+	}
+
+	recordSets := make(map[recordSetKey]dns.RecordSet, len(grouped))
+	for key, endpoints := range grouped {
+		recordSet, err := recordSetForEndpoints(key.recordType, endpoints)
+		if err != nil {
+			return nil, err
+		}
+		recordSets[key] = recordSet
+	}
+	return recordSets, nil
+}
+
+func recordSetForEndpoints(recordType dns.RecordType, endpoints []*v1.Endpoint) (dns.RecordSet, error) {
+	properties := &dns.RecordSetProperties{
+		TTL: to.Int64Ptr(int64(endpoints[0].RecordTTL)),
+	}
+
+	targets := glbcdns.PublishedTargets(endpoints)
+	if len(targets) == 0 {
+		return dns.RecordSet{}, fmt.Errorf("targets is required")
+	}
+
+	switch recordType {
+	case dns.A:
+		records := make([]dns.ARecord, 0, len(targets))
+		for _, target := range targets {
+			records = append(records, dns.ARecord{Ipv4Address: to.StringPtr(target)})
+		}
+		properties.ARecords = &records
+	case dns.AAAA:
+		records := make([]dns.AaaaRecord, 0, len(targets))
+		for _, target := range targets {
+			records = append(records, dns.AaaaRecord{Ipv6Address: to.StringPtr(target)})
+		}
+		properties.AaaaRecords = &records
+	case dns.CNAME:
+		if len(targets) > 1 {
+			return dns.RecordSet{}, fmt.Errorf("CNAME record %s cannot have multiple targets", endpoints[0].DNSName)
+		}
+		properties.CnameRecord = &dns.CnameRecord{Cname: to.StringPtr(targets[0])}
+	case dns.TXT:
+		records := make([]dns.TxtRecord, 0, len(targets))
+		for _, target := range targets {
+			records = append(records, dns.TxtRecord{Value: &[]string{target}})
+		}
+		properties.TxtRecords = &records
+	default:
+		return dns.RecordSet{}, fmt.Errorf("unsupported record type %s", recordType)
+	}
+
+	return dns.RecordSet{RecordSetProperties: properties}, nil
+}
+
+// relativeRecordSetName returns the name of the record set relative to the zone.
+func relativeRecordSetName(dnsName, zoneName string) (string, error) {
+	dnsName = strings.TrimSuffix(dnsName, ".")
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	if dnsName == zoneName {
+		return "@", nil
+	}
+	if !strings.HasSuffix(dnsName, "."+zoneName) {
+		return "", fmt.Errorf("domain %s does not belong to zone %s", dnsName, zoneName)
+	}
+	return strings.TrimSuffix(dnsName, "."+zoneName), nil
+}
+
+func sortedKeys(recordSets map[recordSetKey]dns.RecordSet) []recordSetKey {
+	keys := make([]recordSetKey, 0, len(recordSets))
+	for key := range recordSets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].recordType < keys[j].recordType
+	})
+	return keys
+}
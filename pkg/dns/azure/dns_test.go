@@ -0,0 +1,229 @@
+package azure
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const (
+	testSubscription  = "test-subscription"
+	testResourceGroup = "test-rg"
+	testZone          = "example.com"
+)
+
+// azureDNS is a minimal in-memory stand-in for the Azure DNS REST API.
+type azureDNS struct {
+	mu         sync.Mutex
+	recordSets map[string]*dns.RecordSet
+	deletions  int
+}
+
+func newAzureDNS() *azureDNS {
+	return &azureDNS{recordSets: map[string]*dns.RecordSet{}}
+}
+
+func (s *azureDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zonesPath := "/subscriptions/" + testSubscription + "/resourceGroups/" + testResourceGroup + "/providers/Microsoft.Network/dnsZones"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == zonesPath:
+		// The SDK does not marshal read-only fields, e.g. the zone name
+		writeJSON(w, map[string]interface{}{"value": []map[string]interface{}{
+			{"name": testZone, "tags": map[string]string{"env": "test"}},
+			{"name": "example.org", "tags": map[string]string{"env": "prod"}},
+		}})
+
+	case r.Method == http.MethodGet && r.URL.Path == zonesPath+"/"+testZone:
+		writeJSON(w, map[string]interface{}{"name": testZone})
+
+	case strings.HasPrefix(r.URL.Path, zonesPath+"/"+testZone+"/"):
+		// The key is the record type and the relative record set name, e.g., A/abc
+		key := strings.TrimPrefix(r.URL.Path, zonesPath+"/"+testZone+"/")
+		switch r.Method {
+		case http.MethodPut:
+			recordSet := &dns.RecordSet{}
+			if err := json.NewDecoder(r.Body).Decode(recordSet); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			s.recordSets[key] = recordSet
+			writeJSON(w, recordSet)
+		case http.MethodDelete:
+			if _, ok := s.recordSets[key]; !ok {
+				http.Error(w, "record set not found", http.StatusNotFound)
+				return
+			}
+			delete(s.recordSets, key)
+			s.deletions++
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestProvider(t *testing.T) (*Provider, *azureDNS) {
+	api := newAzureDNS()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	provider, err := NewProvider(Config{
+		SubscriptionID: testSubscription,
+		ResourceGroup:  testResourceGroup,
+		Endpoint:       server.URL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	return provider, api
+}
+
+func weightedEndpoint(dnsName, target, weight string) *v1.Endpoint {
+	endpoint := &v1.Endpoint{
+		DNSName:       dnsName,
+		RecordType:    string(v1.ARecordType),
+		SetIdentifier: target,
+		Targets:       v1.Targets{target},
+		RecordTTL:     60,
+	}
+	endpoint.SetProviderSpecific(glbcdns.ProviderSpecificWeight, weight)
+	return endpoint
+}
+
+func ipv4Addresses(recordSet *dns.RecordSet) []string {
+	var addresses []string
+	for _, record := range *recordSet.ARecords {
+		addresses = append(addresses, *record.Ipv4Address)
+	}
+	return addresses
+}
+
+func TestEnsureWeightedRecord(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				weightedEndpoint("abc.example.com", "1.1.1.1", "120"),
+				weightedEndpoint("abc.example.com", "2.2.2.2", "60"),
+				weightedEndpoint("abc.example.com", "3.3.3.3", "0"),
+			},
+		},
+	}
+
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	g.Expect(api.recordSets).To(gomega.HaveKey("A/abc"))
+	recordSet := api.recordSets["A/abc"]
+	g.Expect(*recordSet.TTL).To(gomega.Equal(int64(60)))
+	// Weights are not supported, and drained endpoints are left out
+	g.Expect(ipv4Addresses(recordSet)).To(gomega.Equal([]string{"1.1.1.1", "2.2.2.2"}))
+
+	// All the endpoints being drained, their targets are kept
+	record.Spec.Endpoints = record.Spec.Endpoints[2:]
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(ipv4Addresses(api.recordSets["A/abc"])).To(gomega.Equal([]string{"3.3.3.3"}))
+}
+
+func TestEnsureDeletesStaleRecords(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	stale := weightedEndpoint("old.example.com", "1.1.1.1", "120")
+	g.Expect(provider.Ensure(&v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{stale}},
+	}, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.recordSets).To(gomega.HaveKey("A/old"))
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{weightedEndpoint("new.example.com", "1.1.1.1", "120")},
+		},
+		Status: v1.DNSRecordStatus{
+			Zones: []v1.DNSZoneStatus{{
+				DNSZone:   v1.DNSZone{ID: testZone},
+				Endpoints: []*v1.Endpoint{stale},
+			}},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	g.Expect(api.recordSets).NotTo(gomega.HaveKey("A/old"))
+	g.Expect(api.recordSets).To(gomega.HaveKey("A/new"))
+}
+
+func TestEnsureRecordOutsideZone(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{weightedEndpoint("abc.example.org", "1.1.1.1", "120")},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.MatchError(gomega.ContainSubstring("does not belong to zone")))
+	g.Expect(api.recordSets).To(gomega.BeEmpty())
+}
+
+func TestDelete(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				{
+					DNSName:    "example.com",
+					RecordType: string(v1.ARecordType),
+					Targets:    v1.Targets{"2.2.2.2", "1.1.1.1"},
+					RecordTTL:  60,
+				},
+			},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.recordSets).To(gomega.HaveKey("A/@"))
+	g.Expect(ipv4Addresses(api.recordSets["A/@"])).To(gomega.Equal([]string{"1.1.1.1", "2.2.2.2"}))
+
+	g.Expect(provider.Delete(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.recordSets).To(gomega.BeEmpty())
+
+	// Deleting a record that does not exist is a no-op
+	g.Expect(provider.Delete(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.deletions).To(gomega.Equal(1))
+}
+
+func TestDiscoverZones(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, _ := newTestProvider(t)
+
+	zone, err := provider.GetZone(testZone)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(zone).To(gomega.Equal(v1.DNSZone{ID: testZone, DNSName: testZone}))
+
+	zones, err := provider.DiscoverZones(map[string]string{"env": "prod"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(zones).To(gomega.Equal([]v1.DNSZone{{ID: "example.org", DNSName: "example.org", Tags: map[string]string{"env": "prod"}}}))
+}
@@ -0,0 +1,85 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ glbcdns.RecordReader = &Provider{}
+
+// Records returns the A, AAAA, CNAME and TXT records of the zone.
+func (p *Provider) Records(zone v1.DNSZone) ([]*v1.Endpoint, error) {
+	ctx := context.Background()
+
+	iterator, err := p.recordSets.ListAllByDNSZoneComplete(ctx, p.config.ResourceGroup, zone.ID, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records of zone %s: %v", zone.ID, err)
+	}
+
+	var endpoints []*v1.Endpoint
+	for ; iterator.NotDone(); err = iterator.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %v", zone.ID, err)
+		}
+		if endpoint := endpointForRecordSet(iterator.Value(), zone.ID); endpoint != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records of zone %s: %v", zone.ID, err)
+	}
+	return endpoints, nil
+}
+
+// endpointForRecordSet returns the endpoint of the record set, or nil if its
+// type is not supported.
+func endpointForRecordSet(recordSet dns.RecordSet, zoneName string) *v1.Endpoint {
+	if recordSet.RecordSetProperties == nil {
+		return nil
+	}
+
+	dnsName := to.String(recordSet.Name)
+	if dnsName == "@" {
+		dnsName = zoneName
+	} else {
+		dnsName = dnsName + "." + zoneName
+	}
+	endpoint := &v1.Endpoint{
+		DNSName:   dnsName,
+		RecordTTL: v1.TTL(to.Int64(recordSet.TTL)),
+	}
+
+	properties := recordSet.RecordSetProperties
+	switch {
+	case properties.ARecords != nil:
+		endpoint.RecordType = string(v1.ARecordType)
+		for _, record := range *properties.ARecords {
+			endpoint.Targets = append(endpoint.Targets, to.String(record.Ipv4Address))
+		}
+	case properties.AaaaRecords != nil:
+		endpoint.RecordType = string(v1.AAAARecordType)
+		for _, record := range *properties.AaaaRecords {
+			endpoint.Targets = append(endpoint.Targets, to.String(record.Ipv6Address))
+		}
+	case properties.CnameRecord != nil:
+		endpoint.RecordType = string(v1.CNAMERecordType)
+		endpoint.Targets = append(endpoint.Targets, strings.TrimSuffix(to.String(properties.CnameRecord.Cname), "."))
+	case properties.TxtRecords != nil:
+		endpoint.RecordType = string(v1.TXTRecordType)
+		for _, record := range *properties.TxtRecords {
+			if record.Value != nil {
+				endpoint.Targets = append(endpoint.Targets, strings.Join(*record.Value, ""))
+			}
+		}
+	default:
+		return nil
+	}
+	return endpoint
+}
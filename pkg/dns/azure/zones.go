@@ -0,0 +1,64 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ glbcdns.ZoneDiscoverer = &Provider{}
+
+// GetZone returns the zone with the given name, which is also its domain.
+func (p *Provider) GetZone(id string) (v1.DNSZone, error) {
+	zone, err := p.zones.Get(context.Background(), p.config.ResourceGroup, id)
+	if err != nil {
+		return v1.DNSZone{}, fmt.Errorf("failed to get DNS zone %s: %v", id, err)
+	}
+	return v1.DNSZone{
+		ID:      to.String(zone.Name),
+		DNSName: to.String(zone.Name),
+	}, nil
+}
+
+// DiscoverZones returns the zones of the resource group with all the given tags.
+func (p *Provider) DiscoverZones(tags map[string]string) ([]v1.DNSZone, error) {
+	ctx := context.Background()
+
+	iterator, err := p.zones.ListByResourceGroupComplete(ctx, p.config.ResourceGroup, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS zones: %v", err)
+	}
+
+	var zones []v1.DNSZone
+	for ; iterator.NotDone(); err = iterator.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DNS zones: %v", err)
+		}
+		zone := iterator.Value()
+		if hasTags(zone, tags) {
+			zones = append(zones, v1.DNSZone{
+				ID:      to.String(zone.Name),
+				DNSName: to.String(zone.Name),
+				Tags:    tags,
+			})
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS zones: %v", err)
+	}
+	return zones, nil
+}
+
+func hasTags(zone dns.Zone, tags map[string]string) bool {
+	for key, value := range tags {
+		if tag, ok := zone.Tags[key]; !ok || to.String(tag) != value {
+			return false
+		}
+	}
+	return true
+}
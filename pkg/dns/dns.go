@@ -4,6 +4,24 @@ import (
 	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
 )
 
+// The provider-specific properties of the endpoints, set by the reconcilers
+// and translated by each provider into its own routing policies. They keep the
+// names of the Route53 properties they originate from, as they are stored in
+// the DNSRecords.
+const (
+	ProviderSpecificEvaluateTargetHealth = "aws/evaluate-target-health"
+	ProviderSpecificWeight               = "aws/weight"
+	ProviderSpecificRegion               = "aws/region"
+	ProviderSpecificFailover             = "aws/failover"
+	ProviderSpecificMultiValueAnswer     = "aws/multi-value-answer"
+	ProviderSpecificHealthCheckID        = "aws/health-check-id"
+	// ProviderSpecificGeolocationContinentCode and ProviderSpecificGeolocationCountryCode
+	// set the geolocation of the users the record is served to. The "*" country code
+	// is the default location, matching the users not matched by any other record.
+	ProviderSpecificGeolocationContinentCode = "aws/geolocation-continent-code"
+	ProviderSpecificGeolocationCountryCode   = "aws/geolocation-country-code"
+)
+
 // Provider knows how to manage DNS zones only as pertains to routing.
 type Provider interface {
 	// Ensure will create or update record.
@@ -16,6 +34,32 @@ type Provider interface {
 	HealthCheckReconciler() HealthCheckReconciler
 }
 
+// ZoneDiscoverer is implemented by the providers that can look up zones, so
+// that records can be published to the zone(s) matching their DNS names.
+type ZoneDiscoverer interface {
+	// GetZone returns the zone with the given ID, with its DNS name.
+	GetZone(id string) (v1.DNSZone, error)
+
+	// DiscoverZones returns the zones with all the given tags, with their ID
+	// and DNS name.
+	DiscoverZones(tags map[string]string) ([]v1.DNSZone, error)
+}
+
+// RecordReader is implemented by the providers that can read the records
+// currently published in a zone.
+type RecordReader interface {
+	// Records returns the records published in the zone, as endpoints.
+	Records(zone v1.DNSZone) ([]*v1.Endpoint, error)
+}
+
+// PropagationTracker is implemented by the providers whose changes to the
+// records of a zone propagate asynchronously to its name servers.
+type PropagationTracker interface {
+	// Propagated returns whether the changes last applied to the records of the
+	// DNSRecord in the zone have propagated to all the name servers of the zone.
+	Propagated(record *v1.DNSRecord, zone v1.DNSZone) (bool, error)
+}
+
 var _ Provider = &FakeProvider{}
 
 type FakeProvider struct{}
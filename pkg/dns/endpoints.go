@@ -0,0 +1,45 @@
+package dns
+
+import (
+	"sort"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+// EndpointsFromZoneStatus returns the endpoints last published by the record
+// in the zone.
+func EndpointsFromZoneStatus(record *v1.DNSRecord, zoneID string) []*v1.Endpoint {
+	for _, zoneStatus := range record.Status.Zones {
+		if zoneStatus.DNSZone.ID == zoneID {
+			return zoneStatus.Endpoints
+		}
+	}
+	return []*v1.Endpoint{}
+}
+
+// PublishedTargets returns the sorted union of the endpoints targets, for the
+// providers without weighted routing. The targets of the endpoints with a
+// weight of 0 are left out, unless all the endpoints have a weight of 0.
+func PublishedTargets(endpoints []*v1.Endpoint) []string {
+	active := map[string]struct{}{}
+	drained := map[string]struct{}{}
+	for _, endpoint := range endpoints {
+		targets := active
+		if prop, ok := endpoint.GetProviderSpecificProperty(ProviderSpecificWeight); ok && prop.Value == "0" {
+			targets = drained
+		}
+		for _, target := range endpoint.Targets {
+			targets[target] = struct{}{}
+		}
+	}
+	if len(active) == 0 {
+		active = drained
+	}
+
+	targets := make([]string, 0, len(active))
+	for target := range active {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
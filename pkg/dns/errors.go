@@ -0,0 +1,43 @@
+package dns
+
+import (
+	"errors"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// RetryableError is returned by the providers for the errors that are expected
+// to be transient, e.g., when the requests to the provider API are throttled,
+// along with the delay after which the request should be retried.
+type RetryableError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
+// AsRetryableError returns the RetryableError in the chain of the error, if any.
+// The errors of aggregates, which errors.As does not unwrap, are searched as
+// well, and the first RetryableError found is returned.
+func AsRetryableError(err error) (*RetryableError, bool) {
+	var retryable *RetryableError
+	if errors.As(err, &retryable) {
+		return retryable, true
+	}
+	var aggregate utilerrors.Aggregate
+	if errors.As(err, &aggregate) {
+		for _, err := range aggregate.Errors() {
+			if retryable, ok := AsRetryableError(err); ok {
+				return retryable, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,275 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	dnsv1 "google.golang.org/api/dns/v1beta2"
+	"google.golang.org/api/option"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/log"
+)
+
+var _ dns.Provider = &Provider{}
+
+// Provider manages records in Google Cloud DNS managed zones.
+//
+// Cloud DNS groups every record sharing a name and a type into a single
+// resource record set, so endpoints with the same DNS name are published
+// together, either as a plain record set or, when they carry a weight, as a
+// weighted round-robin routing policy.
+type Provider struct {
+	service               *dnsv1.Service
+	healthCheckReconciler *healthCheckReconciler
+	config                Config
+	logger                logr.Logger
+}
+
+// Config is the necessary input to configure the manager.
+type Config struct {
+	// Project is the GCP project the managed zones belong to.
+	Project string
+	// Endpoint overrides the Cloud DNS API endpoint. It is meant to target a
+	// local stand-in of the API, hence requests to it are not authenticated.
+	// +optional
+	Endpoint string
+}
+
+func NewProvider(config Config) (*Provider, error) {
+	if len(config.Project) == 0 {
+		return nil, fmt.Errorf("a GCP project is required")
+	}
+
+	var opts []option.ClientOption
+	if len(config.Endpoint) > 0 {
+		opts = append(opts, option.WithEndpoint(config.Endpoint), option.WithoutAuthentication())
+	}
+
+	service, err := dnsv1.NewService(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create Cloud DNS client: %v", err)
+	}
+
+	p := &Provider{
+		service: service,
+		config:  config,
+		logger:  log.Logger.WithName("google-clouddns").WithValues("project", config.Project),
+	}
+	if err := validateServiceEndpoints(p); err != nil {
+		return nil, fmt.Errorf("failed to validate Google provider service endpoints: %v", err)
+	}
+	return p, nil
+}
+
+// validateServiceEndpoints validates that the provider client can communicate
+// with the Cloud DNS API by making a list call.
+func validateServiceEndpoints(provider *Provider) error {
+	if _, err := provider.service.ManagedZones.List(provider.config.Project).MaxResults(1).Do(); err != nil {
+		return fmt.Errorf("failed to list managed zones: %v", err)
+	}
+	return nil
+}
+
+func (p *Provider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	change := &dnsv1.Change{}
+
+	desired := recordSetsForEndpoints(record.Spec.Endpoints)
+	for key, recordSet := range desired {
+		existing, err := p.getRecordSet(zone.ID, key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			change.Deletions = append(change.Deletions, existing)
+		}
+		change.Additions = append(change.Additions, recordSet)
+	}
+
+	// Delete any previously published record sets that are no longer present in record.Spec.Endpoints
+	for key := range recordSetsForEndpoints(dns.EndpointsFromZoneStatus(record, zone.ID)) {
+		if _, found := desired[key]; found {
+			continue
+		}
+		existing, err := p.getRecordSet(zone.ID, key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			change.Deletions = append(change.Deletions, existing)
+		}
+	}
+
+	if err := p.applyChange(zone.ID, change); err != nil {
+		return fmt.Errorf("failed to update record in zone %s: %v", zone.ID, err)
+	}
+	p.logger.Info("Upserted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	change := &dnsv1.Change{}
+
+	for key := range recordSetsForEndpoints(record.Spec.Endpoints) {
+		existing, err := p.getRecordSet(zone.ID, key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			change.Deletions = append(change.Deletions, existing)
+		}
+	}
+
+	if err := p.applyChange(zone.ID, change); err != nil {
+		return fmt.Errorf("failed to delete record in zone %s: %v", zone.ID, err)
+	}
+	p.logger.Info("Deleted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) HealthCheckReconciler() dns.HealthCheckReconciler {
+	if p.healthCheckReconciler == nil {
+		p.healthCheckReconciler = newHealthCheckReconciler(p.logger)
+	}
+
+	return p.healthCheckReconciler
+}
+
+func (p *Provider) applyChange(managedZone string, change *dnsv1.Change) error {
+	if len(change.Additions) == 0 && len(change.Deletions) == 0 {
+		return nil
+	}
+
+	// Sort the changes so that the requests are deterministic
+	sortRecordSets(change.Additions)
+	sortRecordSets(change.Deletions)
+
+	resp, err := p.service.Changes.Create(p.config.Project, managedZone, change).Do()
+	if err != nil {
+		return err
+	}
+	p.logger.Info("Updated DNS record sets", "zone", managedZone, "change", resp.Id, "status", resp.Status)
+	return nil
+}
+
+// getRecordSet returns the record set currently published in the managed zone
+// for the given key, or nil if there is none.
+func (p *Provider) getRecordSet(managedZone string, key recordSetKey) (*dnsv1.ResourceRecordSet, error) {
+	resp, err := p.service.ResourceRecordSets.List(p.config.Project, managedZone).Name(key.name).Type(key.recordType).Do()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list record sets %s %s in zone %s: %v", key.name, key.recordType, managedZone, err)
+	}
+	for _, recordSet := range resp.Rrsets {
+		if recordSet.Name == key.name && recordSet.Type == key.recordType {
+			return recordSet, nil
+		}
+	}
+	return nil, nil
+}
+
+type recordSetKey struct {
+	name       string
+	recordType string
+}
+
+// recordSetsForEndpoints groups the endpoints sharing the same name and type
+// into Cloud DNS resource record sets.
+func recordSetsForEndpoints(endpoints []*v1.Endpoint) map[recordSetKey]*dnsv1.ResourceRecordSet {
+	grouped := map[recordSetKey][]*v1.Endpoint{}
+	for _, endpoint := range endpoints {
+		key := recordSetKey{
+			name:       ensureTrailingDot(endpoint.DNSName),
+			recordType: endpoint.RecordType,
+		}
+		grouped[key] = append(grouped[key], endpoint)
+	}
+
+	recordSets := make(map[recordSetKey]*dnsv1.ResourceRecordSet, len(grouped))
+	for key, endpoints := range grouped {
+		recordSet := &dnsv1.ResourceRecordSet{
+			Name: key.name,
+			Type: key.recordType,
+			Ttl:  int64(endpoints[0].RecordTTL),
+		}
+
+		if isWeighted(endpoints) {
+			policy := &dnsv1.RRSetRoutingPolicyWrrPolicy{}
+			for _, endpoint := range endpoints {
+				policy.Items = append(policy.Items, &dnsv1.RRSetRoutingPolicyWrrPolicyWrrPolicyItem{
+					Weight:          weight(endpoint),
+					Rrdatas:         rrdatas(endpoint),
+					ForceSendFields: []string{"Weight"},
+				})
+			}
+			sort.Slice(policy.Items, func(i, j int) bool {
+				return strings.Join(policy.Items[i].Rrdatas, ",") < strings.Join(policy.Items[j].Rrdatas, ",")
+			})
+			recordSet.RoutingPolicy = &dnsv1.RRSetRoutingPolicy{Wrr: policy}
+		} else {
+			for _, endpoint := range endpoints {
+				recordSet.Rrdatas = append(recordSet.Rrdatas, rrdatas(endpoint)...)
+			}
+		}
+
+		recordSets[key] = recordSet
+	}
+	return recordSets
+}
+
+// isWeighted returns whether the endpoints must be published using a weighted
+// round-robin routing policy, i.e., if any of them has a weight.
+func isWeighted(endpoints []*v1.Endpoint) bool {
+	for _, endpoint := range endpoints {
+		if _, ok := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificWeight); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// weight returns the weight of the endpoint. The weight is read from the
+// `aws/weight` provider specific property, as set by the ingress controller.
+func weight(endpoint *v1.Endpoint) float64 {
+	prop, ok := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificWeight)
+	if !ok {
+		return 1
+	}
+	value, err := strconv.ParseFloat(prop.Value, 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+	return value
+}
+
+func rrdatas(endpoint *v1.Endpoint) []string {
+	rrdatas := make([]string, 0, len(endpoint.Targets))
+	for _, target := range endpoint.Targets {
+		switch endpoint.RecordType {
+		case string(v1.CNAMERecordType):
+			target = ensureTrailingDot(target)
+		case string(v1.TXTRecordType):
+			target = strconv.Quote(target)
+		}
+		rrdatas = append(rrdatas, target)
+	}
+	return rrdatas
+}
+
+func sortRecordSets(recordSets []*dnsv1.ResourceRecordSet) {
+	sort.Slice(recordSets, func(i, j int) bool {
+		if recordSets[i].Name != recordSets[j].Name {
+			return recordSets[i].Name < recordSets[j].Name
+		}
+		return recordSets[i].Type < recordSets[j].Type
+	})
+}
+
+func ensureTrailingDot(hostname string) string {
+	return strings.TrimSuffix(hostname, ".") + "."
+}
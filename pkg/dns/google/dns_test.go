@@ -0,0 +1,233 @@
+package google
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	dnsv1 "google.golang.org/api/dns/v1beta2"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const (
+	testProject = "test-project"
+	testZone    = "test-zone"
+)
+
+// cloudDNS is a minimal in-memory stand-in for the Cloud DNS REST API.
+type cloudDNS struct {
+	mu         sync.Mutex
+	zones      []*dnsv1.ManagedZone
+	recordSets map[string]*dnsv1.ResourceRecordSet
+	changes    []*dnsv1.Change
+}
+
+func newCloudDNS() *cloudDNS {
+	return &cloudDNS{
+		zones: []*dnsv1.ManagedZone{
+			{Name: testZone, DnsName: "example.com.", Labels: map[string]string{"env": "test"}},
+			{Name: "other-zone", DnsName: "example.org.", Labels: map[string]string{"env": "prod"}},
+		},
+		recordSets: map[string]*dnsv1.ResourceRecordSet{},
+	}
+}
+
+func (s *cloudDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zonePath := "/dns/v1beta2/projects/" + testProject + "/managedZones"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == zonePath:
+		writeJSON(w, &dnsv1.ManagedZonesListResponse{ManagedZones: s.zones})
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, zonePath+"/") && !strings.Contains(strings.TrimPrefix(r.URL.Path, zonePath+"/"), "/"):
+		for _, zone := range s.zones {
+			if zone.Name == strings.TrimPrefix(r.URL.Path, zonePath+"/") {
+				writeJSON(w, zone)
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	case r.Method == http.MethodGet && r.URL.Path == zonePath+"/"+testZone+"/rrsets":
+		response := &dnsv1.ResourceRecordSetsListResponse{}
+		if r.URL.Query().Get("name") == "" {
+			for _, recordSet := range s.recordSets {
+				response.Rrsets = append(response.Rrsets, recordSet)
+			}
+		} else if recordSet, ok := s.recordSets[r.URL.Query().Get("name")+"/"+r.URL.Query().Get("type")]; ok {
+			response.Rrsets = append(response.Rrsets, recordSet)
+		}
+		writeJSON(w, response)
+
+	case r.Method == http.MethodPost && r.URL.Path == zonePath+"/"+testZone+"/changes":
+		change := &dnsv1.Change{}
+		if err := json.NewDecoder(r.Body).Decode(change); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, deletion := range change.Deletions {
+			if _, ok := s.recordSets[deletion.Name+"/"+deletion.Type]; !ok {
+				http.Error(w, "record set not found", http.StatusNotFound)
+				return
+			}
+			delete(s.recordSets, deletion.Name+"/"+deletion.Type)
+		}
+		for _, addition := range change.Additions {
+			if _, ok := s.recordSets[addition.Name+"/"+addition.Type]; ok {
+				http.Error(w, "record set already exists", http.StatusConflict)
+				return
+			}
+			s.recordSets[addition.Name+"/"+addition.Type] = addition
+		}
+		s.changes = append(s.changes, change)
+		change.Id = "1"
+		change.Status = "done"
+		writeJSON(w, change)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestProvider(t *testing.T) (*Provider, *cloudDNS) {
+	api := newCloudDNS()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	provider, err := NewProvider(Config{
+		Project:  testProject,
+		Endpoint: server.URL + "/",
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	return provider, api
+}
+
+func weightedEndpoint(dnsName, target, weight string) *v1.Endpoint {
+	endpoint := &v1.Endpoint{
+		DNSName:       dnsName,
+		RecordType:    string(v1.ARecordType),
+		SetIdentifier: target,
+		Targets:       v1.Targets{target},
+		RecordTTL:     60,
+	}
+	endpoint.SetProviderSpecific(dns.ProviderSpecificWeight, weight)
+	return endpoint
+}
+
+func TestEnsureWeightedRecord(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				weightedEndpoint("abc.example.com", "1.1.1.1", "120"),
+				weightedEndpoint("abc.example.com", "2.2.2.2", "60"),
+			},
+		},
+	}
+
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	g.Expect(api.recordSets).To(gomega.HaveKey("abc.example.com./A"))
+	recordSet := api.recordSets["abc.example.com./A"]
+	g.Expect(recordSet.Ttl).To(gomega.Equal(int64(60)))
+	g.Expect(recordSet.Rrdatas).To(gomega.BeEmpty())
+	g.Expect(recordSet.RoutingPolicy.Wrr.Items).To(gomega.HaveLen(2))
+	g.Expect(recordSet.RoutingPolicy.Wrr.Items[0].Rrdatas).To(gomega.ConsistOf("1.1.1.1"))
+	g.Expect(recordSet.RoutingPolicy.Wrr.Items[0].Weight).To(gomega.Equal(float64(120)))
+	g.Expect(recordSet.RoutingPolicy.Wrr.Items[1].Rrdatas).To(gomega.ConsistOf("2.2.2.2"))
+	g.Expect(recordSet.RoutingPolicy.Wrr.Items[1].Weight).To(gomega.Equal(float64(60)))
+
+	// Ensuring the record again replaces the existing record set
+	record.Spec.Endpoints = record.Spec.Endpoints[:1]
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.changes).To(gomega.HaveLen(2))
+	g.Expect(api.changes[1].Deletions).To(gomega.HaveLen(1))
+	g.Expect(api.recordSets["abc.example.com./A"].RoutingPolicy.Wrr.Items).To(gomega.HaveLen(1))
+}
+
+func TestEnsureDeletesStaleRecords(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	stale := weightedEndpoint("old.example.com", "1.1.1.1", "120")
+	g.Expect(provider.Ensure(&v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{stale}},
+	}, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.recordSets).To(gomega.HaveKey("old.example.com./A"))
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{weightedEndpoint("new.example.com", "1.1.1.1", "120")},
+		},
+		Status: v1.DNSRecordStatus{
+			Zones: []v1.DNSZoneStatus{{
+				DNSZone:   v1.DNSZone{ID: testZone},
+				Endpoints: []*v1.Endpoint{stale},
+			}},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	g.Expect(api.recordSets).NotTo(gomega.HaveKey("old.example.com./A"))
+	g.Expect(api.recordSets).To(gomega.HaveKey("new.example.com./A"))
+}
+
+func TestDelete(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, api := newTestProvider(t)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				{
+					DNSName:    "simple.example.com",
+					RecordType: string(v1.ARecordType),
+					Targets:    v1.Targets{"1.1.1.1", "2.2.2.2"},
+					RecordTTL:  60,
+				},
+			},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.recordSets).To(gomega.HaveKey("simple.example.com./A"))
+	g.Expect(api.recordSets["simple.example.com./A"].RoutingPolicy).To(gomega.BeNil())
+	g.Expect(strings.Join(api.recordSets["simple.example.com./A"].Rrdatas, ",")).To(gomega.Equal("1.1.1.1,2.2.2.2"))
+
+	g.Expect(provider.Delete(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.recordSets).To(gomega.BeEmpty())
+
+	// Deleting a record that does not exist is a no-op
+	g.Expect(provider.Delete(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(api.changes).To(gomega.HaveLen(2))
+}
+
+func TestDiscoverZones(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, _ := newTestProvider(t)
+
+	zone, err := provider.GetZone(testZone)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(zone).To(gomega.Equal(v1.DNSZone{ID: testZone, DNSName: "example.com"}))
+
+	zones, err := provider.DiscoverZones(map[string]string{"env": "prod"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(zones).To(gomega.Equal([]v1.DNSZone{{ID: "other-zone", DNSName: "example.org", Tags: map[string]string{"env": "prod"}}}))
+}
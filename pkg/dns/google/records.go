@@ -0,0 +1,68 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dnsv1 "google.golang.org/api/dns/v1beta2"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ dns.RecordReader = &Provider{}
+
+// Records returns the records of the managed zone. The items of a weighted
+// round-robin record set are returned as distinct endpoints, with their weight.
+func (p *Provider) Records(zone v1.DNSZone) ([]*v1.Endpoint, error) {
+	var endpoints []*v1.Endpoint
+	err := p.service.ResourceRecordSets.List(p.config.Project, zone.ID).Pages(context.Background(), func(response *dnsv1.ResourceRecordSetsListResponse) error {
+		for _, recordSet := range response.Rrsets {
+			endpoints = append(endpoints, endpointsForRecordSet(recordSet)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records of managed zone %s: %v", zone.ID, err)
+	}
+	return endpoints, nil
+}
+
+func endpointsForRecordSet(recordSet *dnsv1.ResourceRecordSet) []*v1.Endpoint {
+	newEndpoint := func(rrdatas []string) *v1.Endpoint {
+		endpoint := &v1.Endpoint{
+			DNSName:    strings.TrimSuffix(recordSet.Name, "."),
+			RecordType: recordSet.Type,
+			RecordTTL:  v1.TTL(recordSet.Ttl),
+		}
+		for _, rrdata := range rrdatas {
+			switch recordSet.Type {
+			case string(v1.CNAMERecordType):
+				rrdata = strings.TrimSuffix(rrdata, ".")
+			case string(v1.TXTRecordType):
+				if unquoted, err := strconv.Unquote(rrdata); err == nil {
+					rrdata = unquoted
+				}
+			}
+			endpoint.Targets = append(endpoint.Targets, rrdata)
+		}
+		return endpoint
+	}
+
+	if recordSet.RoutingPolicy == nil || recordSet.RoutingPolicy.Wrr == nil {
+		return []*v1.Endpoint{newEndpoint(recordSet.Rrdatas)}
+	}
+
+	endpoints := make([]*v1.Endpoint, 0, len(recordSet.RoutingPolicy.Wrr.Items))
+	for _, item := range recordSet.RoutingPolicy.Wrr.Items {
+		endpoint := newEndpoint(item.Rrdatas)
+		if len(endpoint.Targets) == 1 {
+			endpoint.SetIdentifier = endpoint.Targets[0]
+		}
+		endpoint.SetProviderSpecific(dns.ProviderSpecificWeight, strconv.FormatFloat(item.Weight, 'f', -1, 64))
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
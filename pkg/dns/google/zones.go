@@ -0,0 +1,57 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dnsv1 "google.golang.org/api/dns/v1beta2"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ dns.ZoneDiscoverer = &Provider{}
+
+func (p *Provider) GetZone(id string) (v1.DNSZone, error) {
+	managedZone, err := p.service.ManagedZones.Get(p.config.Project, id).Do()
+	if err != nil {
+		return v1.DNSZone{}, fmt.Errorf("failed to get managed zone %s: %v", id, err)
+	}
+	return zoneFromManagedZone(managedZone), nil
+}
+
+// DiscoverZones returns the managed zones with all the given tags as labels.
+func (p *Provider) DiscoverZones(tags map[string]string) ([]v1.DNSZone, error) {
+	var zones []v1.DNSZone
+	err := p.service.ManagedZones.List(p.config.Project).Pages(context.Background(), func(response *dnsv1.ManagedZonesListResponse) error {
+		for _, managedZone := range response.ManagedZones {
+			if hasTags(managedZone.Labels, tags) {
+				zone := zoneFromManagedZone(managedZone)
+				zone.Tags = tags
+				zones = append(zones, zone)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed zones: %v", err)
+	}
+	return zones, nil
+}
+
+func zoneFromManagedZone(managedZone *dnsv1.ManagedZone) v1.DNSZone {
+	return v1.DNSZone{
+		ID:      managedZone.Name,
+		DNSName: strings.TrimSuffix(managedZone.DnsName, "."),
+	}
+}
+
+func hasTags(labels, tags map[string]string) bool {
+	for key, value := range tags {
+		if label, ok := labels[key]; !ok || label != value {
+			return false
+		}
+	}
+	return true
+}
@@ -12,20 +12,87 @@ type HealthCheckReconciler interface {
 	Delete(ctx context.Context, endpoint *v1.Endpoint) error
 }
 
+// HealthCheckStatusReader is implemented by the health check reconcilers that
+// can read the status of the health checks of the endpoints.
+type HealthCheckStatusReader interface {
+	// Healthy returns whether the endpoint is healthy, as per its health check,
+	// and false for checked if the endpoint has no health check.
+	Healthy(ctx context.Context, endpoint *v1.Endpoint) (healthy bool, checked bool, err error)
+}
+
+// HealthCheckStatusReporter is implemented by the health check reconcilers that
+// can report the detailed status of the health checks of the endpoints, e.g.
+// the observations of their checkers.
+type HealthCheckStatusReporter interface {
+	// Status returns the status of the health check of the endpoint, or nil if
+	// the endpoint has no health check.
+	Status(ctx context.Context, endpoint *v1.Endpoint) (*v1.EndpointHealthStatus, error)
+}
+
+// HealthCheckLister is implemented by the health check reconcilers that can
+// list the health checks they created, so that the orphaned ones, i.e., no
+// longer set on any endpoint, can be deleted.
+type HealthCheckLister interface {
+	// ListHealthChecks returns the health checks created by GLBC.
+	ListHealthChecks(ctx context.Context) ([]HealthCheck, error)
+	// DeleteHealthCheck deletes the health check by ID.
+	DeleteHealthCheck(ctx context.Context, id string) error
+}
+
+// HealthCheck is a health check created by GLBC.
+type HealthCheck struct {
+	// ID identifies the health check, as set on the endpoints it checks.
+	ID string
+	// DNSName is the hostname of the endpoint it checks.
+	DNSName string
+	// OwnerID is the owner the health check was created by, if any.
+	OwnerID string
+}
+
+// HealthCheckValidator is implemented by the health check reconcilers with
+// constraints of their own on the health checks, e.g. the regions they can be
+// checked from.
+type HealthCheckValidator interface {
+	// Validate returns why the health checks of the spec cannot be created, if
+	// so.
+	Validate(spec HealthCheckSpec) error
+}
+
 type HealthCheckSpec struct {
-	Id               string
-	Name             string
+	Id   string
+	Name string
+	// OwnerID is the owner of the health check, i.e., the GLBC instance, if
+	// set, so that each instance only collects its own orphaned health checks.
+	OwnerID string
+
 	Port             *int64
 	FailureThreshold *int64
 	Protocol         *HealthCheckProtocol
 
 	Path string
+
+	// SearchString is the string the response body of an HTTP(S) endpoint must
+	// contain for the endpoint to be healthy, if not empty.
+	SearchString string
+	// RequestInterval is the number of seconds between the checks of the
+	// endpoint, 10 or 30.
+	RequestInterval *int64
+	// Regions are the regions the endpoint is checked from, or the default
+	// regions of the provider if empty.
+	Regions []string
+	// EnableSNI sends the host name of the endpoint in the TLS handshake of
+	// HTTPS checks.
+	EnableSNI *bool
+	// Inverted inverts the result of the checks, so that the endpoint is
+	// healthy while the checks fail.
+	Inverted bool
 }
 
 type HealthCheckProtocol string
 
 const HealthCheckProtocolHTTP HealthCheckProtocol = "HTTP"
 const HealthCheckProtocolHTTPS HealthCheckProtocol = "HTTPS"
+const HealthCheckProtocolTCP HealthCheckProtocol = "TCP"
 
 type fakeHealthCheckReconciler struct{}
 
@@ -0,0 +1,181 @@
+package inmemory
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/log"
+)
+
+var (
+	_ dns.Provider     = &Provider{}
+	_ dns.RecordReader = &Provider{}
+)
+
+// Provider stores the published records in memory, so that they can be
+// observed by tests, or served over DNS for local development, see Server.
+//
+// The records are stored per zone, and keyed by name, type and set identifier,
+// the same way Route53 identifies the records of a hosted zone.
+type Provider struct {
+	mu                    sync.RWMutex
+	zones                 map[string]map[recordKey]*v1.Endpoint
+	healthCheckReconciler *healthCheckReconciler
+	logger                logr.Logger
+}
+
+type recordKey struct {
+	name          string
+	recordType    string
+	setIdentifier string
+}
+
+func keyForEndpoint(endpoint *v1.Endpoint) recordKey {
+	return recordKey{
+		name:          normalizeName(endpoint.DNSName),
+		recordType:    endpoint.RecordType,
+		setIdentifier: endpoint.SetIdentifier,
+	}
+}
+
+func NewProvider() *Provider {
+	return &Provider{
+		zones:  map[string]map[recordKey]*v1.Endpoint{},
+		logger: log.Logger.WithName("inmemory-dns"),
+	}
+}
+
+func (p *Provider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	records, ok := p.zones[zone.ID]
+	if !ok {
+		records = map[recordKey]*v1.Endpoint{}
+		p.zones[zone.ID] = records
+	}
+
+	desired := map[recordKey]struct{}{}
+	for _, endpoint := range record.Spec.Endpoints {
+		key := keyForEndpoint(endpoint)
+		desired[key] = struct{}{}
+		records[key] = endpoint.DeepCopy()
+	}
+
+	// Delete any previously published records that are no longer present in record.Spec.Endpoints
+	for _, endpoint := range dns.EndpointsFromZoneStatus(record, zone.ID) {
+		key := keyForEndpoint(endpoint)
+		if _, found := desired[key]; !found {
+			delete(records, key)
+		}
+	}
+
+	p.logger.Info("Upserted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if records, ok := p.zones[zone.ID]; ok {
+		for _, endpoint := range record.Spec.Endpoints {
+			delete(records, keyForEndpoint(endpoint))
+		}
+	}
+
+	p.logger.Info("Deleted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) HealthCheckReconciler() dns.HealthCheckReconciler {
+	if p.healthCheckReconciler == nil {
+		p.healthCheckReconciler = newHealthCheckReconciler(p.logger)
+	}
+
+	return p.healthCheckReconciler
+}
+
+// Zones returns the IDs of the zones records have been published to.
+func (p *Provider) Zones() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	zones := make([]string, 0, len(p.zones))
+	for zone := range p.zones {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// Endpoints returns a copy of the records published to the zone, sorted by
+// name, type and set identifier.
+func (p *Provider) Endpoints(zoneID string) []*v1.Endpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var endpoints []*v1.Endpoint
+	for _, endpoint := range p.zones[zoneID] {
+		endpoints = append(endpoints, endpoint.DeepCopy())
+	}
+	sortEndpoints(endpoints)
+	return endpoints
+}
+
+// Records returns a copy of the records published to the zone.
+func (p *Provider) Records(zone v1.DNSZone) ([]*v1.Endpoint, error) {
+	return p.Endpoints(zone.ID), nil
+}
+
+// Lookup returns a copy of the records with the given name and type, across
+// all the zones.
+func (p *Provider) Lookup(name, recordType string) []*v1.Endpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	endpoints := p.lookup(name, recordType)
+	for i, endpoint := range endpoints {
+		endpoints[i] = endpoint.DeepCopy()
+	}
+	return endpoints
+}
+
+// lookup returns the records with the given name and type, across all the
+// zones. An empty type matches any type. It must be called with the lock held.
+func (p *Provider) lookup(name, recordType string) []*v1.Endpoint {
+	name = normalizeName(name)
+
+	var endpoints []*v1.Endpoint
+	for _, records := range p.zones {
+		for key, endpoint := range records {
+			if key.name == name && (recordType == "" || key.recordType == recordType) {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	sortEndpoints(endpoints)
+	return endpoints
+}
+
+func sortEndpoints(endpoints []*v1.Endpoint) {
+	sort.Slice(endpoints, func(i, j int) bool {
+		ki, kj := keyForEndpoint(endpoints[i]), keyForEndpoint(endpoints[j])
+		if ki.name != kj.name {
+			return ki.name < kj.name
+		}
+		if ki.recordType != kj.recordType {
+			return ki.recordType < kj.recordType
+		}
+		return ki.setIdentifier < kj.setIdentifier
+	})
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,126 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/net"
+)
+
+const testZone = "test-zone"
+
+func weightedEndpoint(dnsName, target, weight string) *v1.Endpoint {
+	endpoint := &v1.Endpoint{
+		DNSName:       dnsName,
+		RecordType:    string(v1.ARecordType),
+		SetIdentifier: target,
+		Targets:       v1.Targets{target},
+		RecordTTL:     60,
+	}
+	endpoint.SetProviderSpecific(glbcdns.ProviderSpecificWeight, weight)
+	return endpoint
+}
+
+func TestEnsureAndDelete(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider := NewProvider()
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				weightedEndpoint("abc.example.com", "2.2.2.2", "60"),
+				weightedEndpoint("abc.example.com", "1.1.1.1", "120"),
+			},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	g.Expect(provider.Zones()).To(gomega.Equal([]string{testZone}))
+	endpoints := provider.Endpoints(testZone)
+	g.Expect(endpoints).To(gomega.HaveLen(2))
+	g.Expect(endpoints[0].SetIdentifier).To(gomega.Equal("1.1.1.1"))
+	g.Expect(endpoints[1].SetIdentifier).To(gomega.Equal("2.2.2.2"))
+	g.Expect(provider.Lookup("abc.example.com.", "A")).To(gomega.HaveLen(2))
+	g.Expect(provider.Lookup("abc.example.com", "CNAME")).To(gomega.BeEmpty())
+
+	// Returned records are copies
+	endpoints[0].Targets[0] = "3.3.3.3"
+	g.Expect(provider.Endpoints(testZone)[0].Targets).To(gomega.ConsistOf("1.1.1.1"))
+
+	g.Expect(provider.Delete(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(provider.Endpoints(testZone)).To(gomega.BeEmpty())
+}
+
+func TestEnsureDeletesStaleRecords(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider := NewProvider()
+
+	stale := weightedEndpoint("abc.example.com", "1.1.1.1", "120")
+	kept := weightedEndpoint("abc.example.com", "2.2.2.2", "120")
+	g.Expect(provider.Ensure(&v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{stale, kept}},
+	}, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{kept}},
+		Status: v1.DNSRecordStatus{
+			Zones: []v1.DNSZoneStatus{{
+				DNSZone:   v1.DNSZone{ID: testZone},
+				Endpoints: []*v1.Endpoint{stale, kept},
+			}},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	endpoints := provider.Endpoints(testZone)
+	g.Expect(endpoints).To(gomega.HaveLen(1))
+	g.Expect(endpoints[0].SetIdentifier).To(gomega.Equal("2.2.2.2"))
+}
+
+func TestServer(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider := NewProvider()
+
+	server, err := NewServer(provider, "127.0.0.1:0")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	go func() { _ = server.Start() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	g.Expect(provider.Ensure(&v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				weightedEndpoint("abc.example.com", "1.1.1.1", "120"),
+				weightedEndpoint("abc.example.com", "2.2.2.2", "0"),
+				{
+					DNSName:    "www.example.com",
+					RecordType: string(v1.CNAMERecordType),
+					Targets:    v1.Targets{"abc.example.com"},
+					RecordTTL:  60,
+				},
+			},
+		},
+	}, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+
+	// Hosts resolve through CNAME records, leaving drained endpoints out
+	resolver := net.NewDefaultHostResolver()
+	resolver.Nameservers = []string{server.Addr()}
+	g.Eventually(func() ([]net.HostAddress, error) {
+		return resolver.LookupIPAddr(context.Background(), "www.example.com")
+	}).Should(gomega.HaveLen(1))
+	addresses, err := resolver.LookupIPAddr(context.Background(), "www.example.com")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(addresses[0].IP.String()).To(gomega.Equal("1.1.1.1"))
+
+	// Unknown hosts do not exist, over TCP as well
+	client := &dns.Client{Net: "tcp"}
+	m := new(dns.Msg)
+	m.SetQuestion("unknown.example.com.", dns.TypeA)
+	r, _, err := client.Exchange(m, server.Addr())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(r.Rcode).To(gomega.Equal(dns.RcodeNameError))
+}
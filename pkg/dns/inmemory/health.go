@@ -0,0 +1,34 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// healthCheckReconciler is the health check reconciler for the in-memory
+// provider, which does not probe the endpoints, so the health check
+// configuration is only logged.
+type healthCheckReconciler struct {
+	logger logr.Logger
+}
+
+var _ dns.HealthCheckReconciler = &healthCheckReconciler{}
+
+func newHealthCheckReconciler(l logr.Logger) *healthCheckReconciler {
+	return &healthCheckReconciler{
+		logger: l.WithName("health"),
+	}
+}
+
+func (r *healthCheckReconciler) Reconcile(_ context.Context, spec dns.HealthCheckSpec, endpoint *v1.Endpoint) error {
+	r.logger.V(3).Info("Health checks are not supported by the in-memory provider, skipping", "name", spec.Name, "endpoint", endpoint.SetID())
+	return nil
+}
+
+func (r *healthCheckReconciler) Delete(_ context.Context, _ *v1.Endpoint) error {
+	return nil
+}
@@ -0,0 +1,135 @@
+package inmemory
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/log"
+)
+
+// maxCNAMEChain is the maximum number of CNAME records followed when answering
+// a query.
+const maxCNAMEChain = 8
+
+// Server serves the records of an in-memory provider over UDP and TCP DNS,
+// e.g., so that the generated hosts can be resolved on a laptop with:
+//
+//	dig @127.0.0.1 -p 1053 <host>
+type Server struct {
+	udp *dns.Server
+	tcp *dns.Server
+}
+
+// NewServer creates a server for the provider, listening on the given address
+// for both UDP and TCP.
+func NewServer(provider *Provider, address string) (*Server, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	// Bind UDP to the same port, as it may have been chosen by the system
+	packetConn, err := net.ListenPacket("udp", listener.Addr().String())
+	if err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return &Server{
+		udp: &dns.Server{PacketConn: packetConn, Handler: provider},
+		tcp: &dns.Server{Listener: listener, Handler: provider},
+	}, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() string {
+	return s.tcp.Listener.Addr().String()
+}
+
+// Start serves DNS queries until the server is shut down.
+func (s *Server) Start() error {
+	log.Logger.Info("Started serving in-memory DNS records", "address", s.Addr())
+	errs := make(chan error, 2)
+	go func() { errs <- s.udp.ActivateAndServe() }()
+	go func() { errs <- s.tcp.ActivateAndServe() }()
+	return errors.NewAggregate([]error{<-errs, <-errs})
+}
+
+func (s *Server) Shutdown() error {
+	log.Logger.Info("Stopping in-memory DNS server")
+	return errors.NewAggregate([]error{s.udp.Shutdown(), s.tcp.Shutdown()})
+}
+
+// ServeDNS answers the queries for the records stored by the provider. CNAME
+// records are followed, and as with the other providers that do not support
+// weights, the targets of the endpoints with a weight of 0 are left out, unless
+// all of them have a weight of 0.
+func (p *Provider) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.Authoritative = true
+
+	if len(r.Question) == 1 {
+		p.mu.RLock()
+		answer, found := p.answer(r.Question[0].Name, r.Question[0].Qtype, 0)
+		p.mu.RUnlock()
+
+		m.Answer = answer
+		if !found {
+			m.Rcode = dns.RcodeNameError
+		}
+	} else {
+		m.Rcode = dns.RcodeFormatError
+	}
+
+	if err := w.WriteMsg(m); err != nil {
+		p.logger.Error(err, "Failed to write DNS response")
+	}
+}
+
+// answer returns the records answering the query, and whether the name exists.
+// It must be called with the lock held.
+func (p *Provider) answer(name string, qtype uint16, depth int) ([]dns.RR, bool) {
+	if len(p.lookup(name, "")) == 0 {
+		return nil, false
+	}
+
+	var answer []dns.RR
+	if endpoints := p.lookup(name, dns.TypeToString[qtype]); len(endpoints) > 0 {
+		for _, target := range glbcdns.PublishedTargets(endpoints) {
+			if rr := newRR(name, endpoints[0].RecordTTL, qtype, target); rr != nil {
+				answer = append(answer, rr)
+			}
+		}
+		return answer, true
+	}
+
+	if endpoints := p.lookup(name, string(v1.CNAMERecordType)); len(endpoints) > 0 && depth < maxCNAMEChain {
+		target := endpoints[0].Targets[0]
+		if rr := newRR(name, endpoints[0].RecordTTL, dns.TypeCNAME, target); rr != nil {
+			answer = append(answer, rr)
+		}
+		chained, _ := p.answer(target, qtype, depth+1)
+		answer = append(answer, chained...)
+	}
+	return answer, true
+}
+
+func newRR(name string, ttl v1.TTL, rrtype uint16, target string) dns.RR {
+	switch rrtype {
+	case dns.TypeCNAME:
+		target = dns.Fqdn(target)
+	case dns.TypeTXT:
+		target = strconv.Quote(target)
+	}
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(name), ttl, dns.TypeToString[rrtype], target))
+	if err != nil {
+		return nil
+	}
+	return rr
+}
@@ -0,0 +1,60 @@
+package dns
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kuadrant/kcp-glbc/pkg/metrics"
+)
+
+const (
+	protocolLabel = "protocol"
+	resultLabel   = "result"
+	statusLabel   = "status"
+
+	probeSuccess   = "success"
+	probeFailure   = "failure"
+	probeHealthy   = "healthy"
+	probeUnhealthy = "unhealthy"
+)
+
+var (
+	// healthProbeTotal is a prometheus counter metrics which holds the total
+	// number of probes of the endpoints, by result.
+	healthProbeTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "glbc_dns_health_probe_total",
+			Help: "GLBC total number of health probes of the DNS record endpoints",
+		},
+		[]string{resultLabel},
+	)
+
+	// healthProbeDuration is a prometheus histogram metrics which holds the
+	// duration of the probes of the endpoints, by protocol.
+	healthProbeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "glbc_dns_health_probe_duration_seconds",
+			Help:    "GLBC duration of the health probes of the DNS record endpoints",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 4},
+		},
+		[]string{protocolLabel},
+	)
+
+	// healthProbeEndpoints is a prometheus gauge metrics which holds the number
+	// of probed endpoints, by health status.
+	healthProbeEndpoints = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "glbc_dns_health_probe_endpoints",
+			Help: "GLBC number of DNS record endpoints probed, by health status",
+		},
+		[]string{statusLabel},
+	)
+)
+
+func init() {
+	// Register metrics with the global prometheus registry
+	metrics.Registry.MustRegister(
+		healthProbeTotal,
+		healthProbeDuration,
+		healthProbeEndpoints,
+	)
+}
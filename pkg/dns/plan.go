@@ -0,0 +1,235 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+const (
+	UpsertAction = "UPSERT"
+	DeleteAction = "DELETE"
+)
+
+// Change is a change to the records of a zone.
+type Change struct {
+	// Action is either UPSERT or DELETE.
+	Action   string       `json:"action"`
+	Endpoint *v1.Endpoint `json:"endpoint"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %s %v", c.Action, c.Endpoint.RecordType, c.Endpoint.DNSName, []string(c.Endpoint.Targets))
+}
+
+// PlanChanges returns the changes applying the action to the endpoints of the
+// record. When upserting, the endpoints last published to the zone, as per the
+// record status, that are no longer in the record spec are deleted first, so
+// that they do not conflict with the upserted ones, e.g., when the A records
+// of a name are replaced with a CNAME record.
+func PlanChanges(record *v1.DNSRecord, zoneID, action string) []Change {
+	var changes []Change
+	if action == UpsertAction {
+		expected := map[string]struct{}{}
+		for _, endpoint := range record.Spec.Endpoints {
+			expected[recordSetID(endpoint)] = struct{}{}
+		}
+		for _, endpoint := range EndpointsFromZoneStatus(record, zoneID) {
+			if _, found := expected[recordSetID(endpoint)]; !found {
+				changes = append(changes, Change{Action: DeleteAction, Endpoint: endpoint})
+			}
+		}
+	}
+	for _, endpoint := range record.Spec.Endpoints {
+		changes = append(changes, Change{Action: action, Endpoint: endpoint})
+	}
+	return changes
+}
+
+// recordSetID identifies the record set of the endpoint, as the same set
+// identifier can be used across names, e.g., for the IP address of a cluster
+// published under the names of several locations.
+func recordSetID(endpoint *v1.Endpoint) string {
+	return endpoint.DNSName + "/" + endpoint.RecordType + "/" + endpoint.SetID()
+}
+
+// Plan is the set of changes that would be applied to the records of a zone on
+// behalf of a DNSRecord.
+type Plan struct {
+	// Record is the key of the DNSRecord.
+	Record  string   `json:"record"`
+	Zone    string   `json:"zone"`
+	Changes []Change `json:"changes"`
+}
+
+var (
+	_ Provider     = &DryRunProvider{}
+	_ http.Handler = &DryRunProvider{}
+)
+
+// DryRunProvider is a Provider that plans the changes to the records, without
+// applying them. The plans are kept until they are forgotten, and are served
+// as JSON over HTTP.
+type DryRunProvider struct {
+	Provider
+	logger logr.Logger
+	plans  map[string]map[string][]Change
+	lock   sync.RWMutex
+}
+
+// NewDryRunProvider returns a DryRunProvider planning the changes the given
+// provider would apply. The provider is only used to read the records of the
+// zones, if it supports it.
+func NewDryRunProvider(provider Provider, logger logr.Logger) Provider {
+	p := &DryRunProvider{
+		Provider: provider,
+		logger:   logger,
+		plans:    map[string]map[string][]Change{},
+	}
+	if reader, ok := provider.(RecordReader); ok {
+		return &dryRunRecordReader{DryRunProvider: p, RecordReader: reader}
+	}
+	return p
+}
+
+// dryRunRecordReader is a DryRunProvider for the providers that can read
+// records.
+type dryRunRecordReader struct {
+	*DryRunProvider
+	RecordReader
+}
+
+// AsDryRunProvider returns the DryRunProvider of a provider returned by
+// NewDryRunProvider, if it is one.
+func AsDryRunProvider(provider Provider) (*DryRunProvider, bool) {
+	switch p := provider.(type) {
+	case *DryRunProvider:
+		return p, true
+	case *dryRunRecordReader:
+		return p.DryRunProvider, true
+	}
+	return nil, false
+}
+
+func (p *DryRunProvider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	return p.plan(record, zone, UpsertAction)
+}
+
+func (p *DryRunProvider) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	return p.plan(record, zone, DeleteAction)
+}
+
+// HealthCheckReconciler returns a reconciler that does not create, nor delete,
+// any health check.
+func (p *DryRunProvider) HealthCheckReconciler() HealthCheckReconciler {
+	return &dryRunHealthCheckReconciler{logger: p.logger}
+}
+
+func (p *DryRunProvider) plan(record *v1.DNSRecord, zone v1.DNSZone, action string) error {
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		return err
+	}
+	changes := PlanChanges(record, zone.ID, action)
+	p.logger.Info("Planned DNS record changes", "record", key, "zone", zone, "changes", changes)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.plans[key] == nil {
+		p.plans[key] = map[string][]Change{}
+	}
+	p.plans[key][zone.ID] = changes
+	return nil
+}
+
+// Plan returns the changes last planned for the record in the zone.
+func (p *DryRunProvider) Plan(record *v1.DNSRecord, zoneID string) ([]Change, bool) {
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		return nil, false
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	changes, ok := p.plans[key][zoneID]
+	return changes, ok
+}
+
+// Forget forgets the changes planned for the record with the given key.
+func (p *DryRunProvider) Forget(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.plans, key)
+}
+
+// Plans returns all the plans, sorted by record and zone.
+func (p *DryRunProvider) Plans() []Plan {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	plans := []Plan{}
+	for key, zones := range p.plans {
+		for zoneID, changes := range zones {
+			plans = append(plans, Plan{Record: key, Zone: zoneID, Changes: changes})
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		if plans[i].Record != plans[j].Record {
+			return plans[i].Record < plans[j].Record
+		}
+		return plans[i].Zone < plans[j].Zone
+	})
+	return plans
+}
+
+// ServeHTTP writes the plans as JSON, optionally filtered by the `record` and
+// `zone` query parameters.
+func (p *DryRunProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	record, zone := r.URL.Query().Get("record"), r.URL.Query().Get("zone")
+	plans := []Plan{}
+	for _, plan := range p.Plans() {
+		if (record == "" || plan.Record == record) && (zone == "" || plan.Zone == zone) {
+			plans = append(plans, plan)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(plans); err != nil {
+		p.logger.Error(err, "Failed to write DNS plans")
+	}
+}
+
+// DescribeChanges returns a human readable description of the changes.
+func DescribeChanges(changes []Change) string {
+	if len(changes) == 0 {
+		return "no changes"
+	}
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, change.String())
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+type dryRunHealthCheckReconciler struct {
+	logger logr.Logger
+}
+
+var _ HealthCheckReconciler = &dryRunHealthCheckReconciler{}
+
+func (r *dryRunHealthCheckReconciler) Reconcile(_ context.Context, spec HealthCheckSpec, endpoint *v1.Endpoint) error {
+	r.logger.Info("Skipping health check reconciliation in dry run mode", "healthCheck", spec.Name, "endpoint", endpoint.SetID())
+	return nil
+}
+
+func (r *dryRunHealthCheckReconciler) Delete(_ context.Context, endpoint *v1.Endpoint) error {
+	r.logger.Info("Skipping health check deletion in dry run mode", "endpoint", endpoint.SetID())
+	return nil
+}
@@ -0,0 +1,93 @@
+package dns
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+func TestPlanChanges(t *testing.T) {
+	record := testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1"), aEndpoint("abc.example.com", "2.2.2.2"))
+	record.Status.Zones = []v1.DNSZoneStatus{{
+		DNSZone:   testZone,
+		Endpoints: []*v1.Endpoint{aEndpoint("abc.example.com", "1.1.1.1"), aEndpoint("abc.example.com", "3.3.3.3")},
+	}}
+
+	tests := []struct {
+		name     string
+		zoneID   string
+		action   string
+		expected string
+	}{
+		{
+			name:     "upsert deletes the records no longer desired",
+			zoneID:   testZone.ID,
+			action:   UpsertAction,
+			expected: "DELETE A abc.example.com [3.3.3.3], UPSERT A abc.example.com [1.1.1.1], UPSERT A abc.example.com [2.2.2.2]",
+		},
+		{
+			name:     "upsert to a zone without status",
+			zoneID:   "other.com",
+			action:   UpsertAction,
+			expected: "UPSERT A abc.example.com [1.1.1.1], UPSERT A abc.example.com [2.2.2.2]",
+		},
+		{
+			name:     "delete",
+			zoneID:   testZone.ID,
+			action:   DeleteAction,
+			expected: "DELETE A abc.example.com [1.1.1.1], DELETE A abc.example.com [2.2.2.2]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DescribeChanges(PlanChanges(record, tt.zoneID, tt.action)); got != tt.expected {
+				t.Errorf("expected changes %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDryRunProvider(t *testing.T) {
+	inner := newRecordingProvider()
+	provider := NewDryRunProvider(inner, logr.Discard())
+	if _, ok := provider.(RecordReader); !ok {
+		t.Fatalf("expected the dry run provider to read the records of the provider")
+	}
+	dryRun, ok := AsDryRunProvider(provider)
+	if !ok {
+		t.Fatalf("expected a dry run provider")
+	}
+
+	record := testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1"))
+	if err := provider.Ensure(record, testZone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inner.records) != 0 {
+		t.Errorf("expected no record to be published, got %v", inner.keys())
+	}
+	if changes, _ := dryRun.Plan(record, testZone.ID); DescribeChanges(changes) != "UPSERT A abc.example.com [1.1.1.1]" {
+		t.Errorf("unexpected planned changes %v", changes)
+	}
+
+	response := httptest.NewRecorder()
+	dryRun.ServeHTTP(response, httptest.NewRequest("GET", "/debug/dns/plan?zone=example.com", nil))
+	var plans []Plan
+	if err := json.NewDecoder(response.Body).Decode(&plans); err != nil {
+		t.Fatalf("failed to decode plans: %v", err)
+	}
+	key, _ := cache.MetaNamespaceKeyFunc(record)
+	if len(plans) != 1 || plans[0].Record != key || len(plans[0].Changes) != 1 {
+		t.Errorf("unexpected plans %+v", plans)
+	}
+
+	dryRun.Forget(key)
+	if plans := dryRun.Plans(); len(plans) != 0 {
+		t.Errorf("expected the plans to be forgotten, got %+v", plans)
+	}
+}
@@ -0,0 +1,469 @@
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+const (
+	// DefaultProbeInterval is the default interval between the probes of an
+	// endpoint
+	DefaultProbeInterval = 30 * time.Second
+	// defaultProbeTimeout is the time after which a probe fails
+	defaultProbeTimeout = 4 * time.Second
+	// defaultProbeFailureThreshold is the number of consecutive failed, or
+	// successful, probes after which an endpoint is considered unhealthy, or
+	// healthy again, as by Route53
+	defaultProbeFailureThreshold = 3
+	// maxSearchedBodySize is the size of the beginning of the response body
+	// searched for the search string, as by Route53
+	maxSearchedBodySize = 5120
+)
+
+// Prober is a health check reconciler probing the endpoints from within GLBC,
+// rather than by the DNS provider, so that the health of the endpoints is
+// checked whatever the DNS provider.
+type Prober struct {
+	logger   logr.Logger
+	interval time.Duration
+	client   *http.Client
+	dialer   *net.Dialer
+
+	// OnChange is called when an endpoint becomes unhealthy, or healthy again.
+	OnChange func(endpoint *v1.Endpoint)
+
+	probes map[string]*probe
+	lock   sync.RWMutex
+}
+
+// probe is the health check of an endpoint, and its latest results.
+type probe struct {
+	spec    HealthCheckSpec
+	dnsName string
+	// addresses are the targets of the endpoint, which is healthy while any
+	// of them is, as with the CALCULATED health checks of Route53
+	addresses []string
+	endpoint  *v1.Endpoint
+
+	healthy     bool
+	failures    int64
+	successes   int64
+	lastError   string
+	lastChecked time.Time
+}
+
+var (
+	_ HealthCheckReconciler     = &Prober{}
+	_ HealthCheckStatusReader   = &Prober{}
+	_ HealthCheckStatusReporter = &Prober{}
+)
+
+// NewProber returns a prober probing the endpoints at the interval.
+func NewProber(interval time.Duration, logger logr.Logger) *Prober {
+	if interval <= 0 {
+		interval = DefaultProbeInterval
+	}
+	dialer := &net.Dialer{Timeout: defaultProbeTimeout}
+	return &Prober{
+		logger:   logger,
+		interval: interval,
+		dialer:   dialer,
+		client: &http.Client{
+			Timeout: defaultProbeTimeout,
+			Transport: &http.Transport{
+				DialContext: dialer.DialContext,
+				// The endpoints are probed by IP address, and as with Route53,
+				// their certificate is not validated
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, // #nosec G402
+				DisableKeepAlives: true,
+			},
+			// The redirects are not followed, and count as successes
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		probes: map[string]*probe{},
+	}
+}
+
+// Start probes the endpoints at the interval of the prober, until the context
+// is done.
+func (p *Prober) Start(ctx context.Context) {
+	wait.UntilWithContext(ctx, p.ProbeAll, p.interval)
+}
+
+// Reconcile starts probing the endpoint as per the spec, or updates its probe.
+// The results of the probe are kept, unless its addresses, port, protocol,
+// search string, or inversion change.
+func (p *Prober) Reconcile(_ context.Context, spec HealthCheckSpec, endpoint *v1.Endpoint) error {
+	if _, ok := endpoint.GetAddress(); !ok {
+		return fmt.Errorf("endpoint %s has no address to probe", endpoint.DNSName)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	key := probeKey(endpoint)
+	existing, ok := p.probes[key]
+	if ok && stringsEqual(existing.addresses, endpoint.Targets) && int64Value(existing.spec.Port) == int64Value(spec.Port) &&
+		protocolValue(existing.spec.Protocol) == protocolValue(spec.Protocol) &&
+		existing.spec.SearchString == spec.SearchString && existing.spec.Inverted == spec.Inverted {
+		existing.spec = spec
+		return nil
+	}
+	p.probes[key] = &probe{
+		spec:      spec,
+		dnsName:   endpoint.DNSName,
+		addresses: append([]string(nil), endpoint.Targets...),
+		endpoint: &v1.Endpoint{
+			DNSName:       endpoint.DNSName,
+			RecordType:    endpoint.RecordType,
+			SetIdentifier: endpoint.SetIdentifier,
+			Targets:       endpoint.Targets,
+		},
+		healthy: true,
+	}
+	return nil
+}
+
+// Delete stops probing the endpoint.
+func (p *Prober) Delete(_ context.Context, endpoint *v1.Endpoint) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.probes, probeKey(endpoint))
+	return nil
+}
+
+// Retain stops probing the endpoints other than the given ones, e.g. once they
+// are no longer endpoints of any DNSRecord.
+func (p *Prober) Retain(endpoints []*v1.Endpoint) {
+	retained := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		retained[probeKey(endpoint)] = true
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for key, check := range p.probes {
+		if !retained[key] {
+			p.logger.Info("Deleting stale probe", "name", check.endpoint.DNSName, "identifier", check.endpoint.SetIdentifier)
+			delete(p.probes, key)
+		}
+	}
+}
+
+// Interval returns the interval between the probes of the endpoints.
+func (p *Prober) Interval() time.Duration {
+	return p.interval
+}
+
+// Healthy returns whether the endpoint is healthy, as per its latest probes.
+// An endpoint that has not been probed yet is considered healthy.
+func (p *Prober) Healthy(_ context.Context, endpoint *v1.Endpoint) (bool, bool, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	check, ok := p.probes[probeKey(endpoint)]
+	if !ok {
+		return false, false, nil
+	}
+	return check.healthy, true, nil
+}
+
+// Status returns the health of the endpoint as per its latest probes, or nil if
+// it is not probed.
+func (p *Prober) Status(_ context.Context, endpoint *v1.Endpoint) (*v1.EndpointHealthStatus, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	check, ok := p.probes[probeKey(endpoint)]
+	if !ok {
+		return nil, nil
+	}
+	status := &v1.EndpointHealthStatus{
+		DNSName:       endpoint.DNSName,
+		SetIdentifier: endpoint.SetIdentifier,
+		Healthy:       check.healthy,
+	}
+	if !check.healthy {
+		status.Message = check.lastError
+	}
+	if !check.lastChecked.IsZero() {
+		lastChecked := metav1.NewTime(check.lastChecked)
+		status.LastCheckedTime = &lastChecked
+	}
+	return status, nil
+}
+
+// LastError returns the error of the latest failed probe of the endpoint, if
+// it is unhealthy.
+func (p *Prober) LastError(endpoint *v1.Endpoint) string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	check, ok := p.probes[probeKey(endpoint)]
+	if !ok || check.healthy {
+		return ""
+	}
+	return check.lastError
+}
+
+// ProbeAll probes all the endpoints once.
+func (p *Prober) ProbeAll(ctx context.Context) {
+	p.lock.RLock()
+	probes := make(map[string]probe, len(p.probes))
+	for key, check := range p.probes {
+		probes[key] = *check
+	}
+	p.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	for key, check := range probes {
+		wg.Add(1)
+		go func(key string, check probe) {
+			defer wg.Done()
+			start := time.Now()
+			err := p.probe(ctx, check)
+			healthProbeDuration.WithLabelValues(string(protocolValue(check.spec.Protocol))).Observe(time.Since(start).Seconds())
+			p.record(key, check, err)
+		}(key, check)
+	}
+	wg.Wait()
+
+	healthy, unhealthy := 0, 0
+	p.lock.RLock()
+	for _, check := range p.probes {
+		if check.healthy {
+			healthy++
+		} else {
+			unhealthy++
+		}
+	}
+	p.lock.RUnlock()
+	healthProbeEndpoints.WithLabelValues(probeHealthy).Set(float64(healthy))
+	healthProbeEndpoints.WithLabelValues(probeUnhealthy).Set(float64(unhealthy))
+}
+
+// record records the result of the probe of the endpoint, and notifies the
+// change of its health once the failure threshold is reached.
+func (p *Prober) record(key string, result probe, err error) {
+	p.lock.Lock()
+	current, ok := p.probes[key]
+	// Ignore the results of the probes that have been deleted, or replaced
+	if !ok || !stringsEqual(current.addresses, result.addresses) {
+		p.lock.Unlock()
+		return
+	}
+	threshold := int64(defaultProbeFailureThreshold)
+	if current.spec.FailureThreshold != nil && *current.spec.FailureThreshold > 0 {
+		threshold = *current.spec.FailureThreshold
+	}
+
+	wasHealthy := current.healthy
+	current.lastChecked = time.Now()
+	if err != nil {
+		healthProbeTotal.WithLabelValues(probeFailure).Inc()
+		current.failures++
+		current.successes = 0
+		current.lastError = err.Error()
+		if current.failures >= threshold {
+			current.healthy = false
+		}
+	} else {
+		healthProbeTotal.WithLabelValues(probeSuccess).Inc()
+		current.successes++
+		current.failures = 0
+		if current.successes >= threshold {
+			current.healthy = true
+			current.lastError = ""
+		}
+	}
+	changed := wasHealthy != current.healthy
+	endpoint := current.endpoint
+	healthy := current.healthy
+	lastError := current.lastError
+	p.lock.Unlock()
+
+	if !changed {
+		return
+	}
+	p.logger.Info("Endpoint health changed", "name", endpoint.DNSName, "identifier", endpoint.SetIdentifier, "addresses", result.addresses, "healthy", healthy, "error", lastError)
+	if p.OnChange != nil {
+		p.OnChange(endpoint)
+	}
+}
+
+// probe probes the endpoint, and returns the reason it is unhealthy, if so,
+// inverting the result of the probe if configured.
+func (p *Prober) probe(ctx context.Context, check probe) error {
+	err := p.probeEndpoint(ctx, check)
+	if !check.spec.Inverted {
+		return err
+	}
+	if err == nil {
+		return errors.New("inverted probe succeeded")
+	}
+	return nil
+}
+
+// probeEndpoint probes the addresses of the endpoint, and returns the reason
+// the last one is unhealthy, if all of them are.
+func (p *Prober) probeEndpoint(ctx context.Context, check probe) error {
+	var err error
+	for _, address := range check.addresses {
+		if err = p.probeAddress(ctx, check, address); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (p *Prober) probeAddress(ctx context.Context, check probe, target string) error {
+	protocol := protocolValue(check.spec.Protocol)
+	port := check.spec.Port
+	if port == nil {
+		defaultPort := int64(80)
+		if protocol == HealthCheckProtocolHTTPS {
+			defaultPort = 443
+		}
+		port = &defaultPort
+	}
+	address := net.JoinHostPort(target, strconv.FormatInt(*port, 10))
+
+	ctx, cancel := context.WithTimeout(ctx, defaultProbeTimeout)
+	defer cancel()
+
+	if protocol == HealthCheckProtocolTCP {
+		conn, err := p.dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	scheme := "http"
+	if protocol == HealthCheckProtocolHTTPS {
+		scheme = "https"
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s%s", scheme, address, check.spec.Path), nil)
+	if err != nil {
+		return err
+	}
+	// Probe the endpoint as the host it serves
+	request.Host = check.dnsName
+	response, err := p.clientFor(check).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// Route53 considers the 2xx and 3xx status codes healthy
+	if response.StatusCode < 200 || response.StatusCode >= 400 {
+		return fmt.Errorf("unhealthy status code %d", response.StatusCode)
+	}
+	if check.spec.SearchString == "" {
+		return nil
+	}
+	// As Route53, only search the beginning of the response body
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxSearchedBodySize))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), check.spec.SearchString) {
+		return fmt.Errorf("response body does not contain %q", check.spec.SearchString)
+	}
+	return nil
+}
+
+// clientFor returns the client probing the endpoint, sending its host name in
+// the TLS handshake of HTTPS probes, unless SNI is disabled.
+func (p *Prober) clientFor(check probe) *http.Client {
+	if protocolValue(check.spec.Protocol) != HealthCheckProtocolHTTPS || (check.spec.EnableSNI != nil && !*check.spec.EnableSNI) {
+		return p.client
+	}
+	client := *p.client
+	client.Transport = &http.Transport{
+		DialContext:       p.dialer.DialContext,
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true, ServerName: check.dnsName}, // #nosec G402
+		DisableKeepAlives: true,
+	}
+	return &client
+}
+
+func probeKey(endpoint *v1.Endpoint) string {
+	return endpoint.DNSName + "/" + endpoint.RecordType + "/" + endpoint.SetID()
+}
+
+func stringsEqual(values1, values2 []string) bool {
+	if len(values1) != len(values2) {
+		return false
+	}
+	for i := range values1 {
+		if values1[i] != values2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func protocolValue(protocol *HealthCheckProtocol) HealthCheckProtocol {
+	if protocol == nil {
+		return HealthCheckProtocolHTTP
+	}
+	return *protocol
+}
+
+func int64Value(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// ProbingProvider is a DNS provider whose endpoints are probed from within
+// GLBC, rather than checked by the provider.
+type ProbingProvider struct {
+	Provider
+	prober *Prober
+}
+
+var _ Provider = &ProbingProvider{}
+
+// NewProbingProvider returns a provider whose endpoints are probed by the
+// prober. The records of the zones are read with the given provider, if it
+// supports it, e.g. to check them for drift.
+func NewProbingProvider(provider Provider, prober *Prober) Provider {
+	p := &ProbingProvider{Provider: provider, prober: prober}
+	if reader, ok := provider.(RecordReader); ok {
+		return &probingRecordReader{ProbingProvider: p, RecordReader: reader}
+	}
+	return p
+}
+
+// probingRecordReader is a ProbingProvider for the providers that can read
+// records.
+type probingRecordReader struct {
+	*ProbingProvider
+	RecordReader
+}
+
+// HealthCheckReconciler returns the prober.
+func (p *ProbingProvider) HealthCheckReconciler() HealthCheckReconciler {
+	return p.prober
+}
+
+// AsProber returns the prober of the provider, if its endpoints are probed.
+func AsProber(provider Provider) (*Prober, bool) {
+	prober, ok := provider.HealthCheckReconciler().(*Prober)
+	return prober, ok
+}
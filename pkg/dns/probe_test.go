@@ -0,0 +1,176 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+func TestProber(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	status := http.StatusOK
+	host := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("status: ok"))
+	}))
+	defer server.Close()
+	address, portValue, err := net.SplitHostPort(server.Listener.Addr().String())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	port, err := strconv.ParseInt(portValue, 10, 64)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	endpoint := &v1.Endpoint{
+		DNSName:       "abc.example.com",
+		RecordType:    "A",
+		SetIdentifier: address,
+		Targets:       v1.Targets{address},
+	}
+	threshold := int64(2)
+	prober := NewProber(0, logr.Discard())
+	changes := 0
+	prober.OnChange = func(*v1.Endpoint) { changes++ }
+
+	_, checked, _ := prober.Healthy(context.Background(), endpoint)
+	g.Expect(checked).To(gomega.BeFalse())
+
+	g.Expect(prober.Reconcile(context.Background(), HealthCheckSpec{Path: "/healthz", Port: &port, FailureThreshold: &threshold}, endpoint)).To(gomega.Succeed())
+	healthy, checked, _ := prober.Healthy(context.Background(), endpoint)
+	g.Expect(checked).To(gomega.BeTrue())
+	g.Expect(healthy).To(gomega.BeTrue())
+
+	prober.ProbeAll(context.Background())
+	g.Expect(host).To(gomega.Equal("abc.example.com"))
+
+	// Unhealthy once the failure threshold is reached
+	status = http.StatusServiceUnavailable
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(healthy).To(gomega.BeTrue())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(healthy).To(gomega.BeFalse())
+	g.Expect(prober.LastError(endpoint)).To(gomega.ContainSubstring("503"))
+	g.Expect(changes).To(gomega.Equal(1))
+	healthStatus, err := prober.Status(context.Background(), endpoint)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(healthStatus.Healthy).To(gomega.BeFalse())
+	g.Expect(healthStatus.Message).To(gomega.ContainSubstring("503"))
+	g.Expect(healthStatus.LastCheckedTime).NotTo(gomega.BeNil())
+
+	// Healthy again once as many probes succeed
+	status = http.StatusFound
+	prober.ProbeAll(context.Background())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(healthy).To(gomega.BeTrue())
+	g.Expect(changes).To(gomega.Equal(2))
+
+	// TCP probes only connect
+	tcp := HealthCheckProtocolTCP
+	g.Expect(prober.Reconcile(context.Background(), HealthCheckSpec{Port: &port, Protocol: &tcp, FailureThreshold: &threshold}, endpoint)).To(gomega.Succeed())
+	status = http.StatusServiceUnavailable
+	prober.ProbeAll(context.Background())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(healthy).To(gomega.BeTrue())
+
+	// Search strings are matched within the response body
+	status = http.StatusOK
+	g.Expect(prober.Reconcile(context.Background(), HealthCheckSpec{Path: "/healthz", Port: &port, FailureThreshold: &threshold, SearchString: "status: failed"}, endpoint)).To(gomega.Succeed())
+	prober.ProbeAll(context.Background())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(healthy).To(gomega.BeFalse())
+	g.Expect(prober.LastError(endpoint)).To(gomega.ContainSubstring("status: failed"))
+
+	// Inverted probes fail as the endpoint is healthy
+	g.Expect(prober.Reconcile(context.Background(), HealthCheckSpec{Path: "/healthz", Port: &port, FailureThreshold: &threshold, SearchString: "status: ok", Inverted: true}, endpoint)).To(gomega.Succeed())
+	prober.ProbeAll(context.Background())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(healthy).To(gomega.BeFalse())
+	g.Expect(prober.LastError(endpoint)).To(gomega.ContainSubstring("inverted"))
+
+	g.Expect(prober.Delete(context.Background(), endpoint)).To(gomega.Succeed())
+	_, checked, _ = prober.Healthy(context.Background(), endpoint)
+	g.Expect(checked).To(gomega.BeFalse())
+
+	// Endpoints of several targets are healthy while any of them is
+	failover := &v1.Endpoint{
+		DNSName:       "abc.example.com",
+		RecordType:    "A",
+		SetIdentifier: "primary",
+		Targets:       v1.Targets{"127.0.0.2", address},
+	}
+	status = http.StatusServiceUnavailable
+	g.Expect(prober.Reconcile(context.Background(), HealthCheckSpec{Path: "/healthz", Port: &port, FailureThreshold: &threshold}, failover)).To(gomega.Succeed())
+	prober.ProbeAll(context.Background())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), failover)
+	g.Expect(healthy).To(gomega.BeFalse())
+	status = http.StatusOK
+	prober.ProbeAll(context.Background())
+	prober.ProbeAll(context.Background())
+	healthy, _, _ = prober.Healthy(context.Background(), failover)
+	g.Expect(healthy).To(gomega.BeTrue())
+}
+
+func TestProbingProviderRecordReader(t *testing.T) {
+	prober := NewProber(0, logr.Discard())
+	registry, err := NewTXTRegistry(newRecordingProvider(), "glbc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		provider Provider
+		want     bool
+	}{
+		{name: "record reader", provider: newRecordingProvider(), want: true},
+		{name: "ownership registry", provider: registry, want: true},
+		{name: "dry run", provider: NewDryRunProvider(newRecordingProvider(), logr.Discard()), want: true},
+		{name: "no record reader", provider: &FakeProvider{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			provider := NewProbingProvider(tt.provider, prober)
+			_, ok := provider.(RecordReader)
+			g.Expect(ok).To(gomega.Equal(tt.want))
+			probed, ok := AsProber(provider)
+			g.Expect(ok).To(gomega.BeTrue())
+			g.Expect(probed).To(gomega.BeIdenticalTo(prober))
+		})
+	}
+}
+
+func TestProberRetain(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	endpoint := func(setID string) *v1.Endpoint {
+		return &v1.Endpoint{DNSName: "abc.example.com", RecordType: "A", SetIdentifier: setID, Targets: v1.Targets{setID}}
+	}
+	prober := NewProber(0, logr.Discard())
+	for _, setID := range []string{"1.1.1.1", "2.2.2.2"} {
+		g.Expect(prober.Reconcile(context.Background(), HealthCheckSpec{}, endpoint(setID))).To(gomega.Succeed())
+	}
+
+	prober.Retain([]*v1.Endpoint{endpoint("1.1.1.1")})
+	_, checked, _ := prober.Healthy(context.Background(), endpoint("1.1.1.1"))
+	g.Expect(checked).To(gomega.BeTrue())
+	_, checked, _ = prober.Healthy(context.Background(), endpoint("2.2.2.2"))
+	g.Expect(checked).To(gomega.BeFalse())
+}
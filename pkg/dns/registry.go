@@ -0,0 +1,303 @@
+package dns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+const (
+	// ownershipRecordPrefix prefixes the name of the TXT records holding the
+	// ownership of the records, e.g. `_glbc-a.abc.example.com` for the A records
+	// of `abc.example.com`, or `_glbc-a-wildcard.example.com` for the A records
+	// of `*.example.com`.
+	ownershipRecordPrefix = "_glbc-"
+
+	ownershipHeritage = "kcp-glbc"
+	heritageLabel     = "heritage"
+	ownerLabel        = "kcp-glbc/owner"
+	resourceLabel     = "kcp-glbc/resource"
+)
+
+var (
+	_ Provider     = &TXTRegistry{}
+	_ RecordReader = &TXTRegistry{}
+)
+
+// TXTRegistry is a Provider recording the ownership of the records it publishes
+// in TXT records alongside them, the same way external-dns does. The records
+// owned by another owner or DNSRecord, and the records published by other
+// tools, are not modified. As the records owned by a DNSRecord are read from
+// the zone, they are still updated, or deleted, once its status is lost.
+type TXTRegistry struct {
+	Provider
+	reader  RecordReader
+	ownerID string
+}
+
+// NewTXTRegistry returns a TXTRegistry publishing the records with the given
+// provider, which must be able to read the records of the zones.
+func NewTXTRegistry(provider Provider, ownerID string) (*TXTRegistry, error) {
+	if ownerID == "" {
+		return nil, fmt.Errorf("an owner ID is required")
+	}
+	reader, ok := provider.(RecordReader)
+	if !ok {
+		return nil, fmt.Errorf("the DNS provider does not support reading the records of a zone, required to record their ownership")
+	}
+	return &TXTRegistry{
+		Provider: provider,
+		reader:   reader,
+		ownerID:  ownerID,
+	}, nil
+}
+
+func (r *TXTRegistry) Records(zone v1.DNSZone) ([]*v1.Endpoint, error) {
+	return r.reader.Records(zone)
+}
+
+// Ensure publishes the records along with their ownership records, unless any
+// of them is owned by someone else, in which case none is published.
+func (r *TXTRegistry) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	zoneRecords, err := r.readZoneRecords(zone)
+	if err != nil {
+		return err
+	}
+	resource := resourceOf(record)
+
+	published := map[recordKey]bool{}
+	for _, endpoint := range EndpointsFromZoneStatus(record, zone.ID) {
+		published[keyForEndpoint(endpoint)] = true
+	}
+
+	var errs []error
+	desired := map[recordKey]v1.TTL{}
+	for _, endpoint := range record.Spec.Endpoints {
+		key := keyForEndpoint(endpoint)
+		if _, found := desired[key]; found {
+			continue
+		}
+		desired[key] = endpoint.RecordTTL
+		if err := zoneRecords.checkOwnership(key, r.ownerID, resource, published[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("refusing to update records in zone %s: %v", zone.ID, utilerrors.NewAggregate(errs))
+	}
+
+	ensured := record.DeepCopy()
+	for _, key := range sortedRecordKeys(desired) {
+		ensured.Spec.Endpoints = append(ensured.Spec.Endpoints, ownershipEndpoint(key, r.ownerID, resource, desired[key]))
+	}
+	// The provider deletes the records currently owned by the DNSRecord that
+	// are no longer desired
+	setEndpointsOfZoneStatus(ensured, zone, zoneRecords.ownedBy(r.ownerID, resource, published))
+
+	return r.Provider.Ensure(ensured, zone)
+}
+
+// Delete deletes the records, along with their ownership records, that are
+// owned by the DNSRecord. The records owned by someone else are left untouched.
+func (r *TXTRegistry) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	zoneRecords, err := r.readZoneRecords(zone)
+	if err != nil {
+		return err
+	}
+
+	published := map[recordKey]bool{}
+	for _, endpoint := range record.Spec.Endpoints {
+		published[keyForEndpoint(endpoint)] = true
+	}
+
+	deleted := record.DeepCopy()
+	deleted.Spec.Endpoints = zoneRecords.ownedBy(r.ownerID, resourceOf(record), published)
+	if len(deleted.Spec.Endpoints) == 0 {
+		return nil
+	}
+
+	return r.Provider.Delete(deleted, zone)
+}
+
+type recordKey struct {
+	name       string
+	recordType string
+}
+
+func keyForEndpoint(endpoint *v1.Endpoint) recordKey {
+	return recordKey{
+		name:       strings.ToLower(strings.TrimSuffix(endpoint.DNSName, ".")),
+		recordType: endpoint.RecordType,
+	}
+}
+
+type owner struct {
+	id       string
+	resource string
+	record   *v1.Endpoint
+}
+
+// zoneRecords are the records of a zone, by name and type, and their owners.
+type zoneRecords struct {
+	records map[recordKey][]*v1.Endpoint
+	owners  map[recordKey]owner
+}
+
+func (r *TXTRegistry) readZoneRecords(zone v1.DNSZone) (*zoneRecords, error) {
+	endpoints, err := r.reader.Records(zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records of zone %s: %v", zone.ID, err)
+	}
+
+	z := &zoneRecords{
+		records: map[recordKey][]*v1.Endpoint{},
+		owners:  map[recordKey]owner{},
+	}
+	for _, endpoint := range endpoints {
+		if key, ok := ownedRecordKey(endpoint); ok && len(endpoint.Targets) == 1 {
+			if id, resource, ok := parseOwnership(endpoint.Targets[0]); ok {
+				z.owners[key] = owner{id: id, resource: resource, record: endpoint}
+				continue
+			}
+		}
+		key := keyForEndpoint(endpoint)
+		z.records[key] = append(z.records[key], endpoint)
+	}
+	return z, nil
+}
+
+// checkOwnership returns an error if the records with the given key cannot be
+// modified by the owner on behalf of the resource, i.e., if they are owned by
+// someone else, or if they exist without ownership record and have not been
+// published by the resource.
+func (z *zoneRecords) checkOwnership(key recordKey, ownerID, resource string, published bool) error {
+	if o, ok := z.owners[key]; ok {
+		if o.id != ownerID {
+			return fmt.Errorf("%s record %s is owned by %s", key.recordType, key.name, o.id)
+		}
+		if o.resource != resource {
+			return fmt.Errorf("%s record %s is owned by %s", key.recordType, key.name, o.resource)
+		}
+		return nil
+	}
+	if len(z.records[key]) > 0 && !published {
+		return fmt.Errorf("%s record %s already exists and is not owned by %s", key.recordType, key.name, ownerID)
+	}
+	return nil
+}
+
+// ownedBy returns the records, and their ownership records, owned by the
+// owner on behalf of the resource. The records published by the resource
+// before their ownership was recorded are also returned.
+func (z *zoneRecords) ownedBy(ownerID, resource string, published map[recordKey]bool) []*v1.Endpoint {
+	keys := map[recordKey]v1.TTL{}
+	for key := range z.records {
+		keys[key] = 0
+	}
+	for key := range z.owners {
+		keys[key] = 0
+	}
+
+	var endpoints []*v1.Endpoint
+	for _, key := range sortedRecordKeys(keys) {
+		o, owned := z.owners[key]
+		switch {
+		case owned && o.id == ownerID && o.resource == resource:
+			endpoints = append(endpoints, z.records[key]...)
+			endpoints = append(endpoints, o.record)
+		case !owned && published[key]:
+			endpoints = append(endpoints, z.records[key]...)
+		}
+	}
+	return endpoints
+}
+
+func ownershipEndpoint(key recordKey, ownerID, resource string, ttl v1.TTL) *v1.Endpoint {
+	return &v1.Endpoint{
+		DNSName:    ownershipRecordName(key),
+		RecordType: string(v1.TXTRecordType),
+		Targets: []string{fmt.Sprintf("%s=%s,%s=%s,%s=%s",
+			heritageLabel, ownershipHeritage, ownerLabel, ownerID, resourceLabel, resource)},
+		RecordTTL: ttl,
+	}
+}
+
+func ownershipRecordName(key recordKey) string {
+	label := ownershipRecordPrefix + strings.ToLower(key.recordType)
+	if strings.HasPrefix(key.name, "*.") {
+		return label + "-wildcard." + strings.TrimPrefix(key.name, "*.")
+	}
+	return label + "." + key.name
+}
+
+// ownedRecordKey returns the key of the records owned by the given ownership
+// record, if it is one.
+func ownedRecordKey(endpoint *v1.Endpoint) (recordKey, bool) {
+	key := keyForEndpoint(endpoint)
+	if key.recordType != string(v1.TXTRecordType) || !strings.HasPrefix(key.name, ownershipRecordPrefix) {
+		return recordKey{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key.name, ownershipRecordPrefix), ".", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return recordKey{}, false
+	}
+	if recordType := strings.TrimSuffix(parts[0], "-wildcard"); recordType != parts[0] {
+		return recordKey{name: "*." + parts[1], recordType: strings.ToUpper(recordType)}, true
+	}
+	return recordKey{name: parts[1], recordType: strings.ToUpper(parts[0])}, true
+}
+
+// parseOwnership returns the owner and resource recorded in the value of an
+// ownership record, e.g. `heritage=kcp-glbc,kcp-glbc/owner=glbc,kcp-glbc/resource=dnsrecord/root:org:ws/default/echo`.
+func parseOwnership(value string) (string, string, bool) {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 {
+			labels[kv[0]] = kv[1]
+		}
+	}
+	if labels[heritageLabel] != ownershipHeritage || labels[ownerLabel] == "" {
+		return "", "", false
+	}
+	return labels[ownerLabel], labels[resourceLabel], true
+}
+
+// resourceOf returns the identifier of the DNSRecord recorded in the ownership
+// records.
+func resourceOf(record *v1.DNSRecord) string {
+	return fmt.Sprintf("dnsrecord/%s/%s/%s", logicalcluster.From(record), record.Namespace, record.Name)
+}
+
+func sortedRecordKeys(keys map[recordKey]v1.TTL) []recordKey {
+	sorted := make([]recordKey, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].name != sorted[j].name {
+			return sorted[i].name < sorted[j].name
+		}
+		return sorted[i].recordType < sorted[j].recordType
+	})
+	return sorted
+}
+
+func setEndpointsOfZoneStatus(record *v1.DNSRecord, zone v1.DNSZone, endpoints []*v1.Endpoint) {
+	for i := range record.Status.Zones {
+		if record.Status.Zones[i].DNSZone.ID == zone.ID {
+			record.Status.Zones[i].Endpoints = endpoints
+			return
+		}
+	}
+	record.Status.Zones = append(record.Status.Zones, v1.DNSZoneStatus{
+		DNSZone:   zone,
+		Endpoints: endpoints,
+	})
+}
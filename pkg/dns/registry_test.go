@@ -0,0 +1,247 @@
+package dns
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+// recordingProvider stores the records of a single zone, keyed by name, type
+// and set identifier, and can read them back.
+type recordingProvider struct {
+	FakeProvider
+	records map[string]*v1.Endpoint
+}
+
+func newRecordingProvider(endpoints ...*v1.Endpoint) *recordingProvider {
+	p := &recordingProvider{records: map[string]*v1.Endpoint{}}
+	for _, endpoint := range endpoints {
+		p.records[recordingKey(endpoint)] = endpoint
+	}
+	return p
+}
+
+func recordingKey(endpoint *v1.Endpoint) string {
+	return endpoint.DNSName + "/" + endpoint.RecordType + "/" + endpoint.SetIdentifier
+}
+
+func (p *recordingProvider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	desired := map[string]bool{}
+	for _, endpoint := range record.Spec.Endpoints {
+		desired[recordingKey(endpoint)] = true
+		p.records[recordingKey(endpoint)] = endpoint
+	}
+	for _, endpoint := range EndpointsFromZoneStatus(record, zone.ID) {
+		if !desired[recordingKey(endpoint)] {
+			delete(p.records, recordingKey(endpoint))
+		}
+	}
+	return nil
+}
+
+func (p *recordingProvider) Delete(record *v1.DNSRecord, _ v1.DNSZone) error {
+	for _, endpoint := range record.Spec.Endpoints {
+		delete(p.records, recordingKey(endpoint))
+	}
+	return nil
+}
+
+func (p *recordingProvider) Records(_ v1.DNSZone) ([]*v1.Endpoint, error) {
+	var endpoints []*v1.Endpoint
+	for _, endpoint := range p.records {
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+func (p *recordingProvider) keys() []string {
+	var keys []string
+	for key := range p.records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var testZone = v1.DNSZone{ID: "example.com", DNSName: "example.com"}
+
+func testRecord(name string, endpoints ...*v1.Endpoint) *v1.DNSRecord {
+	return &v1.DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			ClusterName: "root:org:ws",
+		},
+		Spec: v1.DNSRecordSpec{Endpoints: endpoints},
+	}
+}
+
+func aEndpoint(dnsName, ip string) *v1.Endpoint {
+	return &v1.Endpoint{
+		DNSName:       dnsName,
+		RecordType:    string(v1.ARecordType),
+		SetIdentifier: ip,
+		Targets:       []string{ip},
+		RecordTTL:     60,
+	}
+}
+
+func ownershipRecord(dnsName, value string) *v1.Endpoint {
+	return &v1.Endpoint{
+		DNSName:    dnsName,
+		RecordType: string(v1.TXTRecordType),
+		Targets:    []string{value},
+	}
+}
+
+func TestTXTRegistryEnsure(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []*v1.Endpoint
+		record   *v1.DNSRecord
+		wantErr  string
+		wantKeys []string
+	}{
+		{
+			name:   "new record",
+			record: testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1")),
+			wantKeys: []string{
+				"_glbc-a.abc.example.com/TXT/",
+				"abc.example.com/A/1.1.1.1",
+			},
+		},
+		{
+			name:   "wildcard record",
+			record: testRecord("echo", aEndpoint("*.abc.example.com", "1.1.1.1")),
+			wantKeys: []string{
+				"*.abc.example.com/A/1.1.1.1",
+				"_glbc-a-wildcard.abc.example.com/TXT/",
+			},
+		},
+		{
+			name: "record owned by another owner",
+			existing: []*v1.Endpoint{
+				aEndpoint("abc.example.com", "2.2.2.2"),
+				ownershipRecord("_glbc-a.abc.example.com", "heritage=kcp-glbc,kcp-glbc/owner=other,kcp-glbc/resource=dnsrecord/root:org:ws/default/echo"),
+			},
+			record:  testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1")),
+			wantErr: "A record abc.example.com is owned by other",
+			wantKeys: []string{
+				"_glbc-a.abc.example.com/TXT/",
+				"abc.example.com/A/2.2.2.2",
+			},
+		},
+		{
+			name: "record owned by another DNSRecord",
+			existing: []*v1.Endpoint{
+				aEndpoint("abc.example.com", "2.2.2.2"),
+				ownershipRecord("_glbc-a.abc.example.com", "heritage=kcp-glbc,kcp-glbc/owner=glbc,kcp-glbc/resource=dnsrecord/root:org:other/default/echo"),
+			},
+			record:  testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1")),
+			wantErr: "A record abc.example.com is owned by dnsrecord/root:org:other/default/echo",
+			wantKeys: []string{
+				"_glbc-a.abc.example.com/TXT/",
+				"abc.example.com/A/2.2.2.2",
+			},
+		},
+		{
+			name: "record created by another tool",
+			existing: []*v1.Endpoint{
+				aEndpoint("abc.example.com", "2.2.2.2"),
+			},
+			record:  testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1")),
+			wantErr: "A record abc.example.com already exists and is not owned by glbc",
+			wantKeys: []string{
+				"abc.example.com/A/2.2.2.2",
+			},
+		},
+		{
+			name: "record published before its ownership was recorded",
+			existing: []*v1.Endpoint{
+				aEndpoint("abc.example.com", "2.2.2.2"),
+			},
+			record: func() *v1.DNSRecord {
+				record := testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1"))
+				record.Status.Zones = []v1.DNSZoneStatus{{
+					DNSZone:   testZone,
+					Endpoints: []*v1.Endpoint{aEndpoint("abc.example.com", "2.2.2.2")},
+				}}
+				return record
+			}(),
+			wantKeys: []string{
+				"_glbc-a.abc.example.com/TXT/",
+				"abc.example.com/A/1.1.1.1",
+			},
+		},
+		{
+			name: "stale records owned after status loss",
+			existing: []*v1.Endpoint{
+				aEndpoint("abc.example.com", "2.2.2.2"),
+				ownershipRecord("_glbc-a.abc.example.com", "heritage=kcp-glbc,kcp-glbc/owner=glbc,kcp-glbc/resource=dnsrecord/root:org:ws/default/echo"),
+				aEndpoint("def.example.com", "2.2.2.2"),
+				ownershipRecord("_glbc-a.def.example.com", "heritage=kcp-glbc,kcp-glbc/owner=glbc,kcp-glbc/resource=dnsrecord/root:org:ws/default/echo"),
+			},
+			record: testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1")),
+			wantKeys: []string{
+				"_glbc-a.abc.example.com/TXT/",
+				"abc.example.com/A/1.1.1.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newRecordingProvider(tt.existing...)
+			registry, err := NewTXTRegistry(provider, "glbc")
+			if err != nil {
+				t.Fatalf("failed to create registry: %v", err)
+			}
+
+			err = registry.Ensure(tt.record, testZone)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+			if got := provider.keys(); strings.Join(got, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("expected records %v, got %v", tt.wantKeys, got)
+			}
+		})
+	}
+}
+
+func TestTXTRegistryDelete(t *testing.T) {
+	provider := newRecordingProvider(
+		aEndpoint("abc.example.com", "1.1.1.1"),
+		ownershipRecord("_glbc-a.abc.example.com", "heritage=kcp-glbc,kcp-glbc/owner=glbc,kcp-glbc/resource=dnsrecord/root:org:ws/default/echo"),
+		aEndpoint("def.example.com", "2.2.2.2"),
+		ownershipRecord("_glbc-a.def.example.com", "heritage=kcp-glbc,kcp-glbc/owner=other,kcp-glbc/resource=dnsrecord/root:org:ws/default/echo"),
+	)
+	registry, err := NewTXTRegistry(provider, "glbc")
+	if err != nil {
+		t.Fatalf("failed to create registry: %v", err)
+	}
+
+	record := testRecord("echo", aEndpoint("abc.example.com", "1.1.1.1"), aEndpoint("def.example.com", "2.2.2.2"))
+	if err := registry.Delete(record, testZone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"_glbc-a.def.example.com/TXT/", "def.example.com/A/2.2.2.2"}
+	if got := provider.keys(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected records %v, got %v", want, got)
+	}
+}
+
+func TestNewTXTRegistry(t *testing.T) {
+	if _, err := NewTXTRegistry(&FakeProvider{}, "glbc"); err == nil {
+		t.Errorf("expected error for a provider that cannot read records")
+	}
+	if _, err := NewTXTRegistry(newRecordingProvider(), ""); err == nil {
+		t.Errorf("expected error for an empty owner ID")
+	}
+}
@@ -0,0 +1,236 @@
+package rfc2136
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/miekg/dns"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/log"
+)
+
+const (
+	defaultTSIGAlgorithm = dns.HmacSHA256
+	defaultTimeout       = 10 * time.Second
+	tsigFudge            = 300
+)
+
+var _ glbcdns.Provider = &Provider{}
+
+// Provider manages records in a DNS zone using RFC 2136 dynamic updates, e.g.
+// against a BIND or Knot authoritative server. The zone ID is the zone apex,
+// e.g. `example.com`.
+//
+// Plain DNS has no notion of weights. Endpoints sharing the same DNS name and
+// type are therefore published as a single RRset holding the union of their
+// targets, which resolvers pick from evenly. The `aws/weight` property is only
+// used to leave out the targets of the endpoints with a weight of 0, unless all
+// of them have a weight of 0, so that a cluster can still be drained.
+type Provider struct {
+	client                *dns.Client
+	healthCheckReconciler *healthCheckReconciler
+	config                Config
+	logger                logr.Logger
+}
+
+// Config is the necessary input to configure the manager.
+type Config struct {
+	// Server is the address of the authoritative server the updates are sent
+	// to, e.g. `ns1.example.com:53`.
+	Server string
+	// TSIGKeyName is the name of the TSIG key used to sign the updates. The
+	// updates are not signed if empty.
+	// +optional
+	TSIGKeyName string
+	// TSIGSecret is the base64 encoded secret of the TSIG key.
+	// +optional
+	TSIGSecret string
+	// TSIGAlgorithm is the algorithm of the TSIG key, e.g. `hmac-sha256`.
+	// +optional
+	TSIGAlgorithm string
+}
+
+func NewProvider(config Config) (*Provider, error) {
+	if len(config.Server) == 0 {
+		return nil, fmt.Errorf("an RFC 2136 server is required")
+	}
+
+	client := &dns.Client{
+		Net:     "tcp",
+		Timeout: defaultTimeout,
+	}
+	if len(config.TSIGKeyName) > 0 {
+		if len(config.TSIGSecret) == 0 {
+			return nil, fmt.Errorf("a TSIG secret is required for key %s", config.TSIGKeyName)
+		}
+		config.TSIGKeyName = dns.Fqdn(config.TSIGKeyName)
+		if len(config.TSIGAlgorithm) == 0 {
+			config.TSIGAlgorithm = defaultTSIGAlgorithm
+		}
+		config.TSIGAlgorithm = dns.Fqdn(config.TSIGAlgorithm)
+		client.TsigSecret = map[string]string{config.TSIGKeyName: config.TSIGSecret}
+	}
+
+	return &Provider{
+		client: client,
+		config: config,
+		logger: log.Logger.WithName("rfc2136-dns").WithValues("server", config.Server),
+	}, nil
+}
+
+func (p *Provider) Ensure(record *v1.DNSRecord, zone v1.DNSZone) error {
+	desired, err := rrsetsForEndpoints(record.Spec.Endpoints, zone.ID)
+	if err != nil {
+		return err
+	}
+
+	m := newUpdate(zone.ID)
+	// Replace the desired RRsets as a whole
+	for _, key := range sortedKeys(desired) {
+		m.RemoveRRset([]dns.RR{key.header()})
+		m.Insert(desired[key])
+	}
+
+	// Delete any previously published RRsets that are no longer present in record.Spec.Endpoints
+	published, err := rrsetsForEndpoints(glbcdns.EndpointsFromZoneStatus(record, zone.ID), zone.ID)
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(published) {
+		if _, found := desired[key]; !found {
+			m.RemoveRRset([]dns.RR{key.header()})
+		}
+	}
+
+	if err := p.update(m); err != nil {
+		return fmt.Errorf("failed to update record in zone %s: %v", zone.ID, err)
+	}
+	p.logger.Info("Upserted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	rrsets, err := rrsetsForEndpoints(record.Spec.Endpoints, zone.ID)
+	if err != nil {
+		return err
+	}
+
+	m := newUpdate(zone.ID)
+	for _, key := range sortedKeys(rrsets) {
+		m.RemoveRRset([]dns.RR{key.header()})
+	}
+
+	if err := p.update(m); err != nil {
+		return fmt.Errorf("failed to delete record in zone %s: %v", zone.ID, err)
+	}
+	p.logger.Info("Deleted DNS record", "record", record.Spec, "zone", zone)
+	return nil
+}
+
+func (p *Provider) HealthCheckReconciler() glbcdns.HealthCheckReconciler {
+	if p.healthCheckReconciler == nil {
+		p.healthCheckReconciler = newHealthCheckReconciler(p.logger)
+	}
+
+	return p.healthCheckReconciler
+}
+
+func newUpdate(zone string) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	return m
+}
+
+// update sends the UPDATE message to the server, signing it if a TSIG key is
+// configured. The changes of a message are applied atomically by the server.
+func (p *Provider) update(m *dns.Msg) error {
+	if len(m.Ns) == 0 {
+		return nil
+	}
+	if len(p.config.TSIGKeyName) > 0 {
+		m.SetTsig(p.config.TSIGKeyName, p.config.TSIGAlgorithm, tsigFudge, time.Now().Unix())
+	}
+
+	r, _, err := p.client.Exchange(m, p.config.Server)
+	if err != nil {
+		return err
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("server responded with %s", dns.RcodeToString[r.Rcode])
+	}
+	return nil
+}
+
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+}
+
+func (k rrsetKey) header() dns.RR {
+	return &dns.ANY{Hdr: dns.RR_Header{Name: k.name, Rrtype: k.rrtype, Class: dns.ClassINET}}
+}
+
+// rrsetsForEndpoints groups the endpoints sharing the same name and type into
+// RRsets holding the union of their targets.
+func rrsetsForEndpoints(endpoints []*v1.Endpoint, zone string) (map[rrsetKey][]dns.RR, error) {
+	grouped := map[rrsetKey][]*v1.Endpoint{}
+	for _, endpoint := range endpoints {
+		name := dns.Fqdn(endpoint.DNSName)
+		if !dns.IsSubDomain(dns.Fqdn(zone), name) {
+			return nil, fmt.Errorf("domain %s does not belong to zone %s", endpoint.DNSName, zone)
+		}
+		rrtype, ok := dns.StringToType[endpoint.RecordType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported record type %s", endpoint.RecordType)
+		}
+		key := rrsetKey{name: name, rrtype: rrtype}
+		grouped[key] = append(grouped[key], endpoint)
+	}
+
+	rrsets := make(map[rrsetKey][]dns.RR, len(grouped))
+	for key, endpoints := range grouped {
+		var rrs []dns.RR
+		for _, target := range glbcdns.PublishedTargets(endpoints) {
+			rr, err := newRR(key, endpoints[0].RecordTTL, target)
+			if err != nil {
+				return nil, err
+			}
+			rrs = append(rrs, rr)
+		}
+		rrsets[key] = rrs
+	}
+	return rrsets, nil
+}
+
+func newRR(key rrsetKey, ttl v1.TTL, target string) (dns.RR, error) {
+	switch key.rrtype {
+	case dns.TypeCNAME:
+		target = dns.Fqdn(target)
+	case dns.TypeTXT:
+		target = strconv.Quote(target)
+	}
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", key.name, ttl, dns.TypeToString[key.rrtype], target))
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %s for record %s: %v", target, key.name, err)
+	}
+	return rr, nil
+}
+
+func sortedKeys(rrsets map[rrsetKey][]dns.RR) []rrsetKey {
+	keys := make([]rrsetKey, 0, len(rrsets))
+	for key := range rrsets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].rrtype < keys[j].rrtype
+	})
+	return keys
+}
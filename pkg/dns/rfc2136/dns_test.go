@@ -0,0 +1,213 @@
+package rfc2136
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const (
+	testZone        = "example.com"
+	testKeyName     = "glbc-key."
+	testKeySecret   = "c2VjcmV0LWtleS1mb3ItdGVzdGluZw=="
+	testOtherSecret = "b3RoZXItc2VjcmV0LWtleQ=="
+)
+
+// authoritativeServer is a minimal in-process DNS server applying the TSIG
+// signed UPDATE messages it receives to an in-memory zone.
+type authoritativeServer struct {
+	mu      sync.Mutex
+	rrs     map[string][]string
+	updates int
+}
+
+func (s *authoritativeServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := new(dns.Msg)
+	m.SetReply(r)
+	if r.IsTsig() == nil || w.TsigStatus() != nil {
+		m.SetRcode(r, dns.RcodeNotAuth)
+	} else if r.Opcode != dns.OpcodeUpdate || r.Question[0].Name != dns.Fqdn(testZone) {
+		m.SetRcode(r, dns.RcodeRefused)
+	} else {
+		s.updates++
+		for _, rr := range r.Ns {
+			h := rr.Header()
+			key := h.Name + "/" + dns.TypeToString[h.Rrtype]
+			switch h.Class {
+			case dns.ClassANY:
+				delete(s.rrs, key)
+			case dns.ClassINET:
+				s.rrs[key] = append(s.rrs[key], rr.String())
+			}
+		}
+	}
+	if tsig := r.IsTsig(); tsig != nil {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, int64(tsig.TimeSigned))
+	}
+	_ = w.WriteMsg(m)
+}
+
+func (s *authoritativeServer) records(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := append([]string{}, s.rrs[key]...)
+	sort.Strings(records)
+	return records
+}
+
+func newTestServer(t *testing.T) (*authoritativeServer, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	handler := &authoritativeServer{rrs: map[string][]string{}}
+	started := make(chan struct{})
+	server := &dns.Server{
+		Listener:          listener,
+		Handler:           handler,
+		TsigSecret:        map[string]string{testKeyName: testKeySecret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default function rejects UPDATE messages
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return handler, listener.Addr().String()
+}
+
+func newTestProvider(t *testing.T, secret string) (*Provider, *authoritativeServer) {
+	server, address := newTestServer(t)
+	provider, err := NewProvider(Config{
+		Server:      address,
+		TSIGKeyName: "glbc-key",
+		TSIGSecret:  secret,
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	return provider, server
+}
+
+func weightedEndpoint(dnsName, target, weight string) *v1.Endpoint {
+	endpoint := &v1.Endpoint{
+		DNSName:       dnsName,
+		RecordType:    string(v1.ARecordType),
+		SetIdentifier: target,
+		Targets:       v1.Targets{target},
+		RecordTTL:     60,
+	}
+	endpoint.SetProviderSpecific(glbcdns.ProviderSpecificWeight, weight)
+	return endpoint
+}
+
+func TestEnsureWeightedRecord(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, server := newTestProvider(t, testKeySecret)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				weightedEndpoint("abc.example.com", "1.1.1.1", "120"),
+				weightedEndpoint("abc.example.com", "2.2.2.2", "60"),
+				weightedEndpoint("abc.example.com", "3.3.3.3", "0"),
+			},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(server.records("abc.example.com./A")).To(gomega.Equal([]string{
+		"abc.example.com.\t60\tIN\tA\t1.1.1.1",
+		"abc.example.com.\t60\tIN\tA\t2.2.2.2",
+	}))
+
+	// Ensuring the record again replaces the existing RRset
+	record.Spec.Endpoints = record.Spec.Endpoints[2:]
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(server.records("abc.example.com./A")).To(gomega.Equal([]string{
+		"abc.example.com.\t60\tIN\tA\t3.3.3.3",
+	}))
+}
+
+func TestEnsureDeletesStaleRecords(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, server := newTestProvider(t, testKeySecret)
+
+	stale := weightedEndpoint("old.example.com", "1.1.1.1", "120")
+	g.Expect(provider.Ensure(&v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{stale}},
+	}, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(server.records("old.example.com./A")).To(gomega.HaveLen(1))
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{weightedEndpoint("new.example.com", "1.1.1.1", "120")},
+		},
+		Status: v1.DNSRecordStatus{
+			Zones: []v1.DNSZoneStatus{{
+				DNSZone:   v1.DNSZone{ID: testZone},
+				Endpoints: []*v1.Endpoint{stale},
+			}},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(server.records("old.example.com./A")).To(gomega.BeEmpty())
+	g.Expect(server.records("new.example.com./A")).To(gomega.HaveLen(1))
+}
+
+func TestDelete(t *testing.T) {
+	g := gomega.NewWithT(t)
+	provider, server := newTestProvider(t, testKeySecret)
+
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				{
+					DNSName:    "www.example.com",
+					RecordType: string(v1.CNAMERecordType),
+					Targets:    v1.Targets{"abc.example.com"},
+					RecordTTL:  60,
+				},
+			},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(server.records("www.example.com./CNAME")).To(gomega.Equal([]string{
+		"www.example.com.\t60\tIN\tCNAME\tabc.example.com.",
+	}))
+
+	g.Expect(provider.Delete(record, v1.DNSZone{ID: testZone})).To(gomega.Succeed())
+	g.Expect(server.records("www.example.com./CNAME")).To(gomega.BeEmpty())
+	g.Expect(server.updates).To(gomega.Equal(2))
+}
+
+func TestEnsureRejected(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// Records outside the zone are rejected before any update is sent
+	provider, server := newTestProvider(t, testKeySecret)
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{weightedEndpoint("abc.example.org", "1.1.1.1", "120")},
+		},
+	}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).To(gomega.MatchError(gomega.ContainSubstring("does not belong to zone")))
+	g.Expect(server.updates).To(gomega.BeZero())
+
+	// Updates signed with the wrong secret are refused by the server
+	provider, server = newTestProvider(t, testOtherSecret)
+	record.Spec.Endpoints = []*v1.Endpoint{weightedEndpoint("abc.example.com", "1.1.1.1", "120")}
+	g.Expect(provider.Ensure(record, v1.DNSZone{ID: testZone})).NotTo(gomega.Succeed())
+	g.Expect(server.updates).To(gomega.BeZero())
+}
@@ -0,0 +1,84 @@
+package rfc2136
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ glbcdns.RecordReader = &Provider{}
+
+// Records returns the A, AAAA, CNAME and TXT records of the zone, using a zone
+// transfer (AXFR), which must be allowed by the server, for the TSIG key if
+// configured. The records sharing the same name and type are returned as a
+// single endpoint.
+func (p *Provider) Records(zone v1.DNSZone) ([]*v1.Endpoint, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zone.ID))
+	transfer := &dns.Transfer{
+		DialTimeout:  defaultTimeout,
+		ReadTimeout:  defaultTimeout,
+		WriteTimeout: defaultTimeout,
+	}
+	if len(p.config.TSIGKeyName) > 0 {
+		m.SetTsig(p.config.TSIGKeyName, p.config.TSIGAlgorithm, tsigFudge, time.Now().Unix())
+		transfer.TsigSecret = map[string]string{p.config.TSIGKeyName: p.config.TSIGSecret}
+	}
+
+	envelopes, err := transfer.In(m, p.config.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer zone %s: %v", zone.ID, err)
+	}
+
+	endpoints := map[rrsetKey]*v1.Endpoint{}
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, fmt.Errorf("failed to transfer zone %s: %v", zone.ID, envelope.Error)
+		}
+		for _, rr := range envelope.RR {
+			var target string
+			switch rr := rr.(type) {
+			case *dns.A:
+				target = rr.A.String()
+			case *dns.AAAA:
+				target = rr.AAAA.String()
+			case *dns.CNAME:
+				target = strings.TrimSuffix(rr.Target, ".")
+			case *dns.TXT:
+				target = strings.Join(rr.Txt, "")
+			default:
+				continue
+			}
+			key := rrsetKey{name: rr.Header().Name, rrtype: rr.Header().Rrtype}
+			endpoint, ok := endpoints[key]
+			if !ok {
+				endpoint = &v1.Endpoint{
+					DNSName:    strings.TrimSuffix(key.name, "."),
+					RecordType: dns.TypeToString[key.rrtype],
+					RecordTTL:  v1.TTL(rr.Header().Ttl),
+				}
+				endpoints[key] = endpoint
+			}
+			endpoint.Targets = append(endpoint.Targets, target)
+		}
+	}
+
+	records := make([]*v1.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		sort.Strings(endpoint.Targets)
+		records = append(records, endpoint)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].DNSName != records[j].DNSName {
+			return records[i].DNSName < records[j].DNSName
+		}
+		return records[i].RecordType < records[j].RecordType
+	})
+	return records, nil
+}
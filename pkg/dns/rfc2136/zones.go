@@ -0,0 +1,24 @@
+package rfc2136
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	glbcdns "github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+var _ glbcdns.ZoneDiscoverer = &Provider{}
+
+// GetZone returns the zone with the given ID, which is the zone apex.
+func (p *Provider) GetZone(id string) (v1.DNSZone, error) {
+	return v1.DNSZone{
+		ID:      id,
+		DNSName: strings.TrimSuffix(id, "."),
+	}, nil
+}
+
+// DiscoverZones is not supported, as plain DNS has no notion of tags.
+func (p *Provider) DiscoverZones(_ map[string]string) ([]v1.DNSZone, error) {
+	return nil, fmt.Errorf("zone discovery from tags is not supported by RFC 2136 servers")
+}
@@ -32,6 +32,7 @@ const defaultMetricsEndpoint = "/metrics"
 type Server struct {
 	httpServer http.Server
 	listener   net.Listener
+	mux        *http.ServeMux
 }
 
 func NewServer(port int) (*Server, error) {
@@ -53,12 +54,19 @@ func NewServer(port int) (*Server, error) {
 
 	return &Server{
 		listener: listener,
+		mux:      mux,
 		httpServer: http.Server{
 			Handler: mux,
 		},
 	}, nil
 }
 
+// Handle registers an additional handler, e.g. for debugging, served alongside
+// the metrics.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
 func (s *Server) Start() (err error) {
 	if s.listener == nil {
 		log.Logger.Info("Serving metrics is disabled")
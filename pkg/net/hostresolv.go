@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	gonet "net"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 
 type HostResolver interface {
 	LookupIPAddr(ctx context.Context, host string) ([]HostAddress, error)
+	LookupTXT(ctx context.Context, host string) ([]string, error)
 }
 
 type HostAddress struct {
@@ -66,8 +68,36 @@ func (r *ConfigMapHostResolver) LookupIPAddr(ctx context.Context, host string) (
 	return result, nil
 }
 
+// LookupTXT returns the TXT records of the host, stored in the ConfigMap as a
+// JSON list of strings.
+func (r *ConfigMapHostResolver) LookupTXT(ctx context.Context, host string) ([]string, error) {
+	configMap, err := r.Client.CoreV1().ConfigMaps(r.Namespace).Get(ctx, r.Name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	txtValue, ok := configMap.Data[host]
+	if !ok {
+		return nil, &gonet.DNSError{Err: fmt.Sprintf("host not found in ConfigMap %s/%s", r.Name, r.Namespace)}
+	}
+
+	var txt []string
+	if err := json.Unmarshal([]byte(txtValue), &txt); err != nil {
+		return nil, err
+	}
+	return txt, nil
+}
+
+// ErrNoRecords is returned when none of the name servers has records of the
+// requested type for the host.
+var ErrNoRecords = errors.New("no records found for host")
+
 type DefaultHostResolver struct {
 	Client dns.Client
+	// Nameservers are the addresses, i.e., host:port, of the servers queried,
+	// instead of the ones configured in /etc/resolv.conf.
+	// +optional
+	Nameservers []string
 }
 
 func NewDefaultHostResolver() *DefaultHostResolver {
@@ -76,17 +106,78 @@ func NewDefaultHostResolver() *DefaultHostResolver {
 	}
 }
 
+// LookupIPAddr returns the IPv4 and IPv6 addresses of the host, resolved from
+// its A and AAAA records.
 func (hr *DefaultHostResolver) LookupIPAddr(ctx context.Context, host string) ([]HostAddress, error) {
-	cfg, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	var results []HostAddress
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		answer, err := hr.exchange(ctx, host, qtype)
+		if errors.Is(err, ErrNoRecords) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rr := range answer {
+			switch rr := rr.(type) {
+			case *dns.A:
+				results = append(results, HostAddress{
+					Host: host,
+					IP:   rr.A,
+					TTL:  time.Duration(rr.Hdr.Ttl) * time.Second,
+				})
+			case *dns.AAAA:
+				results = append(results, HostAddress{
+					Host: host,
+					IP:   rr.AAAA,
+					TTL:  time.Duration(rr.Hdr.Ttl) * time.Second,
+				})
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoRecords
+	}
+	return results, nil
+}
+
+func (hr *DefaultHostResolver) LookupTXT(ctx context.Context, host string) ([]string, error) {
+	answer, err := hr.exchange(ctx, host, dns.TypeTXT)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, server := range cfg.Servers {
+	var results []string
+	for _, rr := range answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			results = append(results, strings.Join(txt.Txt, ""))
+		}
+	}
+
+	return results, nil
+}
+
+// exchange returns the answer of the first nameserver with records of the
+// given type for the host.
+func (hr *DefaultHostResolver) exchange(ctx context.Context, host string, qtype uint16) ([]dns.RR, error) {
+	nameservers := hr.Nameservers
+	if len(nameservers) == 0 {
+		cfg, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range cfg.Servers {
+			nameservers = append(nameservers, fmt.Sprintf("%s:53", server))
+		}
+	}
+
+	for _, server := range nameservers {
 		m := dns.Msg{}
-		m.SetQuestion(fmt.Sprintf("%s.", host), dns.TypeA)
+		m.SetQuestion(dns.Fqdn(host), qtype)
 
-		r, _, err := hr.Client.ExchangeContext(ctx, &m, fmt.Sprintf("%s:53", server))
+		r, _, err := hr.Client.ExchangeContext(ctx, &m, server)
 		if err != nil {
 			return nil, err
 		}
@@ -95,21 +186,10 @@ func (hr *DefaultHostResolver) LookupIPAddr(ctx context.Context, host string) ([
 			continue
 		}
 
-		results := make([]HostAddress, 0, len(r.Answer))
-		for _, answer := range r.Answer {
-			if a, ok := answer.(*dns.A); ok {
-				results = append(results, HostAddress{
-					Host: host,
-					IP:   a.A,
-					TTL:  time.Duration(a.Hdr.Ttl) * time.Second,
-				})
-			}
-		}
-
-		return results, nil
+		return r.Answer, nil
 	}
 
-	return nil, errors.New("no records found for host")
+	return nil, ErrNoRecords
 }
 
 type SafeHostResolver struct {
@@ -129,3 +209,9 @@ func (r *SafeHostResolver) LookupIPAddr(ctx context.Context, host string) ([]Hos
 	defer r.mu.Unlock()
 	return r.HostResolver.LookupIPAddr(ctx, host)
 }
+
+func (r *SafeHostResolver) LookupTXT(ctx context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.HostResolver.LookupTXT(ctx, host)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/kcp-dev/logicalcluster"
 
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+	"github.com/kuadrant/kcp-glbc/pkg/util/workloadMigration"
 )
 
 const controllerName = "kcp-glbc-deployment"
@@ -27,6 +28,10 @@ func NewController(config *ControllerConfig) (*Controller, error) {
 		Controller:            reconciler.NewController(controllerName, queue),
 		coreClient:            config.DeploymentClient,
 		sharedInformerFactory: config.SharedInformerFactory,
+		drainStrategy:         workloadMigration.DefaultDrainStrategy,
+	}
+	if config.DrainStrategy != nil {
+		c.drainStrategy = *config.DrainStrategy
 	}
 	c.Process = c.process
 
@@ -46,6 +51,9 @@ func NewController(config *ControllerConfig) (*Controller, error) {
 type ControllerConfig struct {
 	DeploymentClient      kubernetes.ClusterInterface
 	SharedInformerFactory informers.SharedInformerFactory
+	// DrainStrategy delays the release of the Deployments migrated away from a
+	// cluster, defaults to workloadMigration.DefaultDrainStrategy
+	DrainStrategy *workloadMigration.DrainStrategy
 }
 
 type Controller struct {
@@ -55,6 +63,7 @@ type Controller struct {
 	indexer               cache.Indexer
 	deploymentLister      appsv1listers.DeploymentLister
 	serviceLister         corev1listers.ServiceLister
+	drainStrategy         workloadMigration.DrainStrategy
 }
 
 func (c *Controller) process(ctx context.Context, key string) error {
@@ -3,10 +3,16 @@ package dns
 import (
 	"context"
 	"fmt"
-	"os"
+	"net/http"
+	"sync"
+	"time"
 
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
@@ -18,6 +24,10 @@ import (
 	kuadrantv1lister "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/listers/kuadrant/v1"
 	"github.com/kuadrant/kcp-glbc/pkg/dns"
 	awsdns "github.com/kuadrant/kcp-glbc/pkg/dns/aws"
+	azuredns "github.com/kuadrant/kcp-glbc/pkg/dns/azure"
+	googledns "github.com/kuadrant/kcp-glbc/pkg/dns/google"
+	"github.com/kuadrant/kcp-glbc/pkg/dns/inmemory"
+	rfc2136dns "github.com/kuadrant/kcp-glbc/pkg/dns/rfc2136"
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
 )
 
@@ -29,29 +39,77 @@ func NewController(config *ControllerConfig) (*Controller, error) {
 	c := &Controller{
 		Controller:            reconciler.NewController(controllerName, queue),
 		dnsRecordClient:       config.DnsRecordClient,
+		kubeClient:            config.KubeClient,
 		sharedInformerFactory: config.SharedInformerFactory,
+		driftCheckInterval:    config.DriftCheckInterval,
+		drifts:                map[string]map[string]string{},
 	}
 	c.Process = c.process
 
-	dnsProvider, err := c.createDNSProvider(config.DNSProvider)
+	dnsProvider, err := c.createDNSProvider(config)
 	if err != nil {
 		return nil, err
 	}
 	c.dnsProvider = dnsProvider
-
-	var dnsZones []v1.DNSZone
-	zoneID, zoneIDSet := os.LookupEnv("AWS_DNS_PUBLIC_ZONE_ID")
-	if zoneIDSet {
-		dnsZone := &v1.DNSZone{
-			ID: zoneID,
+	if tracker, ok := dnsProvider.(dns.PropagationTracker); ok && !config.DryRun {
+		c.propagationTracker = tracker
+	}
+	// The orphaned health checks are collected from the provider, as the dry
+	// run provider does not create health checks
+	if lister, ok := dnsProvider.HealthCheckReconciler().(dns.HealthCheckLister); ok && config.HealthCheckGCInterval > 0 {
+		c.healthCheckLister = lister
+		c.healthCheckGCInterval = config.HealthCheckGCInterval
+		c.healthCheckGCGracePeriod = config.HealthCheckGCGracePeriod
+		// Without an owner, the health checks of other instances sharing the
+		// provider account cannot be told apart, so they are not deleted
+		c.healthCheckGCDryRun = config.HealthCheckGCDryRun || config.DryRun || config.DNSOwnerID == ""
+		if config.DNSOwnerID == "" && !config.HealthCheckGCDryRun {
+			c.Logger.Info("No DNS owner ID set (GLBC_DNS_OWNER_ID), the orphaned health checks are logged but not deleted")
 		}
-		dnsZones = append(dnsZones, *dnsZone)
-		c.Logger.Info("Using AWS DNS zone", "id", zoneID)
-	} else {
-		c.Logger.Info("No AWS DNS zone id set (AWS_DNS_PUBLIC_ZONE_ID), no DNS records will be created!")
+		c.orphanedHealthChecks = map[string]time.Time{}
+	}
+
+	dnsZones, err := discoverZones(dnsProvider, config.DNSZoneIDs, config.DNSZoneTags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover DNS zones: %v", err)
+	}
+	if len(dnsZones) == 0 {
+		c.Logger.Info("No DNS zone set (GLBC_DNS_ZONE_ID or GLBC_DNS_ZONE_TAGS), no DNS records will be created!")
+	}
+	for _, zone := range dnsZones {
+		c.Logger.Info("Using DNS zone", "id", zone.ID, "domain", zone.DNSName)
 	}
 	c.dnsZones = dnsZones
 
+	if config.DryRun {
+		c.Logger.Info("Running in dry run mode, the DNS record changes are planned but not applied")
+		c.dnsProvider = dns.NewDryRunProvider(c.dnsProvider, c.Logger.WithName("dry-run"))
+		c.dryRun, _ = dns.AsDryRunProvider(c.dnsProvider)
+	}
+
+	if config.DNSOwnerID != "" {
+		registry, err := dns.NewTXTRegistry(c.dnsProvider, config.DNSOwnerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DNS ownership registry: %v", err)
+		}
+		c.Logger.Info("Recording the ownership of DNS records", "owner", config.DNSOwnerID)
+		c.dnsProvider = registry
+		c.ownerID = config.DNSOwnerID
+	}
+
+	if config.HealthProbe {
+		c.prober = dns.NewProber(config.HealthProbeInterval, c.Logger.WithName("prober"))
+		c.prober.OnChange = c.enqueueRecordsOf
+		c.Logger.Info("Probing the health of the DNS record endpoints", "interval", config.HealthProbeInterval)
+		c.dnsProvider = dns.NewProbingProvider(c.dnsProvider, c.prober)
+	}
+
+	if reader, ok := c.dnsProvider.(dns.RecordReader); ok {
+		c.recordReader = reader
+	} else if c.driftCheckInterval > 0 {
+		c.Logger.Info("The DNS provider does not support reading records, the published records are not checked for drift")
+	}
+
 	c.sharedInformerFactory.Kuadrant().V1().DNSRecords().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) { c.Enqueue(obj) },
 		UpdateFunc: func(old, obj interface{}) {
@@ -65,6 +123,39 @@ func NewController(config *ControllerConfig) (*Controller, error) {
 	c.indexer = c.sharedInformerFactory.Kuadrant().V1().DNSRecords().Informer().GetIndexer()
 	c.lister = c.sharedInformerFactory.Kuadrant().V1().DNSRecords().Lister()
 
+	if config.KubeSharedInformerFactory != nil {
+		// The policies and records are looked up by workspace and namespace
+		for _, informer := range []cache.SharedIndexInformer{
+			c.sharedInformerFactory.Kuadrant().V1().HealthCheckPolicies().Informer(),
+			c.sharedInformerFactory.Kuadrant().V1().DNSRecords().Informer(),
+		} {
+			if err := reconciler.AddClusterNamespaceIndex(informer); err != nil {
+				return nil, err
+			}
+		}
+		c.sharedInformerFactory.Kuadrant().V1().HealthCheckPolicies().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { c.enqueueRecordsOfNamespace(obj) },
+			UpdateFunc: func(old, obj interface{}) {
+				if old.(*v1.HealthCheckPolicy).Generation != obj.(*v1.HealthCheckPolicy).Generation {
+					c.enqueueRecordsOfNamespace(obj)
+				}
+			},
+			DeleteFunc: func(obj interface{}) { c.enqueueRecordsOfNamespace(obj) },
+		})
+		// The labels of an Ingress select its HealthCheckPolicies, and its
+		// DNSRecord has the same key
+		config.KubeSharedInformerFactory.Networking().V1().Ingresses().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				if !equality.Semantic.DeepEqual(old.(*networkingv1.Ingress).Labels, obj.(*networkingv1.Ingress).Labels) {
+					c.Enqueue(obj)
+				}
+			},
+		})
+
+		c.healthCheckPolicyIndexer = c.sharedInformerFactory.Kuadrant().V1().HealthCheckPolicies().Informer().GetIndexer()
+		c.ingressIndexer = config.KubeSharedInformerFactory.Networking().V1().Ingresses().Informer().GetIndexer()
+	}
+
 	return c, nil
 }
 
@@ -72,16 +163,130 @@ type ControllerConfig struct {
 	DnsRecordClient       kuadrantv1.ClusterInterface
 	SharedInformerFactory externalversions.SharedInformerFactory
 	DNSProvider           string
+	Google                googledns.Config
+	Azure                 azuredns.Config
+	RFC2136               rfc2136dns.Config
+
+	// DNSZoneIDs are the IDs of the zones where records are published.
+	DNSZoneIDs []string
+	// DNSZoneTags are the tags of the zones where records are published.
+	DNSZoneTags map[string]string
+	// InMemoryDNSAddress is the address the in-memory provider records are
+	// served on, if not empty.
+	InMemoryDNSAddress string
+	// DriftCheckInterval is the interval at which the published records are
+	// compared with the DNSRecords, and published again if they differ. The
+	// records are not checked if 0.
+	DriftCheckInterval time.Duration
+	// DNSOwnerID identifies this instance in the TXT records holding the
+	// ownership of the records it publishes. Ownership is not recorded if empty.
+	DNSOwnerID string
+	// KubeClient is used to record events about the DNSRecords, if not nil.
+	KubeClient kubernetes.ClusterInterface
+	// KubeSharedInformerFactory provides the Ingresses the HealthCheckPolicies
+	// are resolved for. The policies are ignored if nil.
+	KubeSharedInformerFactory informers.SharedInformerFactory
+	// DryRun plans the changes to the records, and reports them in the
+	// DNSRecord status, events and logs, without applying them.
+	DryRun bool
+	// HealthProbe probes the health of the endpoints from within GLBC, rather
+	// than by the DNS provider, and does not route to the unhealthy ones.
+	HealthProbe bool
+	// HealthProbeInterval is the interval between the probes of an endpoint.
+	HealthProbeInterval time.Duration
+	// HealthCheckGCInterval is the interval at which the health checks created
+	// by GLBC, and no longer referenced by any DNSRecord, are collected. They
+	// are not collected if 0.
+	HealthCheckGCInterval time.Duration
+	// HealthCheckGCGracePeriod is the time a health check must remain
+	// unreferenced before being deleted.
+	HealthCheckGCGracePeriod time.Duration
+	// HealthCheckGCDryRun logs the orphaned health checks without deleting
+	// them.
+	HealthCheckGCDryRun bool
 }
 
 type Controller struct {
 	*reconciler.Controller
 	sharedInformerFactory externalversions.SharedInformerFactory
 	dnsRecordClient       kuadrantv1.ClusterInterface
+	kubeClient            kubernetes.ClusterInterface
 	indexer               cache.Indexer
 	lister                kuadrantv1lister.DNSRecordLister
 	dnsProvider           dns.Provider
 	dnsZones              []v1.DNSZone
+	// ownerID is the owner of the records in the ownership registry, if any
+	ownerID            string
+	dnsServer          *inmemory.Server
+	recordReader       dns.RecordReader
+	driftCheckInterval time.Duration
+	dryRun             *dns.DryRunProvider
+	propagationTracker dns.PropagationTracker
+	prober             *dns.Prober
+	healthCheckLister  dns.HealthCheckLister
+	// healthCheckPolicyIndexer and ingressIndexer resolve the HealthCheckPolicy
+	// of the DNSRecords, if set
+	healthCheckPolicyIndexer cache.Indexer
+	ingressIndexer           cache.Indexer
+	// orphanedHealthChecks are the times the health checks were first found
+	// unreferenced, by ID
+	orphanedHealthChecks     map[string]time.Time
+	healthCheckGCInterval    time.Duration
+	healthCheckGCGracePeriod time.Duration
+	healthCheckGCDryRun      bool
+	// drifts are the drifts of the records published to each zone, by DNSRecord
+	// key and zone ID, until the DNSRecord is reconciled
+	drifts     map[string]map[string]string
+	driftsLock sync.Mutex
+}
+
+// Start starts the controller, checks the published records for drift, probes
+// the health of the endpoints, collects the orphaned health checks, and serves
+// the in-memory provider records, if configured, until the context is done.
+func (c *Controller) Start(ctx context.Context, numThreads int) {
+	if c.recordReader != nil && c.driftCheckInterval > 0 {
+		go wait.UntilWithContext(ctx, c.checkDrift, c.driftCheckInterval)
+	}
+	if c.prober != nil {
+		go c.prober.Start(ctx)
+		go func() {
+			// The probes would all appear stale until the DNSRecords are listed
+			if cache.WaitForCacheSync(ctx.Done(), c.sharedInformerFactory.Kuadrant().V1().DNSRecords().Informer().HasSynced) {
+				wait.UntilWithContext(ctx, c.retainProbes, c.prober.Interval())
+			}
+		}()
+	}
+	if c.healthCheckLister != nil {
+		go func() {
+			// The health checks would all appear orphaned until the DNSRecords
+			// are listed
+			if cache.WaitForCacheSync(ctx.Done(), c.sharedInformerFactory.Kuadrant().V1().DNSRecords().Informer().HasSynced) {
+				wait.UntilWithContext(ctx, c.collectHealthChecks, c.healthCheckGCInterval)
+			}
+		}()
+	}
+	if c.dnsServer != nil {
+		go func() {
+			if err := c.dnsServer.Start(); err != nil {
+				c.Logger.Error(err, "Failed serving in-memory DNS records")
+			}
+		}()
+		defer func() {
+			if err := c.dnsServer.Shutdown(); err != nil {
+				c.Logger.Error(err, "Failed stopping in-memory DNS server")
+			}
+		}()
+	}
+	c.Controller.Start(ctx, numThreads)
+}
+
+// PlanHandler returns the handler serving the planned DNS record changes, or
+// nil if the controller does not run in dry run mode.
+func (c *Controller) PlanHandler() http.Handler {
+	if c.dryRun == nil {
+		return nil
+	}
+	return c.dryRun
 }
 
 func (c *Controller) process(ctx context.Context, key string) error {
@@ -91,6 +296,10 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	}
 
 	if !exists {
+		c.forgetDrifts(key)
+		if c.dryRun != nil {
+			c.dryRun.Forget(key)
+		}
 		return nil
 	}
 
@@ -98,6 +307,13 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	target := current.DeepCopy()
 
 	if err = c.reconcile(ctx, target); err != nil {
+		// Retry after the delay suggested by the provider, rather than after the
+		// delay of the queue rate limiter, which would count towards the retries
+		if retryable, ok := dns.AsRetryableError(err); ok {
+			c.Logger.Info("Retrying DNSRecord reconciliation after transient provider error", "key", key, "retryAfter", retryable.RetryAfter, "error", err.Error())
+			c.Queue.AddAfter(key, retryable.RetryAfter)
+			return nil
+		}
 		return err
 	}
 
@@ -109,12 +325,39 @@ func (c *Controller) process(ctx context.Context, key string) error {
 	return nil
 }
 
-func (c *Controller) createDNSProvider(dnsProviderName string) (dns.Provider, error) {
+// requeueOnRetryableError queues the DNSRecord again after the delay suggested
+// by the provider, if the error is retryable.
+func (c *Controller) requeueOnRetryableError(record *v1.DNSRecord, err error) {
+	retryable, ok := dns.AsRetryableError(err)
+	if !ok {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		c.Logger.Error(err, "Failed to get DNSRecord key")
+		return
+	}
+	c.Queue.AddAfter(key, retryable.RetryAfter)
+}
+
+func (c *Controller) createDNSProvider(config *ControllerConfig) (dns.Provider, error) {
 	var dnsProvider dns.Provider
 	var dnsError error
-	switch dnsProviderName {
+	switch config.DNSProvider {
 	case "aws":
 		dnsProvider, dnsError = newAWSDNSProvider()
+	case "google":
+		dnsProvider, dnsError = newGoogleDNSProvider(config.Google)
+	case "azure":
+		dnsProvider, dnsError = newAzureDNSProvider(config.Azure)
+	case "rfc2136":
+		dnsProvider, dnsError = newRFC2136DNSProvider(config.RFC2136)
+	case "inmemory":
+		provider := inmemory.NewProvider()
+		if config.InMemoryDNSAddress != "" {
+			c.dnsServer, dnsError = inmemory.NewServer(provider, config.InMemoryDNSAddress)
+		}
+		dnsProvider = provider
 	default:
 		dnsProvider = &dns.FakeProvider{}
 	}
@@ -131,3 +374,30 @@ func newAWSDNSProvider() (dns.Provider, error) {
 
 	return dnsProvider, nil
 }
+
+func newGoogleDNSProvider(config googledns.Config) (dns.Provider, error) {
+	provider, err := googledns.NewProvider(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Google DNS manager: %v", err)
+	}
+
+	return provider, nil
+}
+
+func newAzureDNSProvider(config azuredns.Config) (dns.Provider, error) {
+	provider, err := azuredns.NewProvider(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure DNS manager: %v", err)
+	}
+
+	return provider, nil
+}
+
+func newRFC2136DNSProvider(config rfc2136dns.Config) (dns.Provider, error) {
+	provider, err := rfc2136dns.NewProvider(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RFC 2136 DNS manager: %v", err)
+	}
+
+	return provider, nil
+}
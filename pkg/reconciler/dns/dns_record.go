@@ -3,7 +3,6 @@ package dns
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -16,6 +15,7 @@ import (
 	"github.com/kcp-dev/logicalcluster"
 
 	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
 	"github.com/kuadrant/kcp-glbc/pkg/util/metadata"
 	"github.com/kuadrant/kcp-glbc/pkg/util/slice"
 )
@@ -54,17 +54,31 @@ func (c *Controller) reconcile(ctx context.Context, dnsRecord *v1.DNSRecord) err
 		dnsRecord.Finalizers = append(dnsRecord.Finalizers, DNSRecordFinalizer)
 	}
 
-	statuses := c.publishRecordToZones(c.dnsZones, dnsRecord)
-	if !dnsZoneStatusSlicesEqual(statuses, dnsRecord.Status.Zones) || dnsRecord.Status.ObservedGeneration != dnsRecord.Generation {
+	// The configuration is resolved once, for both the status and the health
+	// checks to agree on it
+	config, configErr := c.healthChecksConfig(dnsRecord)
+	healthChecks := c.healthCheckStatuses(ctx, dnsRecord)
+	statuses := c.publishRecordToZones(ctx, c.dnsZones, dnsRecord)
+	statuses = c.reportFailover(healthChecks, statuses)
+	statuses = c.reportProbedHealth(dnsRecord, statuses)
+	statuses = reportHealthChecksConfig(config, configErr, statuses)
+	if !dnsZoneStatusSlicesEqual(statuses, dnsRecord.Status.Zones) || dnsRecord.Status.ObservedGeneration != dnsRecord.Generation ||
+		!healthStatusesEqual(healthChecks, dnsRecord.Status.HealthChecks) {
+		previous := dnsRecord.Status.Zones
 		dnsRecord.Status.Zones = statuses
 		dnsRecord.Status.ObservedGeneration = dnsRecord.Generation
+		dnsRecord.Status.HealthChecks = healthChecks
 		_, err := c.dnsRecordClient.Cluster(logicalcluster.From(dnsRecord)).KuadrantV1().DNSRecords(dnsRecord.Namespace).UpdateStatus(ctx, dnsRecord, metav1.UpdateOptions{})
 		if err != nil {
 			return err
 		}
+		c.deleteReplacedHealthChecks(ctx, dnsRecord, previous)
 	}
+	c.requeueUnpropagated(dnsRecord)
+	c.requeueFailover(dnsRecord)
+	c.requeueHealthChecks(dnsRecord)
 
-	if err := c.ReconcileHealthChecks(ctx, dnsRecord); err != nil {
+	if err := c.ReconcileHealthChecks(ctx, dnsRecord, config, configErr); err != nil {
 		c.Logger.Error(err, "Failed to reconcile health check for DNSRecord", "record", dnsRecord)
 		return err
 	}
@@ -72,16 +86,48 @@ func (c *Controller) reconcile(ctx context.Context, dnsRecord *v1.DNSRecord) err
 	return nil
 }
 
-func (c *Controller) publishRecordToZones(zones []v1.DNSZone, record *v1.DNSRecord) []v1.DNSZoneStatus {
+func (c *Controller) publishRecordToZones(ctx context.Context, zones []v1.DNSZone, record *v1.DNSRecord) []v1.DNSZoneStatus {
+	zones, zoneRecords := recordsForZones(zones, record)
 	var statuses []v1.DNSZoneStatus
 	for i := range zones {
 		zone := zones[i]
+		zoneRecord := zoneRecords[i]
+		zoneRecord.Spec.Endpoints = c.withProbedHealth(zoneRecord.Spec.Endpoints)
+
+		drift, checked := c.takeDrift(record, zone.ID)
 
 		// Only publish the record if the DNSRecord has been modified
-		// (which would mean the target could have changed) or its
-		// status does not indicate that it has already been published.
-		if record.Generation == record.Status.ObservedGeneration && recordIsAlreadyPublishedToZone(record, &zone) {
+		// (which would mean the target could have changed), its
+		// status does not indicate that it has already been published,
+		// the published records have drifted, or the health of the probed
+		// endpoints has changed.
+		if record.Generation == record.Status.ObservedGeneration && recordIsAlreadyPublishedToZone(record, &zone) && drift == "" &&
+			!c.probedHealthChanged(record, zone, zoneRecord.Spec.Endpoints) {
 			c.Logger.Info("Skipping zone to which the DNS record is already published", "record", record, "zone", zone)
+			var conditions []v1.DNSZoneCondition
+			if checked {
+				conditions = append(conditions, v1.DNSZoneCondition{
+					Type:    v1.DNSRecordDriftedConditionType,
+					Status:  string(ConditionFalse),
+					Reason:  "InSync",
+					Message: "The published records match the DNS record",
+				})
+			}
+			if condition, ok := c.checkPropagation(zoneRecord, zone); ok {
+				conditions = append(conditions, condition)
+			}
+			if len(conditions) > 0 {
+				statuses = append(statuses, v1.DNSZoneStatus{
+					DNSZone:    zone,
+					Conditions: conditions,
+					Endpoints:  zoneRecord.Spec.Endpoints,
+				})
+			}
+			continue
+		}
+
+		if c.dryRun != nil {
+			statuses = append(statuses, c.planRecordInZone(ctx, record, zoneRecord, zone))
 			continue
 		}
 
@@ -94,37 +140,78 @@ func (c *Controller) publishRecordToZones(zones []v1.DNSZone, record *v1.DNSReco
 		if recordIsAlreadyPublishedToZone(record, &zone) {
 			c.Logger.Info("replacing DNS record", "record", record, "zone", zone)
 
-			if err := c.dnsProvider.Ensure(record, zone); err != nil {
-				c.Logger.Error(err, "Failed to replace DNS record in zone", "record", record.Spec, "zone", zone)
+			if err := c.dnsProvider.Ensure(zoneRecord, zone); err != nil {
+				c.Logger.Error(err, "Failed to replace DNS record in zone", "record", zoneRecord.Spec, "zone", zone)
 				condition.Status = string(ConditionTrue)
-				condition.Reason = "ProviderError"
+				condition.Reason = providerErrorReason(err)
 				condition.Message = fmt.Sprintf("The DNS provider failed to replace the record: %v", err)
+				c.requeueOnRetryableError(record, err)
 			} else {
-				c.Logger.Info("Replaced DNS record in zone", "record", record.Spec, "zone", zone)
+				c.Logger.Info("Replaced DNS record in zone", "record", zoneRecord.Spec, "zone", zone)
 				condition.Status = string(ConditionFalse)
 				condition.Reason = "ProviderSuccess"
 				condition.Message = "The DNS provider succeeded in replacing the record"
 			}
 		} else {
-			if err := c.dnsProvider.Ensure(record, zone); err != nil {
-				c.Logger.Error(err, "Failed to publish DNS record to zone", "record", record.Spec, "zone", zone)
+			if err := c.dnsProvider.Ensure(zoneRecord, zone); err != nil {
+				c.Logger.Error(err, "Failed to publish DNS record to zone", "record", zoneRecord.Spec, "zone", zone)
 				condition.Status = string(ConditionTrue)
-				condition.Reason = "ProviderError"
+				condition.Reason = providerErrorReason(err)
 				condition.Message = fmt.Sprintf("The DNS provider failed to ensure the record: %v", err)
+				c.requeueOnRetryableError(record, err)
 			} else {
-				c.Logger.Info("Published DNS record to zone", "record", record.Spec, "zone", zone)
+				c.Logger.Info("Published DNS record to zone", "record", zoneRecord.Spec, "zone", zone)
 				condition.Status = string(ConditionFalse)
 				condition.Reason = "ProviderSuccess"
 				condition.Message = "The DNS provider succeeded in ensuring the record"
 			}
 		}
+		conditions := []v1.DNSZoneCondition{condition}
+		if c.propagationTracker != nil && condition.Status == string(ConditionFalse) {
+			conditions = append(conditions, pendingPropagationCondition())
+		}
+		if drift != "" {
+			conditions = append(conditions, v1.DNSZoneCondition{
+				Type:    v1.DNSRecordDriftedConditionType,
+				Status:  string(ConditionTrue),
+				Reason:  "RecordsDrifted",
+				Message: fmt.Sprintf("The published records drifted from the DNS record: %s", drift),
+			})
+		}
 		statuses = append(statuses, v1.DNSZoneStatus{
 			DNSZone:    zone,
-			Conditions: []v1.DNSZoneCondition{condition},
-			Endpoints:  record.Spec.Endpoints,
+			Conditions: conditions,
+			Endpoints:  zoneRecord.Spec.Endpoints,
 		})
 	}
-	return mergeStatuses(zones, record.Status.DeepCopy().Zones, statuses)
+	return mergeStatuses(zones, c.unpublishRecordFromZones(ctx, zones, record), statuses)
+}
+
+// unpublishRecordFromZones deletes the record from the zones it is published
+// to, but that it no longer belongs to, and returns the statuses of the zones
+// the record is still published to.
+func (c *Controller) unpublishRecordFromZones(ctx context.Context, zones []v1.DNSZone, record *v1.DNSRecord) []v1.DNSZoneStatus {
+	var statuses []v1.DNSZoneStatus
+	for _, status := range record.Status.DeepCopy().Zones {
+		if containsZone(zones, status.DNSZone) {
+			statuses = append(statuses, status)
+			continue
+		}
+		if recordIsAlreadyPublishedToZone(record, &status.DNSZone) {
+			if c.dryRun != nil {
+				statuses = append(statuses, c.planRecordDeletionFromZone(ctx, record, status))
+				continue
+			}
+			if err := c.dnsProvider.Delete(recordWithEndpoints(record, status.Endpoints), status.DNSZone); err != nil {
+				c.Logger.Error(err, "Failed to delete DNS record from zone", "record", record, "zone", status.DNSZone)
+				c.requeueOnRetryableError(record, err)
+				statuses = append(statuses, status)
+				continue
+			}
+			c.Logger.Info("Deleted DNS record from zone", "record", record, "zone", status.DNSZone)
+		}
+	}
+	return statuses
 }
 
 func (c *Controller) deleteRecord(record *v1.DNSRecord) error {
@@ -136,13 +223,31 @@ func (c *Controller) deleteRecord(record *v1.DNSRecord) error {
 		if !recordIsAlreadyPublishedToZone(record, &zone) {
 			continue
 		}
-		err := c.dnsProvider.Delete(record, zone)
+		// Only delete the endpoints that were published to the zone
+		err := c.dnsProvider.Delete(recordWithEndpoints(record, record.Status.Zones[i].Endpoints), zone)
 		if err != nil {
 			errs = append(errs, err)
 		} else {
 			c.Logger.Info("Deleted DNSRecord from DNS provider", "record", record.Spec, "zone", zone)
 		}
 	}
+	// The records may have been published to zones missing from the status,
+	// e.g. if it failed to be updated. As the ownership registry only deletes
+	// the records owned by the DNSRecord, they are deleted from the zones of
+	// the spec as well.
+	if c.ownerID != "" {
+		zones, zoneRecords := recordsForZones(c.dnsZones, record)
+		for i, zone := range zones {
+			if hasZoneStatus(record, zone.ID) {
+				continue
+			}
+			if err := c.dnsProvider.Delete(zoneRecords[i], zone); err != nil {
+				errs = append(errs, err)
+			} else {
+				c.Logger.Info("Deleted DNSRecord from DNS provider", "record", record.Spec, "zone", zone)
+			}
+		}
+	}
 	if len(errs) == 0 {
 		if slice.ContainsString(record.Finalizers, DNSRecordFinalizer) {
 			record.Finalizers = slice.RemoveString(record.Finalizers, DNSRecordFinalizer)
@@ -151,12 +256,21 @@ func (c *Controller) deleteRecord(record *v1.DNSRecord) error {
 	return utilerrors.NewAggregate(errs)
 }
 
+// providerErrorReason returns the reason of the Failed condition reporting the
+// provider error.
+func providerErrorReason(err error) string {
+	if _, ok := dns.AsRetryableError(err); ok {
+		return "ProviderThrottled"
+	}
+	return "ProviderError"
+}
+
 // recordIsAlreadyPublishedToZone returns a Boolean value indicating whether the
 // given DNSRecord is already published to the given zone, as determined from
 // the DNSRecord's status conditions.
 func recordIsAlreadyPublishedToZone(record *v1.DNSRecord, zoneToPublish *v1.DNSZone) bool {
 	for _, zoneInStatus := range record.Status.Zones {
-		if !reflect.DeepEqual(&zoneInStatus.DNSZone, zoneToPublish) {
+		if zoneInStatus.DNSZone.ID != zoneToPublish.ID {
 			continue
 		}
 
@@ -177,8 +291,9 @@ func mergeStatuses(zones []v1.DNSZone, statuses, updates []v1.DNSZoneStatus) []v
 	for i, update := range updates {
 		add := true
 		for j, status := range statuses {
-			if cmp.Equal(status.DNSZone, update.DNSZone) {
+			if status.DNSZone.ID == update.DNSZone.ID {
 				add = false
+				statuses[j].DNSZone = update.DNSZone
 				statuses[j].Conditions = mergeConditions(status.Conditions, update.Conditions)
 				statuses[j].Endpoints = update.Endpoints
 			}
@@ -194,8 +309,8 @@ func mergeStatuses(zones []v1.DNSZone, statuses, updates []v1.DNSZoneStatus) []v
 var clock utilclock.Clock = utilclock.RealClock{}
 
 // mergeConditions adds or updates matching conditions, and updates
-// the transition time if details of a condition have changed. Returns
-// the updated condition array.
+// the transition time if the status or reason of a condition have changed.
+// Returns the updated condition array.
 func mergeConditions(conditions, updates []v1.DNSZoneCondition) []v1.DNSZoneCondition {
 	now := metav1.NewTime(clock.Now())
 	var additions []v1.DNSZoneCondition
@@ -222,6 +337,22 @@ func mergeConditions(conditions, updates []v1.DNSZoneCondition) []v1.DNSZoneCond
 	return conditions
 }
 
+// setConditions merges the updates into the conditions, as mergeConditions
+// does, and also updates the message of the conditions whose status and reason
+// are unchanged, for the conditions whose message reports the current state,
+// e.g. the planned changes or the unhealthy endpoints.
+func setConditions(conditions, updates []v1.DNSZoneCondition) []v1.DNSZoneCondition {
+	conditions = mergeConditions(conditions, updates)
+	for _, update := range updates {
+		for j := range conditions {
+			if conditions[j].Type == update.Type {
+				conditions[j].Message = update.Message
+			}
+		}
+	}
+	return conditions
+}
+
 func conditionChanged(a, b v1.DNSZoneCondition) bool {
 	return a.Status != b.Status || a.Reason != b.Reason
 }
@@ -0,0 +1,114 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+type deletingProvider struct {
+	dns.FakeProvider
+	deleted []string
+	err     error
+}
+
+func (p *deletingProvider) Delete(record *v1.DNSRecord, zone v1.DNSZone) error {
+	p.deleted = append(p.deleted, zone.ID)
+	return p.err
+}
+
+func TestDeleteRecord(t *testing.T) {
+	zones := []v1.DNSZone{{ID: "Z1", DNSName: "example.com"}, {ID: "Z2", DNSName: "example.org"}}
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				{DNSName: "abc.example.com", RecordType: "A", Targets: v1.Targets{"1.1.1.1"}},
+				{DNSName: "abc.example.org", RecordType: "A", Targets: v1.Targets{"1.1.1.1"}},
+			},
+		},
+		Status: v1.DNSRecordStatus{
+			Zones: []v1.DNSZoneStatus{{
+				DNSZone:    zones[0],
+				Conditions: []v1.DNSZoneCondition{{Type: v1.DNSRecordFailedConditionType, Status: string(ConditionFalse)}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		ownerID    string
+		err        error
+		deleted    []string
+		retryAfter time.Duration
+	}{
+		{name: "zones of the status", deleted: []string{"Z1"}},
+		{name: "zones of the spec with an ownership registry", ownerID: "glbc", deleted: []string{"Z1", "Z2"}},
+		{
+			name:       "throttled deletion",
+			ownerID:    "glbc",
+			err:        &dns.RetryableError{Err: errors.New("throttled"), RetryAfter: time.Minute},
+			deleted:    []string{"Z1", "Z2"},
+			retryAfter: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			provider := &deletingProvider{err: tt.err}
+			c := &Controller{
+				Controller:  &reconciler.Controller{Logger: logr.Discard()},
+				dnsProvider: provider,
+				dnsZones:    zones,
+				ownerID:     tt.ownerID,
+			}
+			err := c.deleteRecord(record.DeepCopy())
+			g.Expect(provider.deleted).To(gomega.Equal(tt.deleted))
+			if tt.err == nil {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				return
+			}
+			retryable, ok := dns.AsRetryableError(err)
+			g.Expect(ok).To(gomega.BeTrue())
+			g.Expect(retryable.RetryAfter).To(gomega.Equal(tt.retryAfter))
+		})
+	}
+}
+
+func TestMergeConditions(t *testing.T) {
+	condition := func(status, reason, message string) v1.DNSZoneCondition {
+		return v1.DNSZoneCondition{Type: v1.DNSRecordPlannedConditionType, Status: status, Reason: reason, Message: message}
+	}
+
+	tests := []struct {
+		name        string
+		merge       func(conditions, updates []v1.DNSZoneCondition) []v1.DNSZoneCondition
+		update      v1.DNSZoneCondition
+		wantMessage string
+		transition  bool
+	}{
+		{name: "merge unchanged", merge: mergeConditions, update: condition("True", "Planned", "2 changes"), wantMessage: "1 change"},
+		{name: "merge changed", merge: mergeConditions, update: condition("False", "NoChanges", "No changes"), wantMessage: "No changes", transition: true},
+		{name: "set unchanged", merge: setConditions, update: condition("True", "Planned", "2 changes"), wantMessage: "2 changes"},
+		{name: "set changed", merge: setConditions, update: condition("False", "NoChanges", "No changes"), wantMessage: "No changes", transition: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			conditions := []v1.DNSZoneCondition{condition("True", "Planned", "1 change")}
+			conditions = tt.merge(conditions, []v1.DNSZoneCondition{tt.update})
+			g.Expect(conditions).To(gomega.HaveLen(1))
+			g.Expect(conditions[0].Status).To(gomega.Equal(tt.update.Status))
+			g.Expect(conditions[0].Message).To(gomega.Equal(tt.wantMessage))
+			g.Expect(conditions[0].LastTransitionTime.IsZero()).To(gomega.Equal(!tt.transition))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// checkDrift reads the records of the zones, and compares them with the
+// DNSRecords published to each zone. The DNSRecords that have drifted, or that
+// are back in sync, are queued, so that their records are published again, and
+// their Drifted condition updated.
+func (c *Controller) checkDrift(_ context.Context) {
+	records, err := c.lister.List(labels.Everything())
+	if err != nil {
+		c.Logger.Error(err, "Failed to list DNSRecords for the drift check")
+		return
+	}
+
+	for _, zone := range c.dnsZones {
+		live, err := c.recordReader.Records(zone)
+		if err != nil {
+			c.Logger.Error(err, "Failed to read the records of zone for the drift check", "zone", zone)
+			continue
+		}
+
+		for _, record := range records {
+			// Only check the records whose latest generation is published
+			if record.DeletionTimestamp != nil || record.Generation != record.Status.ObservedGeneration || !recordIsAlreadyPublishedToZone(record, &zone) {
+				continue
+			}
+			zones, zoneRecords := recordsForZones(c.dnsZones, record)
+			var expected []*v1.Endpoint
+			for i := range zones {
+				if zones[i].ID == zone.ID {
+					expected = c.withProbedHealth(zoneRecords[i].Spec.Endpoints)
+				}
+			}
+
+			drift := driftOf(expected, live)
+			if drift == "" && !hasDriftedInZone(record, zone) {
+				continue
+			}
+			key, err := cache.MetaNamespaceKeyFunc(record)
+			if err != nil {
+				c.Logger.Error(err, "Failed to get DNSRecord key")
+				continue
+			}
+			if drift != "" {
+				c.Logger.Info("DNS record drifted", "record", key, "zone", zone, "drift", drift)
+				dnsRecordDriftTotal.Inc()
+			}
+			c.setDrift(key, zone.ID, drift)
+			c.Queue.Add(key)
+		}
+	}
+}
+
+// setDrift records the drift of the records published to the zone, or an
+// empty drift if they are back in sync, until the DNSRecord is reconciled.
+func (c *Controller) setDrift(key, zoneID, drift string) {
+	c.driftsLock.Lock()
+	defer c.driftsLock.Unlock()
+	if c.drifts[key] == nil {
+		c.drifts[key] = map[string]string{}
+	}
+	c.drifts[key][zoneID] = drift
+}
+
+// takeDrift returns, and forgets, the drift of the records published to the
+// zone, and whether the records have been checked since the last reconciliation.
+func (c *Controller) takeDrift(record *v1.DNSRecord, zoneID string) (string, bool) {
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		return "", false
+	}
+	c.driftsLock.Lock()
+	defer c.driftsLock.Unlock()
+	drift, checked := c.drifts[key][zoneID]
+	if checked {
+		delete(c.drifts[key], zoneID)
+		if len(c.drifts[key]) == 0 {
+			delete(c.drifts, key)
+		}
+	}
+	return drift, checked
+}
+
+func (c *Controller) forgetDrifts(key string) {
+	c.driftsLock.Lock()
+	defer c.driftsLock.Unlock()
+	delete(c.drifts, key)
+}
+
+func hasDriftedInZone(record *v1.DNSRecord, zone v1.DNSZone) bool {
+	for _, status := range record.Status.Zones {
+		if status.DNSZone.ID != zone.ID {
+			continue
+		}
+		for _, condition := range status.Conditions {
+			if condition.Type == v1.DNSRecordDriftedConditionType {
+				return condition.Status == string(ConditionTrue)
+			}
+		}
+	}
+	return false
+}
+
+type driftKey struct {
+	name       string
+	recordType string
+}
+
+func driftKeyForEndpoint(endpoint *v1.Endpoint) driftKey {
+	return driftKey{
+		name:       strings.ToLower(strings.TrimSuffix(endpoint.DNSName, ".")),
+		recordType: endpoint.RecordType,
+	}
+}
+
+// driftOf describes the differences between the expected endpoints and the
+// live records of the zone, or returns an empty string if there is none.
+//
+// The records are compared by name and type, as most providers group the
+// endpoints sharing a name and a type into a single record set. As some
+// providers leave out the targets of the endpoints with a weight of 0, these
+// targets may be missing from the live records.
+func driftOf(expected, live []*v1.Endpoint) string {
+	required := map[driftKey]map[string]bool{}
+	allowed := map[driftKey]map[string]bool{}
+	for _, endpoint := range expected {
+		key := driftKeyForEndpoint(endpoint)
+		if allowed[key] == nil {
+			allowed[key] = map[string]bool{}
+			required[key] = map[string]bool{}
+		}
+		drained := false
+		if prop, ok := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificWeight); ok && prop.Value == "0" {
+			drained = true
+		}
+		for _, target := range endpoint.Targets {
+			allowed[key][normalizeTarget(target)] = true
+			if !drained {
+				required[key][normalizeTarget(target)] = true
+			}
+		}
+	}
+
+	published := map[driftKey]map[string]bool{}
+	for _, endpoint := range live {
+		key := driftKeyForEndpoint(endpoint)
+		if _, ok := allowed[key]; !ok {
+			continue
+		}
+		if published[key] == nil {
+			published[key] = map[string]bool{}
+		}
+		for _, target := range endpoint.Targets {
+			published[key][normalizeTarget(target)] = true
+		}
+	}
+
+	keys := make([]driftKey, 0, len(allowed))
+	for key := range allowed {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].recordType < keys[j].recordType
+	})
+
+	var drifts []string
+	for _, key := range keys {
+		targets, ok := published[key]
+		if !ok {
+			drifts = append(drifts, fmt.Sprintf("%s record %s is missing", key.recordType, key.name))
+			continue
+		}
+		if len(required[key]) == 0 {
+			required[key] = allowed[key]
+		}
+		if missing := difference(required[key], targets); len(missing) > 0 {
+			drifts = append(drifts, fmt.Sprintf("%s record %s is missing targets %v", key.recordType, key.name, missing))
+		}
+		if unexpected := difference(targets, allowed[key]); len(unexpected) > 0 {
+			drifts = append(drifts, fmt.Sprintf("%s record %s has unexpected targets %v", key.recordType, key.name, unexpected))
+		}
+	}
+	return strings.Join(drifts, ", ")
+}
+
+func normalizeTarget(target string) string {
+	return strings.ToLower(strings.TrimSuffix(target, "."))
+}
+
+// difference returns the sorted elements of a that are not in b.
+func difference(a, b map[string]bool) []string {
+	var diff []string
+	for element := range a {
+		if !b[element] {
+			diff = append(diff, element)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
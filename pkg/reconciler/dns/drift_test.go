@@ -0,0 +1,69 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+func TestDriftOf(t *testing.T) {
+	endpoint := func(dnsName, target, weight string) *v1.Endpoint {
+		e := &v1.Endpoint{DNSName: dnsName, RecordType: "A", SetIdentifier: target, Targets: v1.Targets{target}}
+		if weight != "" {
+			e.SetProviderSpecific(dns.ProviderSpecificWeight, weight)
+		}
+		return e
+	}
+	expected := []*v1.Endpoint{
+		endpoint("abc.example.com", "1.1.1.1", "120"),
+		endpoint("abc.example.com", "2.2.2.2", "0"),
+		endpoint("def.example.com", "3.3.3.3", ""),
+	}
+
+	tests := []struct {
+		name     string
+		live     []*v1.Endpoint
+		expected string
+	}{
+		{
+			name: "in sync",
+			live: []*v1.Endpoint{
+				endpoint("abc.example.com.", "1.1.1.1", "120"),
+				endpoint("abc.example.com.", "2.2.2.2", "0"),
+				endpoint("def.example.com.", "3.3.3.3", ""),
+				endpoint("other.example.com.", "4.4.4.4", ""),
+			},
+		},
+		{
+			name: "drained target left out",
+			live: []*v1.Endpoint{
+				endpoint("abc.example.com", "1.1.1.1", ""),
+				endpoint("def.example.com", "3.3.3.3", ""),
+			},
+		},
+		{
+			name: "missing record",
+			live: []*v1.Endpoint{
+				endpoint("abc.example.com", "1.1.1.1", "120"),
+			},
+			expected: "A record def.example.com is missing",
+		},
+		{
+			name: "missing and unexpected targets",
+			live: []*v1.Endpoint{
+				endpoint("abc.example.com", "5.5.5.5", "120"),
+				endpoint("def.example.com", "3.3.3.3", ""),
+			},
+			expected: "A record abc.example.com is missing targets [1.1.1.1], A record abc.example.com has unexpected targets [5.5.5.5]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(driftOf(expected, tt.live)).To(gomega.Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// planRecordInZone plans the changes publishing the record to the zone, and
+// returns the status of the zone reporting them. The endpoints of the status
+// remain the ones last published, so that the changes are planned against the
+// records actually published to the zone.
+func (c *Controller) planRecordInZone(ctx context.Context, record, zoneRecord *v1.DNSRecord, zone v1.DNSZone) v1.DNSZoneStatus {
+	status := v1.DNSZoneStatus{
+		DNSZone:   zone,
+		Endpoints: publishedEndpoints(record, zone.ID),
+	}
+	if err := c.dnsProvider.Ensure(zoneRecord, zone); err != nil {
+		c.Logger.Error(err, "Failed to plan DNS record changes in zone", "record", zoneRecord.Spec, "zone", zone)
+		status.Conditions = []v1.DNSZoneCondition{{
+			Type:    v1.DNSRecordPlannedConditionType,
+			Status:  string(ConditionFalse),
+			Reason:  "PlanFailed",
+			Message: fmt.Sprintf("The DNS record changes could not be planned: %v", err),
+		}}
+		return status
+	}
+	changes, _ := c.dryRun.Plan(zoneRecord, zone.ID)
+	status.Conditions = []v1.DNSZoneCondition{c.plannedCondition(ctx, record, zone, changes)}
+	return status
+}
+
+// planRecordDeletionFromZone plans the changes deleting the record from a zone
+// it no longer belongs to, and returns the status of the zone reporting them.
+func (c *Controller) planRecordDeletionFromZone(ctx context.Context, record *v1.DNSRecord, status v1.DNSZoneStatus) v1.DNSZoneStatus {
+	deleted := recordWithEndpoints(record, status.Endpoints)
+	if err := c.dnsProvider.Delete(deleted, status.DNSZone); err != nil {
+		c.Logger.Error(err, "Failed to plan DNS record deletion from zone", "record", record, "zone", status.DNSZone)
+		return status
+	}
+	changes, _ := c.dryRun.Plan(deleted, status.DNSZone.ID)
+	status.Conditions = setConditions(status.Conditions, []v1.DNSZoneCondition{c.plannedCondition(ctx, record, status.DNSZone, changes)})
+	return status
+}
+
+// plannedCondition returns the Planned condition reporting the changes, and
+// records an event if they differ from the ones last planned.
+func (c *Controller) plannedCondition(ctx context.Context, record *v1.DNSRecord, zone v1.DNSZone, changes []dns.Change) v1.DNSZoneCondition {
+	description := dns.DescribeChanges(changes)
+	condition := v1.DNSZoneCondition{
+		Type:    v1.DNSRecordPlannedConditionType,
+		Status:  string(ConditionTrue),
+		Reason:  "DryRun",
+		Message: fmt.Sprintf("The DNS provider would apply: %s", description),
+	}
+	if zoneConditionMessage(record, zone.ID, v1.DNSRecordPlannedConditionType) != condition.Message {
+		c.recordEvent(ctx, record, corev1.EventTypeNormal, "DNSChangesPlanned", fmt.Sprintf("Planned changes to zone %s: %s", zone.ID, description))
+	}
+	return condition
+}
+
+// recordEvent records an event about the DNSRecord, in its logical cluster.
+func (c *Controller) recordEvent(ctx context.Context, record *v1.DNSRecord, eventType, reason, message string) {
+	if c.kubeClient == nil {
+		return
+	}
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: record.Name + ".",
+			Namespace:    record.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      v1.SchemeGroupVersion.String(),
+			Kind:            "DNSRecord",
+			Name:            record.Name,
+			Namespace:       record.Namespace,
+			UID:             record.UID,
+			ResourceVersion: record.ResourceVersion,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Source:         corev1.EventSource{Component: controllerName},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	_, err := c.kubeClient.Cluster(logicalcluster.From(record)).CoreV1().Events(record.Namespace).Create(ctx, event, metav1.CreateOptions{})
+	if err != nil {
+		c.Logger.Error(err, "Failed to record event", "record", record, "reason", reason)
+	}
+}
+
+func publishedEndpoints(record *v1.DNSRecord, zoneID string) []*v1.Endpoint {
+	for _, status := range record.Status.Zones {
+		if status.DNSZone.ID == zoneID {
+			return status.Endpoints
+		}
+	}
+	return nil
+}
+
+func zoneConditionMessage(record *v1.DNSRecord, zoneID, conditionType string) string {
+	for _, status := range record.Status.Zones {
+		if status.DNSZone.ID != zoneID {
+			continue
+		}
+		for _, condition := range status.Conditions {
+			if condition.Type == conditionType {
+				return condition.Message
+			}
+		}
+	}
+	return ""
+}
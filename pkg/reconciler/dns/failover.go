@@ -0,0 +1,120 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const (
+	// failoverCheckInterval is the interval at which the health of the primary
+	// failover records is checked, to report the records serving the traffic.
+	failoverCheckInterval = 30 * time.Second
+
+	failoverPrimary   = "PRIMARY"
+	failoverSecondary = "SECONDARY"
+
+	// defaultFailoverHealthCheckPath is the path the failover records are
+	// checked on, when no health check is configured, as Route53 only fails
+	// over from the records with a health check.
+	defaultFailoverHealthCheckPath = "/"
+)
+
+// reportFailover sets the FailedOver condition of the zones the failover
+// records are published to, as per the health of the endpoints read for the
+// status of the DNSRecord, so that it is only read once per reconciliation.
+func (c *Controller) reportFailover(healthChecks []v1.EndpointHealthStatus, statuses []v1.DNSZoneStatus) []v1.DNSZoneStatus {
+	for i := range statuses {
+		condition, ok := c.failoverCondition(healthChecks, statuses[i].Endpoints)
+		if !ok {
+			continue
+		}
+		statuses[i].Conditions = setConditions(statuses[i].Conditions, []v1.DNSZoneCondition{condition})
+	}
+	return statuses
+}
+
+// failoverCondition returns the FailedOver condition of the failover records,
+// reporting whether the traffic is served by the primary records, i.e., if any
+// of them is healthy, or by the secondary ones.
+func (c *Controller) failoverCondition(healthChecks []v1.EndpointHealthStatus, endpoints []*v1.Endpoint) (v1.DNSZoneCondition, bool) {
+	primary := failoverEndpoints(endpoints, failoverPrimary)
+	if len(primary) == 0 {
+		return v1.DNSZoneCondition{}, false
+	}
+	if _, ok := c.dnsProvider.HealthCheckReconciler().(dns.HealthCheckStatusReporter); !ok {
+		return v1.DNSZoneCondition{}, false
+	}
+
+	primaryHealthy := false
+	for _, endpoint := range primary {
+		// An endpoint not checked yet is considered healthy
+		if status := findHealthStatus(healthChecks, endpoint); status == nil || status.Healthy {
+			primaryHealthy = true
+		}
+	}
+
+	if primaryHealthy {
+		return v1.DNSZoneCondition{
+			Type:    v1.DNSRecordFailedOverConditionType,
+			Status:  string(ConditionFalse),
+			Reason:  "PrimaryHealthy",
+			Message: fmt.Sprintf("The traffic is served by the primary sync targets [%s]", syncTargetsOf(primary)),
+		}, true
+	}
+	return v1.DNSZoneCondition{
+		Type:   v1.DNSRecordFailedOverConditionType,
+		Status: string(ConditionTrue),
+		Reason: "PrimaryUnhealthy",
+		Message: fmt.Sprintf("The primary sync targets [%s] are unhealthy, the traffic is served by the secondary sync targets [%s]",
+			syncTargetsOf(primary), syncTargetsOf(failoverEndpoints(endpoints, failoverSecondary))),
+	}, true
+}
+
+// requeueFailover queues the DNSRecord again after a delay, if it has failover
+// records, so that the health of the primary records is reported.
+func (c *Controller) requeueFailover(record *v1.DNSRecord) {
+	if !hasFailoverEndpoints(record) {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		c.Logger.Error(err, "Failed to get DNSRecord key")
+		return
+	}
+	c.Queue.AddAfter(key, failoverCheckInterval)
+}
+
+func hasFailoverEndpoints(record *v1.DNSRecord) bool {
+	return len(failoverEndpoints(record.Spec.Endpoints, failoverPrimary)) > 0
+}
+
+func failoverEndpoints(endpoints []*v1.Endpoint, failover string) []*v1.Endpoint {
+	var result []*v1.Endpoint
+	for _, endpoint := range endpoints {
+		if prop, ok := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificFailover); ok && prop.Value == failover {
+			result = append(result, endpoint)
+		}
+	}
+	return result
+}
+
+// syncTargetsOf returns the sync targets the endpoints route the traffic to.
+func syncTargetsOf(endpoints []*v1.Endpoint) string {
+	var syncTargets []string
+	seen := map[string]bool{}
+	for _, endpoint := range endpoints {
+		for _, syncTarget := range strings.Split(endpoint.Labels[v1.SyncTargetsLabel], ",") {
+			if syncTarget != "" && !seen[syncTarget] {
+				seen[syncTarget] = true
+				syncTargets = append(syncTargets, syncTarget)
+			}
+		}
+	}
+	return strings.Join(syncTargets, ", ")
+}
@@ -0,0 +1,48 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+func TestFailoverCondition(t *testing.T) {
+	endpoint := func(setID, failover, syncTarget string) *v1.Endpoint {
+		e := &v1.Endpoint{DNSName: "abc.example.com", RecordType: "A", SetIdentifier: setID, Targets: v1.Targets{"1.1.1.1"}, Labels: v1.Labels{v1.SyncTargetsLabel: syncTarget}}
+		e.SetProviderSpecific(dns.ProviderSpecificFailover, failover)
+		return e
+	}
+	endpoints := []*v1.Endpoint{endpoint("primary", failoverPrimary, "cluster-1"), endpoint("secondary", failoverSecondary, "cluster-2")}
+	health := func(setID string, healthy bool) v1.EndpointHealthStatus {
+		return v1.EndpointHealthStatus{DNSName: "abc.example.com", SetIdentifier: setID, Healthy: healthy}
+	}
+
+	tests := []struct {
+		name         string
+		healthChecks []v1.EndpointHealthStatus
+		wantStatus   string
+	}{
+		{name: "not checked yet", wantStatus: string(ConditionFalse)},
+		{name: "primary healthy", healthChecks: []v1.EndpointHealthStatus{health("primary", true), health("secondary", false)}, wantStatus: string(ConditionFalse)},
+		{name: "primary unhealthy", healthChecks: []v1.EndpointHealthStatus{health("primary", false), health("secondary", true)}, wantStatus: string(ConditionTrue)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			c := &Controller{dnsProvider: dns.NewProbingProvider(&dns.FakeProvider{}, dns.NewProber(0, logr.Discard()))}
+			condition, ok := c.failoverCondition(tt.healthChecks, endpoints)
+			g.Expect(ok).To(gomega.BeTrue())
+			g.Expect(condition.Type).To(gomega.Equal(v1.DNSRecordFailedOverConditionType))
+			g.Expect(condition.Status).To(gomega.Equal(tt.wantStatus))
+		})
+	}
+
+	c := &Controller{dnsProvider: &dns.FakeProvider{}}
+	_, ok := c.failoverCondition(nil, endpoints)
+	gomega.NewWithT(t).Expect(ok).To(gomega.BeFalse())
+}
@@ -19,10 +19,18 @@ const ANNOTATION_HEALTH_CHECK_PREFIX = "kuadrant.experimental/health-"
 
 // healthChecksConfig represents the user configuration for the health checks
 type healthChecksConfig struct {
+	// Policy is the name of the HealthCheckPolicy of the configuration, or
+	// empty if it is from annotations
+	Policy           string
 	Endpoint         string
 	Port             *int64
 	FailureThreshold *int64
 	Protocol         *dns.HealthCheckProtocol
+	SearchString     string
+	RequestInterval  *int64
+	Regions          []string
+	EnableSNI        *bool
+	Inverted         bool
 }
 
 // annotationsConfigMap contains the logic to map an annotation-based configuration
@@ -38,13 +46,12 @@ var annotationsConfigMap = map[string]func(string, *healthChecksConfig) error{
 	"protocol": notNilConfig(func(protocol string, c *healthChecksConfig) error {
 		var value dns.HealthCheckProtocol
 		switch protocol {
-		case string(dns.HealthCheckProtocolHTTP):
-		case string(dns.HealthCheckProtocolHTTPS):
+		case string(dns.HealthCheckProtocolHTTP), string(dns.HealthCheckProtocolHTTPS), string(dns.HealthCheckProtocolTCP):
 			value = dns.HealthCheckProtocol(protocol)
 		}
 
 		if value == "" {
-			return fmt.Errorf("invalid protocol %s. Only supported values are HTTP and HTTPS", protocol)
+			return fmt.Errorf("invalid protocol %s. Only supported values are HTTP, HTTPS and TCP", protocol)
 		}
 
 		c.Protocol = &value
@@ -53,23 +60,114 @@ var annotationsConfigMap = map[string]func(string, *healthChecksConfig) error{
 	"failure-threshold": notNilConfig(configInt64(func(v int64, c *healthChecksConfig) {
 		c.FailureThreshold = &v
 	})),
+	"search-string": notNilConfig(func(searchString string, c *healthChecksConfig) error {
+		c.SearchString = searchString
+		return nil
+	}),
+	"request-interval": notNilConfig(configInt64(func(v int64, c *healthChecksConfig) {
+		c.RequestInterval = &v
+	})),
+	"regions": notNilConfig(func(regions string, c *healthChecksConfig) error {
+		c.Regions = nil
+		for _, region := range strings.Split(regions, ",") {
+			if region = strings.TrimSpace(region); region != "" {
+				c.Regions = append(c.Regions, region)
+			}
+		}
+		return nil
+	}),
+	"enable-sni": notNilConfig(configBool(func(v bool, c *healthChecksConfig) {
+		c.EnableSNI = &v
+	})),
+	"inverted": notNilConfig(configBool(func(v bool, c *healthChecksConfig) {
+		c.Inverted = v
+	})),
 }
 
-func (c *Controller) ReconcileHealthChecks(ctx context.Context, dnsRecord *v1.DNSRecord) error {
-	config, err := configFromAnnotations(dnsRecord.Annotations)
+// ReconcileHealthChecks reconciles the health checks of the endpoints of the
+// record with the configuration, as returned by healthChecksConfig.
+func (c *Controller) ReconcileHealthChecks(ctx context.Context, dnsRecord *v1.DNSRecord, config *healthChecksConfig, err error) error {
 	if err != nil {
-		return err
+		// The invalid configuration is reported by the HealthChecksConfigured
+		// condition, and the health checks are left as they are until it is
+		// fixed, which updates the DNSRecord
+		c.Logger.Info("Skipping health checks reconciliation, invalid configuration", "record", dnsRecord, "error", err.Error())
+		return nil
 	}
 
 	if config == nil {
 		return c.reconcileHealthCheckDeletion(ctx, dnsRecord)
 	}
 
-	if err = validateHealthChecksConfig(config); err != nil {
-		return err
+	return c.reconcileHealthCheck(ctx, config, dnsRecord)
+}
+
+// healthChecksConfig returns the validated configuration of the health checks
+// of the record, or nil if the record has no health checks. The effective
+// HealthCheckPolicy of the Ingress of the record takes precedence over its
+// annotations.
+func (c *Controller) healthChecksConfig(dnsRecord *v1.DNSRecord) (*healthChecksConfig, error) {
+	policy, err := c.healthCheckPolicyFor(dnsRecord)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.reconcileHealthCheck(ctx, config, dnsRecord)
+	var config *healthChecksConfig
+	if policy != nil {
+		config = configFromPolicy(policy)
+	} else if config, err = configFromAnnotations(dnsRecord.Annotations); err != nil {
+		return nil, err
+	}
+
+	// Route53 only fails over from the records with a health check
+	if config == nil && hasFailoverEndpoints(dnsRecord) {
+		config = &healthChecksConfig{Endpoint: defaultFailoverHealthCheckPath}
+	}
+
+	if config == nil {
+		return nil, nil
+	}
+
+	err = validateHealthChecksConfig(config)
+	if validator, ok := c.dnsProvider.HealthCheckReconciler().(dns.HealthCheckValidator); ok && err == nil {
+		err = validator.Validate(config.spec())
+	}
+	if err != nil && config.Policy != "" {
+		return nil, fmt.Errorf("HealthCheckPolicy %s: %v", config.Policy, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// reportHealthChecksConfig sets the HealthChecksConfigured condition of the
+// zones, if the record configures health checks, as per the configuration
+// returned by healthChecksConfig.
+func reportHealthChecksConfig(config *healthChecksConfig, err error, statuses []v1.DNSZoneStatus) []v1.DNSZoneStatus {
+	if config == nil && err == nil {
+		return statuses
+	}
+
+	condition := v1.DNSZoneCondition{
+		Type:    v1.DNSRecordHealthChecksConfiguredConditionType,
+		Status:  string(ConditionTrue),
+		Reason:  "HealthChecksConfigured",
+		Message: "The health checks of the endpoints are configured",
+	}
+	if config != nil && config.Policy != "" {
+		condition.Message = fmt.Sprintf("The health checks of the endpoints are configured by the HealthCheckPolicy %s", config.Policy)
+	}
+	if err != nil {
+		condition.Status = string(ConditionFalse)
+		condition.Reason = "InvalidHealthChecks"
+		condition.Message = fmt.Sprintf("The health checks configuration is invalid: %v", err)
+	}
+	for i := range statuses {
+		statuses[i].Conditions = setConditions(statuses[i].Conditions, []v1.DNSZoneCondition{condition})
+	}
+	return statuses
 }
 
 func (c *Controller) reconcileHealthCheck(ctx context.Context, config *healthChecksConfig, dnsRecord *v1.DNSRecord) error {
@@ -87,14 +185,10 @@ func (c *Controller) reconcileHealthCheck(ctx context.Context, config *healthChe
 			return err
 		}
 
-		spec := dns.HealthCheckSpec{
-			Id:               endpointId,
-			Name:             fmt.Sprintf("%s-%s", dnsEndpoint.DNSName, dnsEndpoint.SetIdentifier),
-			Path:             config.Endpoint,
-			Port:             config.Port,
-			Protocol:         config.Protocol,
-			FailureThreshold: config.FailureThreshold,
-		}
+		spec := config.spec()
+		spec.Id = endpointId
+		spec.OwnerID = c.ownerID
+		spec.Name = fmt.Sprintf("%s-%s", dnsEndpoint.DNSName, dnsEndpoint.SetIdentifier)
 
 		c.Logger.Info("Reconciling health check for endpoint", "name", dnsEndpoint.DNSName, "identifier", dnsEndpoint.SetIdentifier)
 
@@ -107,20 +201,70 @@ func (c *Controller) reconcileHealthCheck(ctx context.Context, config *healthChe
 	return nil
 }
 
+// reconcileHealthCheckDeletion deletes the health checks of the endpoints of
+// the spec, which may not have been published, e.g. if they are probed, and of
+// the published endpoints, which may no longer be endpoints of the spec.
 func (c *Controller) reconcileHealthCheckDeletion(ctx context.Context, dnsRecord *v1.DNSRecord) error {
 	reconciler := c.dnsProvider.HealthCheckReconciler()
 
+	endpoints := append([]*v1.Endpoint(nil), dnsRecord.Spec.Endpoints...)
 	for _, zone := range dnsRecord.Status.Zones {
-		for _, endpoint := range zone.Endpoints {
-			if err := reconciler.Delete(ctx, endpoint); err != nil {
-				return err
-			}
+		endpoints = append(endpoints, zone.Endpoints...)
+	}
+	deleted := map[string]bool{}
+	for _, endpoint := range endpoints {
+		id, _ := endpoint.GetProviderSpecific(dns.ProviderSpecificHealthCheckID)
+		key := endpoint.DNSName + "/" + endpoint.RecordType + "/" + endpoint.SetID() + "/" + id
+		if deleted[key] {
+			continue
+		}
+		deleted[key] = true
+		if err := reconciler.Delete(ctx, endpoint); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// deleteReplacedHealthChecks deletes the health checks of the endpoints
+// previously published, that are referenced by neither the spec nor the
+// endpoints now published, e.g. as they have been replaced. The health checks
+// previously published to the zones the record failed to be published to are
+// kept, as the records of the zones may still reference them.
+func (c *Controller) deleteReplacedHealthChecks(ctx context.Context, record *v1.DNSRecord, previous []v1.DNSZoneStatus) {
+	lister, ok := c.dnsProvider.HealthCheckReconciler().(dns.HealthCheckLister)
+	if !ok {
+		return
+	}
+	referenced := referencedHealthChecks([]*v1.DNSRecord{record})
+	for _, status := range previous {
+		if recordIsAlreadyPublishedToZone(record, &status.DNSZone) || !hasZoneStatus(record, status.DNSZone.ID) {
+			continue
+		}
+		for _, endpoint := range status.Endpoints {
+			if id, ok := endpoint.GetProviderSpecific(dns.ProviderSpecificHealthCheckID); ok {
+				referenced[id] = true
+			}
+		}
+	}
+
+	for _, status := range previous {
+		for _, endpoint := range status.Endpoints {
+			id, ok := endpoint.GetProviderSpecific(dns.ProviderSpecificHealthCheckID)
+			if !ok || referenced[id] {
+				continue
+			}
+			referenced[id] = true
+			if err := lister.DeleteHealthCheck(ctx, id); err != nil {
+				c.Logger.Error(err, "Failed to delete replaced health check, left to be collected", "id", id, "name", endpoint.DNSName, "identifier", endpoint.SetIdentifier)
+				continue
+			}
+			c.Logger.Info("Deleted replaced health check", "id", id, "name", endpoint.DNSName, "identifier", endpoint.SetIdentifier)
+		}
+	}
+}
+
 // idForEndpoint returns a unique identifier for an endpoint
 func idForEndpoint(dnsRecord *v1.DNSRecord, endpoint *v1.Endpoint) (string, error) {
 	hash := md5.New()
@@ -173,7 +317,7 @@ func validateHealthChecksConfig(config *healthChecksConfig) error {
 		return errors.New("health checks config can't be nil")
 	}
 
-	if config.Endpoint == "" {
+	if config.Endpoint == "" && (config.Protocol == nil || *config.Protocol != dns.HealthCheckProtocolTCP) {
 		return errors.New("endpoint is a required value to configure health checks")
 	}
 	if config.Port == nil {
@@ -184,9 +328,44 @@ func validateHealthChecksConfig(config *healthChecksConfig) error {
 		config.Protocol = &defaultProtocol
 	}
 
+	if *config.Port < 1 || *config.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535, got %d", *config.Port)
+	}
+	if config.FailureThreshold != nil && (*config.FailureThreshold < 1 || *config.FailureThreshold > 10) {
+		return fmt.Errorf("failure-threshold must be between 1 and 10, got %d", *config.FailureThreshold)
+	}
+	if config.SearchString != "" && *config.Protocol == dns.HealthCheckProtocolTCP {
+		return errors.New("search-string is only supported by the HTTP and HTTPS protocols")
+	}
+	if len(config.SearchString) > 255 {
+		return fmt.Errorf("search-string must be at most 255 characters, got %d", len(config.SearchString))
+	}
+	if config.RequestInterval != nil && *config.RequestInterval != 10 && *config.RequestInterval != 30 {
+		return fmt.Errorf("request-interval must be 10 or 30 seconds, got %d", *config.RequestInterval)
+	}
+	if config.EnableSNI != nil && *config.EnableSNI && *config.Protocol != dns.HealthCheckProtocolHTTPS {
+		return fmt.Errorf("enable-sni is only supported by the HTTPS protocol, got %s", *config.Protocol)
+	}
+
 	return nil
 }
 
+// spec returns the spec of the health checks of the endpoints, as per the
+// configuration.
+func (config *healthChecksConfig) spec() dns.HealthCheckSpec {
+	return dns.HealthCheckSpec{
+		Path:             config.Endpoint,
+		Port:             config.Port,
+		Protocol:         config.Protocol,
+		FailureThreshold: config.FailureThreshold,
+		SearchString:     config.SearchString,
+		RequestInterval:  config.RequestInterval,
+		Regions:          config.Regions,
+		EnableSNI:        config.EnableSNI,
+		Inverted:         config.Inverted,
+	}
+}
+
 func configInt64(f func(int64, *healthChecksConfig)) func(string, *healthChecksConfig) error {
 	return func(s string, c *healthChecksConfig) error {
 		intValue, err := strconv.Atoi(s)
@@ -199,6 +378,17 @@ func configInt64(f func(int64, *healthChecksConfig)) func(string, *healthChecksC
 	}
 }
 
+func configBool(f func(bool, *healthChecksConfig)) func(string, *healthChecksConfig) error {
+	return func(s string, c *healthChecksConfig) error {
+		value, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f(value, c)
+		return nil
+	}
+}
+
 func notNilConfig(f func(string, *healthChecksConfig) error) func(string, *healthChecksConfig) error {
 	return func(s string, hcc *healthChecksConfig) error {
 		if hcc == nil {
@@ -0,0 +1,92 @@
+package dns
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// collectHealthChecks deletes the health checks created by this GLBC instance
+// for the names of the zones, that have not been referenced by any DNSRecord
+// for the grace period, e.g. as GLBC stopped before recording a new health
+// check in its DNSRecord, or the DNSRecord was deleted without its finalizer
+// being run.
+func (c *Controller) collectHealthChecks(ctx context.Context) {
+	healthChecks, err := c.healthCheckLister.ListHealthChecks(ctx)
+	if err != nil {
+		c.Logger.Error(err, "Failed to list health checks")
+		return
+	}
+	records, err := c.lister.List(labels.Everything())
+	if err != nil {
+		c.Logger.Error(err, "Failed to list DNSRecords")
+		return
+	}
+	referenced := referencedHealthChecks(records)
+
+	now := clock.Now()
+	orphaned := map[string]time.Time{}
+	for _, healthCheck := range healthChecks {
+		if referenced[healthCheck.ID] || healthCheck.OwnerID != c.ownerID || !c.isZoneName(healthCheck.DNSName) {
+			continue
+		}
+		since, ok := c.orphanedHealthChecks[healthCheck.ID]
+		if !ok {
+			c.Logger.Info("Found orphaned health check", "id", healthCheck.ID, "name", healthCheck.DNSName, "gracePeriod", c.healthCheckGCGracePeriod)
+			since = now
+		}
+		orphaned[healthCheck.ID] = since
+		if now.Sub(since) < c.healthCheckGCGracePeriod {
+			continue
+		}
+
+		if c.healthCheckGCDryRun {
+			c.Logger.Info("Orphaned health check would be deleted", "id", healthCheck.ID, "name", healthCheck.DNSName, "orphanedSince", since)
+			continue
+		}
+		if err := c.healthCheckLister.DeleteHealthCheck(ctx, healthCheck.ID); err != nil {
+			c.Logger.Error(err, "Failed to delete orphaned health check", "id", healthCheck.ID, "name", healthCheck.DNSName)
+			continue
+		}
+		c.Logger.Info("Deleted orphaned health check", "id", healthCheck.ID, "name", healthCheck.DNSName, "orphanedSince", since)
+		orphanedHealthCheckDeletedTotal.Inc()
+		delete(orphaned, healthCheck.ID)
+	}
+	c.orphanedHealthChecks = orphaned
+	orphanedHealthChecks.Set(float64(len(orphaned)))
+}
+
+// isZoneName returns whether the name belongs to any of the zones, so that the
+// health checks of the names managed by other instances are left alone.
+func (c *Controller) isZoneName(name string) bool {
+	for _, zone := range c.dnsZones {
+		if zone.DNSName == "" {
+			return true
+		}
+	}
+	return longestZoneMatchLength(c.dnsZones, &v1.Endpoint{DNSName: name}) >= 0
+}
+
+// referencedHealthChecks returns the IDs of the health checks set on the
+// endpoints of the records, or on the endpoints they published.
+func referencedHealthChecks(records []*v1.DNSRecord) map[string]bool {
+	referenced := map[string]bool{}
+	reference := func(endpoints []*v1.Endpoint) {
+		for _, endpoint := range endpoints {
+			if id, ok := endpoint.GetProviderSpecific(dns.ProviderSpecificHealthCheckID); ok {
+				referenced[id] = true
+			}
+		}
+	}
+	for _, record := range records {
+		reference(record.Spec.Endpoints)
+		for _, zone := range record.Status.Zones {
+			reference(zone.Endpoints)
+		}
+	}
+	return referenced
+}
@@ -0,0 +1,130 @@
+package dns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	kuadrantv1lister "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/listers/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+type fakeHealthCheckLister struct {
+	healthChecks []dns.HealthCheck
+	deleted      []string
+}
+
+func (l *fakeHealthCheckLister) ListHealthChecks(context.Context) ([]dns.HealthCheck, error) {
+	return l.healthChecks, nil
+}
+
+func (l *fakeHealthCheckLister) DeleteHealthCheck(_ context.Context, id string) error {
+	l.deleted = append(l.deleted, id)
+	return nil
+}
+
+func TestCollectHealthChecks(t *testing.T) {
+	fakeClock := utilclock.NewFakeClock(time.Now())
+	defer func(c utilclock.Clock) { clock = c }(clock)
+	clock = fakeClock
+
+	endpoint := &v1.Endpoint{DNSName: "abc.example.com", RecordType: "A", Targets: v1.Targets{"1.1.1.1"}}
+	endpoint.SetProviderSpecific(dns.ProviderSpecificHealthCheckID, "referenced")
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	g := gomega.NewWithT(t)
+	g.Expect(indexer.Add(&v1.DNSRecord{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: "default"},
+		Spec:       v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{endpoint}},
+	})).To(gomega.Succeed())
+
+	lister := &fakeHealthCheckLister{healthChecks: []dns.HealthCheck{
+		{ID: "referenced", DNSName: "abc.example.com", OwnerID: "glbc"},
+		{ID: "orphaned", DNSName: "def.example.com", OwnerID: "glbc"},
+		{ID: "other-zone", DNSName: "abc.example.org", OwnerID: "glbc"},
+		{ID: "other-owner", DNSName: "def.example.com", OwnerID: "other"},
+		{ID: "no-owner", DNSName: "def.example.com"},
+	}}
+	c := &Controller{
+		Controller:               &reconciler.Controller{Logger: logr.Discard()},
+		lister:                   kuadrantv1lister.NewDNSRecordLister(indexer),
+		dnsZones:                 []v1.DNSZone{{ID: "zone", DNSName: "example.com"}},
+		healthCheckLister:        lister,
+		healthCheckGCGracePeriod: time.Hour,
+		ownerID:                  "glbc",
+		orphanedHealthChecks:     map[string]time.Time{},
+	}
+
+	// Orphaned health checks are only deleted after the grace period
+	c.collectHealthChecks(context.Background())
+	g.Expect(c.orphanedHealthChecks).To(gomega.HaveKey("orphaned"))
+	g.Expect(c.orphanedHealthChecks).To(gomega.HaveLen(1))
+	g.Expect(lister.deleted).To(gomega.BeEmpty())
+
+	// Nor in dry run mode
+	fakeClock.Step(time.Hour)
+	c.healthCheckGCDryRun = true
+	c.collectHealthChecks(context.Background())
+	g.Expect(lister.deleted).To(gomega.BeEmpty())
+
+	c.healthCheckGCDryRun = false
+	c.collectHealthChecks(context.Background())
+	g.Expect(lister.deleted).To(gomega.Equal([]string{"orphaned"}))
+	g.Expect(c.orphanedHealthChecks).To(gomega.BeEmpty())
+}
+
+type listingHealthCheckReconciler struct {
+	dns.HealthCheckReconciler
+	*fakeHealthCheckLister
+}
+
+type listingProvider struct {
+	dns.FakeProvider
+	reconciler *listingHealthCheckReconciler
+}
+
+func (p *listingProvider) HealthCheckReconciler() dns.HealthCheckReconciler {
+	return p.reconciler
+}
+
+func TestDeleteReplacedHealthChecks(t *testing.T) {
+	endpoint := func(id string) *v1.Endpoint {
+		endpoint := &v1.Endpoint{DNSName: "abc.example.com", RecordType: "A", Targets: v1.Targets{"1.1.1.1"}}
+		endpoint.SetProviderSpecific(dns.ProviderSpecificHealthCheckID, id)
+		return endpoint
+	}
+	failed := func(status string) []v1.DNSZoneCondition {
+		return []v1.DNSZoneCondition{{Type: v1.DNSRecordFailedConditionType, Status: status}}
+	}
+	previous := []v1.DNSZoneStatus{
+		{DNSZone: v1.DNSZone{ID: "published"}, Endpoints: []*v1.Endpoint{endpoint("replaced")}},
+		{DNSZone: v1.DNSZone{ID: "failed"}, Endpoints: []*v1.Endpoint{endpoint("kept")}},
+		{DNSZone: v1.DNSZone{ID: "unchanged"}, Endpoints: []*v1.Endpoint{endpoint("current")}},
+	}
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{endpoint("current")}},
+		Status: v1.DNSRecordStatus{Zones: []v1.DNSZoneStatus{
+			{DNSZone: v1.DNSZone{ID: "published"}, Endpoints: []*v1.Endpoint{endpoint("current")}, Conditions: failed(string(ConditionFalse))},
+			{DNSZone: v1.DNSZone{ID: "failed"}, Endpoints: []*v1.Endpoint{endpoint("current")}, Conditions: failed(string(ConditionTrue))},
+			{DNSZone: v1.DNSZone{ID: "unchanged"}, Endpoints: []*v1.Endpoint{endpoint("current")}, Conditions: failed(string(ConditionFalse))},
+		}},
+	}
+
+	lister := &fakeHealthCheckLister{}
+	c := &Controller{
+		Controller:  &reconciler.Controller{Logger: logr.Discard()},
+		dnsProvider: &listingProvider{reconciler: &listingHealthCheckReconciler{fakeHealthCheckLister: lister}},
+	}
+	c.deleteReplacedHealthChecks(context.Background(), record, previous)
+
+	g := gomega.NewWithT(t)
+	g.Expect(lister.deleted).To(gomega.Equal([]string{"replaced"}))
+}
@@ -0,0 +1,90 @@
+package dns
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler/healthcheckpolicy"
+)
+
+// defaultHealthCheckPolicyPath is the path of the health endpoint of the
+// HealthCheckPolicies that do not set it.
+const defaultHealthCheckPolicyPath = "/"
+
+// healthCheckPolicyFor returns the effective HealthCheckPolicy of the Ingress
+// of the record, if any. The DNSRecord of an Ingress has the same key.
+func (c *Controller) healthCheckPolicyFor(record *v1.DNSRecord) (*v1.HealthCheckPolicy, error) {
+	if c.healthCheckPolicyIndexer == nil {
+		return nil, nil
+	}
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		return nil, err
+	}
+	object, exists, err := c.ingressIndexer.GetByKey(key)
+	if err != nil || !exists {
+		return nil, err
+	}
+	ingress := object.(*networkingv1.Ingress)
+	objects, err := reconciler.ByClusterNamespace(c.healthCheckPolicyIndexer, ingress)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*v1.HealthCheckPolicy, 0, len(objects))
+	for _, object := range objects {
+		policies = append(policies, object.(*v1.HealthCheckPolicy))
+	}
+	return healthcheckpolicy.Effective(policies, ingress), nil
+}
+
+// configFromPolicy returns the configuration of the health checks of the
+// policy. The policy is validated as the configuration from annotations.
+func configFromPolicy(policy *v1.HealthCheckPolicy) *healthChecksConfig {
+	spec := policy.Spec
+	config := &healthChecksConfig{
+		Policy:           policy.Name,
+		Endpoint:         spec.Path,
+		Port:             spec.Port,
+		FailureThreshold: spec.FailureThreshold,
+		SearchString:     spec.SearchString,
+		RequestInterval:  spec.RequestInterval,
+		Regions:          spec.Regions,
+		EnableSNI:        spec.EnableSNI,
+		Inverted:         spec.Inverted,
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = defaultHealthCheckPolicyPath
+	}
+	if spec.Protocol != nil {
+		protocol := dns.HealthCheckProtocol(*spec.Protocol)
+		config.Protocol = &protocol
+	}
+	return config
+}
+
+// enqueueRecordsOfNamespace queues the DNSRecords of the workspace and
+// namespace of the HealthCheckPolicy, so that its changes are applied to the
+// health checks of the Ingresses it targets, or targeted.
+func (c *Controller) enqueueRecordsOfNamespace(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	policy, err := meta.Accessor(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	records, err := reconciler.ByClusterNamespace(c.indexer, policy)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, record := range records {
+		c.Enqueue(record)
+	}
+}
@@ -0,0 +1,96 @@
+package dns
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// healthStatusRefreshInterval is the interval at which the health of the
+// endpoints is read again, and the times of their latest checks refreshed in
+// the DNSRecord status, if their health has not changed in the meantime.
+const healthStatusRefreshInterval = time.Minute
+
+// healthCheckStatuses returns the health of the endpoints of the record with a
+// health check, as reported by the health check reconciler of the provider.
+// The previous health of an endpoint is kept if it cannot be read.
+func (c *Controller) healthCheckStatuses(ctx context.Context, record *v1.DNSRecord) []v1.EndpointHealthStatus {
+	reporter, ok := c.dnsProvider.HealthCheckReconciler().(dns.HealthCheckStatusReporter)
+	if !ok {
+		return nil
+	}
+
+	var statuses []v1.EndpointHealthStatus
+	for _, endpoint := range record.Spec.Endpoints {
+		status, err := reporter.Status(ctx, endpoint)
+		if err != nil {
+			c.Logger.Error(err, "Failed to read the health of the endpoint", "name", endpoint.DNSName, "identifier", endpoint.SetIdentifier)
+			if previous := findHealthStatus(record.Status.HealthChecks, endpoint); previous != nil {
+				statuses = append(statuses, *previous)
+			}
+			continue
+		}
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+	}
+	return statuses
+}
+
+// healthStatusesEqual returns whether the health of the endpoints is unchanged,
+// so that the DNSRecord status is not updated on every check of the endpoints.
+// The times of the checks are only compared once they are older than the
+// refresh interval.
+func healthStatusesEqual(a, b []v1.EndpointHealthStatus) bool {
+	opts := []cmp.Option{
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(v1.EndpointHealthStatus{}, "LastCheckedTime"),
+		cmpopts.IgnoreFields(v1.HealthCheckObservation{}, "LastCheckedTime"),
+	}
+	if !cmp.Equal(a, b, opts...) {
+		return false
+	}
+	for i := range a {
+		if a[i].LastCheckedTime == nil || b[i].LastCheckedTime == nil {
+			if a[i].LastCheckedTime != b[i].LastCheckedTime {
+				return false
+			}
+			continue
+		}
+		elapsed := a[i].LastCheckedTime.Sub(b[i].LastCheckedTime.Time)
+		if elapsed >= healthStatusRefreshInterval || -elapsed >= healthStatusRefreshInterval {
+			return false
+		}
+	}
+	return true
+}
+
+// requeueHealthChecks queues the DNSRecord again after the refresh interval, if
+// it has endpoints with a health check, so that their health is reported.
+func (c *Controller) requeueHealthChecks(record *v1.DNSRecord) {
+	if len(record.Status.HealthChecks) == 0 {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(record)
+	if err != nil {
+		c.Logger.Error(err, "Failed to get DNSRecord key")
+		return
+	}
+	c.Queue.AddAfter(key, healthStatusRefreshInterval)
+}
+
+func findHealthStatus(statuses []v1.EndpointHealthStatus, endpoint *v1.Endpoint) *v1.EndpointHealthStatus {
+	for i := range statuses {
+		if statuses[i].DNSName == endpoint.DNSName && statuses[i].SetIdentifier == endpoint.SetIdentifier {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
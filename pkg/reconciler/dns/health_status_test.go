@@ -0,0 +1,45 @@
+package dns
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+func TestHealthStatusesEqual(t *testing.T) {
+	now := time.Now()
+	status := func(healthy bool, checked time.Time) []v1.EndpointHealthStatus {
+		lastChecked := metav1.NewTime(checked)
+		return []v1.EndpointHealthStatus{{
+			DNSName:         "abc.example.com",
+			SetIdentifier:   "primary",
+			Healthy:         healthy,
+			LastCheckedTime: &lastChecked,
+			Observations: []v1.HealthCheckObservation{
+				{Region: "eu-west-1", Healthy: healthy, LastCheckedTime: &lastChecked},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name string
+		a, b []v1.EndpointHealthStatus
+		want bool
+	}{
+		{name: "no health checks", a: nil, b: []v1.EndpointHealthStatus{}, want: true},
+		{name: "checked again", a: status(true, now), b: status(true, now.Add(-10*time.Second)), want: true},
+		{name: "checked after the refresh interval", a: status(true, now), b: status(true, now.Add(-healthStatusRefreshInterval)), want: false},
+		{name: "health changed", a: status(false, now), b: status(true, now), want: false},
+		{name: "health check added", a: status(true, now), b: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := healthStatusesEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("healthStatusesEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
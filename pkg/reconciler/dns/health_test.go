@@ -0,0 +1,162 @@
+package dns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+func TestReportHealthChecksConfig(t *testing.T) {
+	c := &Controller{Controller: &reconciler.Controller{Logger: logr.Discard()}, dnsProvider: &dns.FakeProvider{}}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantStatus  string
+		wantMessage string
+	}{
+		{name: "no health checks"},
+		{
+			name:        "HTTPS with search string and SNI",
+			annotations: map[string]string{"endpoint": "/healthz", "protocol": "HTTPS", "port": "443", "search-string": "ok", "enable-sni": "true", "request-interval": "10"},
+			wantStatus:  string(ConditionTrue),
+		},
+		{
+			name:        "inverted TCP",
+			annotations: map[string]string{"endpoint": "/", "protocol": "TCP", "inverted": "true"},
+			wantStatus:  string(ConditionTrue),
+		},
+		{
+			name:        "search string with TCP",
+			annotations: map[string]string{"endpoint": "/", "protocol": "TCP", "search-string": "ok"},
+			wantStatus:  string(ConditionFalse),
+			wantMessage: "search-string is only supported by the HTTP and HTTPS protocols",
+		},
+		{
+			name:        "search string too long",
+			annotations: map[string]string{"endpoint": "/", "search-string": strings.Repeat("a", 256)},
+			wantStatus:  string(ConditionFalse),
+			wantMessage: "search-string must be at most 255 characters",
+		},
+		{
+			name:        "unsupported request interval",
+			annotations: map[string]string{"endpoint": "/", "request-interval": "20"},
+			wantStatus:  string(ConditionFalse),
+			wantMessage: "request-interval must be 10 or 30 seconds",
+		},
+		{
+			name:        "SNI with HTTP",
+			annotations: map[string]string{"endpoint": "/", "enable-sni": "true"},
+			wantStatus:  string(ConditionFalse),
+			wantMessage: "enable-sni is only supported by the HTTPS protocol",
+		},
+		{
+			name:        "invalid boolean",
+			annotations: map[string]string{"endpoint": "/", "inverted": "maybe"},
+			wantStatus:  string(ConditionFalse),
+			wantMessage: "invalid value for annotation " + ANNOTATION_HEALTH_CHECK_PREFIX + "inverted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			for k, v := range tt.annotations {
+				annotations[ANNOTATION_HEALTH_CHECK_PREFIX+k] = v
+			}
+			record := &v1.DNSRecord{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+
+			config, err := c.healthChecksConfig(record)
+			statuses := reportHealthChecksConfig(config, err, []v1.DNSZoneStatus{{}})
+			var condition *v1.DNSZoneCondition
+			for i := range statuses[0].Conditions {
+				if statuses[0].Conditions[i].Type == v1.DNSRecordHealthChecksConfiguredConditionType {
+					condition = &statuses[0].Conditions[i]
+				}
+			}
+			if tt.wantStatus == "" {
+				if condition != nil {
+					t.Errorf("reportHealthChecksConfig() condition = %v, want none", condition)
+				}
+				return
+			}
+			if condition == nil {
+				t.Fatalf("reportHealthChecksConfig() condition = nil, want %s", tt.wantStatus)
+			}
+			if condition.Status != tt.wantStatus || !strings.Contains(condition.Message, tt.wantMessage) {
+				t.Errorf("reportHealthChecksConfig() condition = %s %q, want %s %q", condition.Status, condition.Message, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestHealthChecksConfigFromPolicy(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ingresses := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	policies := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{reconciler.ClusterNamespaceIndex: reconciler.ClusterNamespaceIndexFunc})
+	c := &Controller{
+		Controller:               &reconciler.Controller{Logger: logr.Discard()},
+		dnsProvider:              &dns.FakeProvider{},
+		healthCheckPolicyIndexer: policies,
+		ingressIndexer:           ingresses,
+	}
+
+	record := &v1.DNSRecord{ObjectMeta: metav1.ObjectMeta{
+		Name:        "abc",
+		Namespace:   "default",
+		Annotations: map[string]string{ANNOTATION_HEALTH_CHECK_PREFIX + "endpoint": "/annotated"},
+	}}
+	g.Expect(ingresses.Add(&networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: "default", Labels: map[string]string{"app": "abc"}},
+	})).To(gomega.Succeed())
+
+	// The annotations apply without policy
+	config, err := c.healthChecksConfig(record)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config.Endpoint).To(gomega.Equal("/annotated"))
+
+	// The policies of the other workspaces do not apply
+	g.Expect(policies.Add(&v1.HealthCheckPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", ClusterName: "other"},
+		Spec:       v1.HealthCheckPolicySpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "abc"}}},
+	})).To(gomega.Succeed())
+	config, err = c.healthChecksConfig(record)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config.Endpoint).To(gomega.Equal("/annotated"))
+
+	// The policy takes precedence over the annotations
+	https := v1.HealthCheckProtocolHTTPS
+	policy := &v1.HealthCheckPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec: v1.HealthCheckPolicySpec{
+			Selector:        &metav1.LabelSelector{MatchLabels: map[string]string{"app": "abc"}},
+			Protocol:        &https,
+			RequestInterval: aws.Int64(10),
+		},
+	}
+	g.Expect(policies.Add(policy)).To(gomega.Succeed())
+	config, err = c.healthChecksConfig(record)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config.Policy).To(gomega.Equal("policy"))
+	g.Expect(config.Endpoint).To(gomega.Equal(defaultHealthCheckPolicyPath))
+	g.Expect(*config.Protocol).To(gomega.Equal(dns.HealthCheckProtocolHTTPS))
+	g.Expect(*config.RequestInterval).To(gomega.Equal(int64(10)))
+	statuses := reportHealthChecksConfig(config, err, []v1.DNSZoneStatus{{}})
+	g.Expect(statuses[0].Conditions[0].Message).To(gomega.ContainSubstring("HealthCheckPolicy policy"))
+
+	// The invalid policy is reported, rather than falling back to the annotations
+	policy.Spec.SearchString = strings.Repeat("a", 256)
+	_, err = c.healthChecksConfig(record)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("HealthCheckPolicy policy: search-string must be at most 255 characters")))
+}
@@ -0,0 +1,44 @@
+package dns
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kuadrant/kcp-glbc/pkg/metrics"
+)
+
+var (
+	// dnsRecordDriftTotal is a prometheus counter metrics which holds the total
+	// number of times the records published to a zone were found to differ
+	// from their DNSRecord.
+	dnsRecordDriftTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "glbc_dns_record_drift_total",
+			Help: "GLBC total number of DNS records found drifted from their published state",
+		})
+
+	// orphanedHealthChecks is a prometheus gauge metrics which holds the
+	// number of health checks created by GLBC that are no longer referenced by
+	// any DNSRecord.
+	orphanedHealthChecks = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "glbc_dns_orphaned_health_checks",
+			Help: "GLBC number of health checks not referenced by any DNS record",
+		})
+
+	// orphanedHealthCheckDeletedTotal is a prometheus counter metrics which
+	// holds the total number of orphaned health checks deleted.
+	orphanedHealthCheckDeletedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "glbc_dns_orphaned_health_check_deleted_total",
+			Help: "GLBC total number of orphaned health checks deleted",
+		})
+)
+
+func init() {
+	// Register metrics with the global prometheus registry
+	metrics.Registry.MustRegister(
+		dnsRecordDriftTotal,
+		orphanedHealthChecks,
+		orphanedHealthCheckDeletedTotal,
+	)
+}
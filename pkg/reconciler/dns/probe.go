@@ -0,0 +1,157 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// withProbedHealth returns the endpoints to publish given the health of the
+// probed endpoints: the unhealthy weighted endpoints are weighted to 0, and the
+// other unhealthy endpoints are removed, until they recover. The endpoints of a
+// record set are all kept if they are all unhealthy, so that the name still
+// resolves.
+func (c *Controller) withProbedHealth(endpoints []*v1.Endpoint) []*v1.Endpoint {
+	if c.prober == nil {
+		return endpoints
+	}
+
+	unhealthy := map[*v1.Endpoint]bool{}
+	setSizes := map[string]int{}
+	unhealthySetSizes := map[string]int{}
+	for _, endpoint := range endpoints {
+		set := endpoint.DNSName + "/" + endpoint.RecordType
+		setSizes[set]++
+		if healthy, checked, _ := c.prober.Healthy(context.Background(), endpoint); checked && !healthy {
+			unhealthy[endpoint] = true
+			unhealthySetSizes[set]++
+		}
+	}
+	if len(unhealthy) == 0 {
+		return endpoints
+	}
+
+	var result []*v1.Endpoint
+	for _, endpoint := range endpoints {
+		set := endpoint.DNSName + "/" + endpoint.RecordType
+		if !unhealthy[endpoint] || unhealthySetSizes[set] == setSizes[set] {
+			result = append(result, endpoint)
+			continue
+		}
+		if _, weighted := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificWeight); weighted {
+			drained := endpoint.DeepCopy()
+			drained.SetProviderSpecific(dns.ProviderSpecificWeight, "0")
+			result = append(result, drained)
+		}
+	}
+	return result
+}
+
+// probedHealthChanged returns whether the endpoints to publish to the zone
+// differ from the endpoints published to it, as the health of the probed
+// endpoints has changed.
+func (c *Controller) probedHealthChanged(record *v1.DNSRecord, zone v1.DNSZone, endpoints []*v1.Endpoint) bool {
+	if c.prober == nil {
+		return false
+	}
+	for _, status := range record.Status.Zones {
+		if status.DNSZone.ID == zone.ID {
+			return !equality.Semantic.DeepEqual(status.Endpoints, endpoints)
+		}
+	}
+	return false
+}
+
+// reportProbedHealth sets the Healthy condition of the zones the probed
+// endpoints are published to.
+func (c *Controller) reportProbedHealth(record *v1.DNSRecord, statuses []v1.DNSZoneStatus) []v1.DNSZoneStatus {
+	if c.prober == nil {
+		return statuses
+	}
+	for i := range statuses {
+		probed := 0
+		var unhealthy []string
+		// The health of the endpoints of the spec is reported, as the published
+		// unhealthy endpoints may have been removed
+		for _, endpoint := range record.Spec.Endpoints {
+			healthy, checked, _ := c.prober.Healthy(context.Background(), endpoint)
+			if !checked || !c.isPublishedTo(statuses[i].DNSZone, endpoint) {
+				continue
+			}
+			probed++
+			if !healthy {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s %s (%s): %s", endpoint.RecordType, endpoint.DNSName, endpoint.SetID(), c.prober.LastError(endpoint)))
+			}
+		}
+		if probed == 0 {
+			continue
+		}
+
+		condition := v1.DNSZoneCondition{
+			Type:    v1.DNSRecordHealthyConditionType,
+			Status:  string(ConditionTrue),
+			Reason:  "EndpointsHealthy",
+			Message: fmt.Sprintf("The %d probed endpoints are healthy", probed),
+		}
+		if len(unhealthy) > 0 {
+			sort.Strings(unhealthy)
+			condition.Status = string(ConditionFalse)
+			condition.Reason = "EndpointsUnhealthy"
+			condition.Message = fmt.Sprintf("%d of the %d probed endpoints are unhealthy, and not routed to unless all the endpoints of their record set are: %s",
+				len(unhealthy), probed, strings.Join(unhealthy, "; "))
+		}
+		statuses[i].Conditions = setConditions(statuses[i].Conditions, []v1.DNSZoneCondition{condition})
+	}
+	return statuses
+}
+
+// isPublishedTo returns whether the endpoint is published to the zone, i.e.,
+// the zone is the closest to its name.
+func (c *Controller) isPublishedTo(zone v1.DNSZone, endpoint *v1.Endpoint) bool {
+	if zone.DNSName == "" {
+		return true
+	}
+	length := zoneMatchLength(zone, endpoint)
+	return length >= 0 && length == longestZoneMatchLength(c.dnsZones, endpoint)
+}
+
+// enqueueRecordsOf queues the DNSRecords with endpoints of the name of the
+// endpoint, so that they are published as per its health.
+func (c *Controller) enqueueRecordsOf(endpoint *v1.Endpoint) {
+	records, err := c.lister.List(labels.Everything())
+	if err != nil {
+		c.Logger.Error(err, "Failed to list DNSRecords of probed endpoint", "name", endpoint.DNSName)
+		return
+	}
+	for _, record := range records {
+		for _, e := range record.Spec.Endpoints {
+			if e.DNSName == endpoint.DNSName {
+				c.Enqueue(record)
+				break
+			}
+		}
+	}
+}
+
+// retainProbes stops probing the endpoints that are no longer endpoints of any
+// DNSRecord, e.g. as their address changed, or their DNSRecord was deleted
+// without its finalizer being run.
+func (c *Controller) retainProbes(_ context.Context) {
+	records, err := c.lister.List(labels.Everything())
+	if err != nil {
+		c.Logger.Error(err, "Failed to list DNSRecords of probed endpoints")
+		return
+	}
+	var endpoints []*v1.Endpoint
+	for _, record := range records {
+		endpoints = append(endpoints, record.Spec.Endpoints...)
+	}
+	c.prober.Retain(endpoints)
+}
@@ -0,0 +1,114 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+func TestWithProbedHealth(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	healthyPort := portOf(t, server.Listener.Addr())
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	unhealthyPort := portOf(t, closed.Addr())
+	g.Expect(closed.Close()).To(gomega.Succeed())
+
+	endpoint := func(dnsName, setID, weight string) *v1.Endpoint {
+		e := &v1.Endpoint{DNSName: dnsName, RecordType: "A", SetIdentifier: setID, Targets: v1.Targets{"127.0.0.1"}}
+		if weight != "" {
+			e.SetProviderSpecific(dns.ProviderSpecificWeight, weight)
+		}
+		return e
+	}
+	endpoints := []*v1.Endpoint{
+		endpoint("weighted.example.com", "healthy", "120"),
+		endpoint("weighted.example.com", "unhealthy", "120"),
+		endpoint("failover.example.com", "primary", ""),
+		endpoint("failover.example.com", "secondary", ""),
+		endpoint("unhealthy.example.com", "unhealthy", ""),
+		endpoint("unchecked.example.com", "unchecked", ""),
+	}
+
+	prober := dns.NewProber(0, logr.Discard())
+	threshold := int64(1)
+	for _, e := range endpoints[:5] {
+		port := healthyPort
+		if e.SetIdentifier == "unhealthy" || e.SetIdentifier == "primary" {
+			port = unhealthyPort
+		}
+		g.Expect(prober.Reconcile(context.Background(), dns.HealthCheckSpec{Path: "/", Port: &port, FailureThreshold: &threshold}, e)).To(gomega.Succeed())
+	}
+	prober.ProbeAll(context.Background())
+
+	c := &Controller{prober: prober}
+	published := c.withProbedHealth(endpoints)
+
+	var got []string
+	for _, e := range published {
+		weight, _ := e.GetProviderSpecificProperty(dns.ProviderSpecificWeight)
+		got = append(got, e.DNSName+"/"+e.SetIdentifier+"="+weight.Value)
+	}
+	g.Expect(got).To(gomega.Equal([]string{
+		"weighted.example.com/healthy=120",
+		"weighted.example.com/unhealthy=0",
+		"failover.example.com/secondary=",
+		"unhealthy.example.com/unhealthy=",
+		"unchecked.example.com/unchecked=",
+	}))
+	// The endpoints of the spec are left untouched
+	weight, _ := endpoints[1].GetProviderSpecificProperty(dns.ProviderSpecificWeight)
+	g.Expect(weight.Value).To(gomega.Equal("120"))
+}
+
+func portOf(t *testing.T, addr net.Addr) int64 {
+	_, value, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func TestReconcileHealthCheckDeletion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	endpoint := func(setID string) *v1.Endpoint {
+		return &v1.Endpoint{DNSName: "abc.example.com", RecordType: "A", SetIdentifier: setID, Targets: v1.Targets{setID}}
+	}
+	prober := dns.NewProber(0, logr.Discard())
+	for _, setID := range []string{"1.1.1.1", "2.2.2.2"} {
+		g.Expect(prober.Reconcile(context.Background(), dns.HealthCheckSpec{}, endpoint(setID))).To(gomega.Succeed())
+	}
+	// The endpoint of the spec has not been published yet, and the published
+	// endpoint is no longer an endpoint of the spec
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{Endpoints: []*v1.Endpoint{endpoint("2.2.2.2")}},
+		Status: v1.DNSRecordStatus{
+			Zones: []v1.DNSZoneStatus{{DNSZone: v1.DNSZone{ID: "Z1"}, Endpoints: []*v1.Endpoint{endpoint("1.1.1.1")}}},
+		},
+	}
+
+	c := &Controller{dnsProvider: dns.NewProbingProvider(&dns.FakeProvider{}, prober), prober: prober}
+	g.Expect(c.reconcileHealthCheckDeletion(context.Background(), record)).To(gomega.Succeed())
+	for _, setID := range []string{"1.1.1.1", "2.2.2.2"} {
+		_, checked, _ := prober.Healthy(context.Background(), endpoint(setID))
+		g.Expect(checked).To(gomega.BeFalse())
+	}
+	g.Expect(record.Spec.Endpoints).To(gomega.HaveLen(1))
+}
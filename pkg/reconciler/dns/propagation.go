@@ -0,0 +1,79 @@
+package dns
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+// propagationCheckInterval is the interval at which the propagation of the
+// changes to the records is checked, until they are in sync.
+const propagationCheckInterval = 10 * time.Second
+
+func pendingPropagationCondition() v1.DNSZoneCondition {
+	return v1.DNSZoneCondition{
+		Type:    v1.DNSRecordPropagatedConditionType,
+		Status:  string(ConditionFalse),
+		Reason:  "Pending",
+		Message: "The records are propagating to the name servers of the zone",
+	}
+}
+
+// checkPropagation returns the Propagated condition of the records published
+// to the zone, if their propagation is pending.
+func (c *Controller) checkPropagation(record *v1.DNSRecord, zone v1.DNSZone) (v1.DNSZoneCondition, bool) {
+	if c.propagationTracker == nil || !propagationIsPending(record, zone) {
+		return v1.DNSZoneCondition{}, false
+	}
+	propagated, err := c.propagationTracker.Propagated(record, zone)
+	if err != nil {
+		c.Logger.Error(err, "Failed to check the propagation of the DNS record", "record", record, "zone", zone)
+		condition := pendingPropagationCondition()
+		condition.Message = fmt.Sprintf("The propagation of the records could not be checked: %v", err)
+		return condition, true
+	}
+	if !propagated {
+		return pendingPropagationCondition(), true
+	}
+	return v1.DNSZoneCondition{
+		Type:    v1.DNSRecordPropagatedConditionType,
+		Status:  string(ConditionTrue),
+		Reason:  "InSync",
+		Message: "The records have propagated to all the name servers of the zone",
+	}, true
+}
+
+// requeueUnpropagated queues the DNSRecord again after a delay, if the
+// propagation of its records is pending in any zone, so that it is checked
+// without blocking the workers.
+func (c *Controller) requeueUnpropagated(record *v1.DNSRecord) {
+	for _, status := range record.Status.Zones {
+		if !propagationIsPending(record, status.DNSZone) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(record)
+		if err != nil {
+			c.Logger.Error(err, "Failed to get DNSRecord key")
+			return
+		}
+		c.Queue.AddAfter(key, propagationCheckInterval)
+		return
+	}
+}
+
+func propagationIsPending(record *v1.DNSRecord, zone v1.DNSZone) bool {
+	for _, status := range record.Status.Zones {
+		if status.DNSZone.ID != zone.ID {
+			continue
+		}
+		for _, condition := range status.Conditions {
+			if condition.Type == v1.DNSRecordPropagatedConditionType {
+				return condition.Status == string(ConditionFalse)
+			}
+		}
+	}
+	return false
+}
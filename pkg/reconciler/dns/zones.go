@@ -0,0 +1,127 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// discoverZones returns the zones configured by ID, and the zones with all the
+// configured tags. The domains of the zones are looked up, if the provider
+// supports it, so that records are only published to the matching zones.
+func discoverZones(provider dns.Provider, ids []string, tags map[string]string) ([]v1.DNSZone, error) {
+	discoverer, canDiscover := provider.(dns.ZoneDiscoverer)
+
+	var zones []v1.DNSZone
+	for _, id := range ids {
+		if !canDiscover {
+			zones = append(zones, v1.DNSZone{ID: id})
+			continue
+		}
+		zone, err := discoverer.GetZone(id)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	if len(tags) > 0 {
+		if !canDiscover {
+			return nil, fmt.Errorf("the DNS provider does not support zone discovery from tags")
+		}
+		discovered, err := discoverer.DiscoverZones(tags)
+		if err != nil {
+			return nil, err
+		}
+		if len(discovered) == 0 {
+			return nil, fmt.Errorf("no DNS zone found with tags %v", tags)
+		}
+		for _, zone := range discovered {
+			if !containsZone(zones, zone) {
+				zones = append(zones, zone)
+			}
+		}
+	}
+
+	return zones, nil
+}
+
+// recordsForZones returns, for each zone the record must be published to, a copy
+// of the record holding the endpoints that belong to the zone. An endpoint
+// belongs to the zone(s) whose domain is the longest suffix of its DNS name,
+// and, unless it is the endpoint of a custom host, to the zones without domain.
+func recordsForZones(zones []v1.DNSZone, record *v1.DNSRecord) ([]v1.DNSZone, []*v1.DNSRecord) {
+	var selectedZones []v1.DNSZone
+	var records []*v1.DNSRecord
+	for _, zone := range zones {
+		var endpoints []*v1.Endpoint
+		for _, endpoint := range record.Spec.Endpoints {
+			if zone.DNSName == "" {
+				// A zone without domain is not known to be authoritative for the custom hosts
+				if endpoint.Labels[v1.CustomHostLabel] == "" {
+					endpoints = append(endpoints, endpoint)
+				}
+			} else if length := zoneMatchLength(zone, endpoint); length >= 0 && length == longestZoneMatchLength(zones, endpoint) {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		if zone.DNSName != "" && len(endpoints) == 0 {
+			continue
+		}
+		selectedZones = append(selectedZones, zone)
+		records = append(records, recordWithEndpoints(record, endpoints))
+	}
+	return selectedZones, records
+}
+
+// zoneMatchLength returns the length of the zone domain if it is a suffix of
+// the endpoint DNS name, or -1 otherwise.
+func zoneMatchLength(zone v1.DNSZone, endpoint *v1.Endpoint) int {
+	dnsName := strings.ToLower(strings.TrimSuffix(endpoint.DNSName, "."))
+	zoneName := strings.ToLower(strings.TrimSuffix(zone.DNSName, "."))
+	if zoneName == "" || (dnsName != zoneName && !strings.HasSuffix(dnsName, "."+zoneName)) {
+		return -1
+	}
+	return len(zoneName)
+}
+
+func longestZoneMatchLength(zones []v1.DNSZone, endpoint *v1.Endpoint) int {
+	longest := -1
+	for _, zone := range zones {
+		if length := zoneMatchLength(zone, endpoint); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
+
+// recordWithEndpoints returns a copy of the record with the given endpoints.
+func recordWithEndpoints(record *v1.DNSRecord, endpoints []*v1.Endpoint) *v1.DNSRecord {
+	copy := record.DeepCopy()
+	copy.Spec.Endpoints = nil
+	for _, endpoint := range endpoints {
+		copy.Spec.Endpoints = append(copy.Spec.Endpoints, endpoint.DeepCopy())
+	}
+	return copy
+}
+
+func containsZone(zones []v1.DNSZone, zone v1.DNSZone) bool {
+	for _, z := range zones {
+		if z.ID == zone.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasZoneStatus returns whether the record has a status for the zone.
+func hasZoneStatus(record *v1.DNSRecord, zoneID string) bool {
+	for _, status := range record.Status.Zones {
+		if status.DNSZone.ID == zoneID {
+			return true
+		}
+	}
+	return false
+}
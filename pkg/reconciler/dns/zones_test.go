@@ -0,0 +1,140 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+type zoneDiscoverer struct {
+	dns.FakeProvider
+	zones []v1.DNSZone
+}
+
+var _ dns.ZoneDiscoverer = &zoneDiscoverer{}
+
+func (d *zoneDiscoverer) GetZone(id string) (v1.DNSZone, error) {
+	for _, zone := range d.zones {
+		if zone.ID == id {
+			return zone, nil
+		}
+	}
+	return v1.DNSZone{ID: id}, nil
+}
+
+func (d *zoneDiscoverer) DiscoverZones(tags map[string]string) ([]v1.DNSZone, error) {
+	var zones []v1.DNSZone
+	for _, zone := range d.zones {
+		if zone.Tags["env"] == tags["env"] {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func TestDiscoverZones(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	provider := &zoneDiscoverer{zones: []v1.DNSZone{
+		{ID: "Z1", DNSName: "example.com", Tags: map[string]string{"env": "prod"}},
+		{ID: "Z2", DNSName: "eu.example.com", Tags: map[string]string{"env": "prod"}},
+		{ID: "Z3", DNSName: "example.org", Tags: map[string]string{"env": "test"}},
+	}}
+
+	zones, err := discoverZones(provider, []string{"Z3", "Z1"}, map[string]string{"env": "prod"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(zones).To(gomega.Equal([]v1.DNSZone{provider.zones[2], provider.zones[0], provider.zones[1]}))
+
+	_, err = discoverZones(provider, nil, map[string]string{"env": "dev"})
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// Providers that cannot discover zones only support zone IDs
+	zones, err = discoverZones(&dns.FakeProvider{}, []string{"Z1"}, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(zones).To(gomega.Equal([]v1.DNSZone{{ID: "Z1"}}))
+	_, err = discoverZones(&dns.FakeProvider{}, nil, map[string]string{"env": "prod"})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestRecordsForZones(t *testing.T) {
+	endpoint := func(dnsName string) *v1.Endpoint {
+		return &v1.Endpoint{DNSName: dnsName, RecordType: "A", Targets: v1.Targets{"1.1.1.1"}}
+	}
+	record := &v1.DNSRecord{
+		Spec: v1.DNSRecordSpec{
+			Endpoints: []*v1.Endpoint{
+				endpoint("abc.example.com"),
+				endpoint("abc.eu.example.com"),
+				endpoint("eu.example.com"),
+				endpoint("abc.example.net"),
+				{DNSName: "api.example.org", RecordType: "CNAME", Targets: v1.Targets{"abc.example.com"}, Labels: v1.Labels{v1.CustomHostLabel: "true"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		zones     []v1.DNSZone
+		expected  []string
+		endpoints [][]string
+	}{
+		{
+			name:      "zone without domain",
+			zones:     []v1.DNSZone{{ID: "Z0"}},
+			expected:  []string{"Z0"},
+			endpoints: [][]string{{"abc.example.com", "abc.eu.example.com", "eu.example.com", "abc.example.net"}},
+		},
+		{
+			name: "longest suffix",
+			zones: []v1.DNSZone{
+				{ID: "Z1", DNSName: "example.com"},
+				{ID: "Z2", DNSName: "eu.example.com"},
+				{ID: "Z3", DNSName: "example.org"},
+			},
+			expected:  []string{"Z1", "Z2", "Z3"},
+			endpoints: [][]string{{"abc.example.com"}, {"abc.eu.example.com", "eu.example.com"}, {"api.example.org"}},
+		},
+		{
+			name: "zones with the same domain",
+			zones: []v1.DNSZone{
+				{ID: "public", DNSName: "example.net"},
+				{ID: "private", DNSName: "example.net."},
+			},
+			expected:  []string{"public", "private"},
+			endpoints: [][]string{{"abc.example.net"}, {"abc.example.net"}},
+		},
+		{
+			name:      "custom host",
+			zones:     []v1.DNSZone{{ID: "Z0"}, {ID: "Z3", DNSName: "example.org"}},
+			expected:  []string{"Z0", "Z3"},
+			endpoints: [][]string{{"abc.example.com", "abc.eu.example.com", "eu.example.com", "abc.example.net"}, {"api.example.org"}},
+		},
+		{
+			name:  "no matching zone",
+			zones: []v1.DNSZone{{ID: "Z4", DNSName: "example.io"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			zones, records := recordsForZones(tt.zones, record)
+			g.Expect(zones).To(gomega.HaveLen(len(tt.expected)))
+			for i, zone := range zones {
+				g.Expect(zone.ID).To(gomega.Equal(tt.expected[i]))
+				var dnsNames []string
+				for _, endpoint := range records[i].Spec.Endpoints {
+					dnsNames = append(dnsNames, endpoint.DNSName)
+				}
+				g.Expect(dnsNames).To(gomega.Equal(tt.endpoints[i]))
+			}
+		})
+	}
+
+	// The record is not modified
+	g := gomega.NewWithT(t)
+	g.Expect(record.Spec.Endpoints).To(gomega.HaveLen(5))
+}
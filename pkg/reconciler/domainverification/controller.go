@@ -0,0 +1,96 @@
+package domainverification
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clusters"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	kuadrantv1 "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/clientset/versioned"
+	"github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/informers/externalversions"
+	"github.com/kuadrant/kcp-glbc/pkg/net"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+const controllerName = "kcp-glbc-domain-verification"
+
+// NewController returns a new Controller which reconciles DomainVerification.
+func NewController(config *ControllerConfig) (*Controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), controllerName)
+	c := &Controller{
+		Controller:               reconciler.NewController(controllerName, queue),
+		domainVerificationClient: config.DomainVerificationClient,
+		sharedInformerFactory:    config.SharedInformerFactory,
+		hostResolver:             config.HostResolver,
+		verifiedDomains:          config.VerifiedDomains,
+		secret:                   config.Secret,
+	}
+	c.Process = c.process
+
+	c.sharedInformerFactory.Kuadrant().V1().DomainVerifications().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.Enqueue(obj) },
+		UpdateFunc: func(old, obj interface{}) {
+			if old.(*v1.DomainVerification).ResourceVersion != obj.(*v1.DomainVerification).ResourceVersion {
+				c.Enqueue(obj)
+			}
+		},
+		// Forget the domain of the deleted verifications
+		DeleteFunc: func(obj interface{}) { c.Enqueue(obj) },
+	})
+
+	c.indexer = c.sharedInformerFactory.Kuadrant().V1().DomainVerifications().Informer().GetIndexer()
+
+	return c, nil
+}
+
+type ControllerConfig struct {
+	DomainVerificationClient kuadrantv1.ClusterInterface
+	SharedInformerFactory    externalversions.SharedInformerFactory
+	HostResolver             net.HostResolver
+	VerifiedDomains          *VerifiedDomains
+	// Secret salts the verification tokens, so that they cannot be derived
+	// from the workspace and the domain only.
+	Secret string
+}
+
+type Controller struct {
+	*reconciler.Controller
+	sharedInformerFactory    externalversions.SharedInformerFactory
+	domainVerificationClient kuadrantv1.ClusterInterface
+	indexer                  cache.Indexer
+	hostResolver             net.HostResolver
+	verifiedDomains          *VerifiedDomains
+	secret                   string
+}
+
+func (c *Controller) process(ctx context.Context, key string) error {
+	object, exists, err := c.indexer.GetByKey(key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		cluster, name := clusters.SplitClusterAwareKey(key)
+		return c.verifiedDomains.remove(ctx, cluster, name)
+	}
+
+	current := object.(*v1.DomainVerification)
+	target := current.DeepCopy()
+
+	if err = c.reconcile(ctx, key, target); err != nil {
+		return err
+	}
+
+	if !equality.Semantic.DeepEqual(current.Status, target.Status) {
+		_, err := c.domainVerificationClient.Cluster(logicalcluster.From(target)).KuadrantV1().DomainVerifications().UpdateStatus(ctx, target, metav1.UpdateOptions{})
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package domainverification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/net"
+	"github.com/kuadrant/kcp-glbc/pkg/util/slice"
+)
+
+const (
+	// recordPrefix is prepended to the domain to form the name of the TXT
+	// record holding the verification token.
+	recordPrefix = "_kuadrant-verification"
+	// recheckInterval is the interval between two verifications of a domain
+	// that is not verified yet.
+	recheckInterval = 5 * time.Minute
+	// verifiedRecheckInterval is the interval between two verifications of a
+	// verified domain, so that the domains whose TXT record is removed, e.g.,
+	// once transferred, are no longer trusted.
+	verifiedRecheckInterval = time.Hour
+)
+
+// Token returns the verification token of the domain for the workspace. The
+// token is derived from both, so that a TXT record only proves the ownership
+// of the domain by a single workspace, and salted with the secret of the
+// deployment, so that it cannot be computed before being handed out.
+func Token(secret string, cluster logicalcluster.Name, domain string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(cluster.String() + "/" + normalizeDomain(domain)))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// RecordName returns the name of the TXT record holding the verification token
+// of the domain.
+func RecordName(domain string) string {
+	return fmt.Sprintf("%s.%s", recordPrefix, normalizeDomain(domain))
+}
+
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
+
+func (c *Controller) reconcile(ctx context.Context, key string, verification *v1.DomainVerification) error {
+	token := Token(c.secret, logicalcluster.From(verification), verification.Spec.Domain)
+	recordName := RecordName(verification.Spec.Domain)
+
+	// The domain or the secret has changed, so its ownership must be verified again
+	if verification.Status.Token != token || verification.Status.RecordName != recordName {
+		verification.Status = v1.DomainVerificationStatus{
+			Token:      token,
+			RecordName: recordName,
+		}
+	}
+
+	// The status is only trusted if it matches the domains verified by GLBC,
+	// otherwise the ownership is checked right away
+	if verified := c.verifiedDomains.IsVerified(verification); verification.Status.Verified != verified {
+		verification.Status.Verified = verified
+		verification.Status.NextCheck = nil
+	}
+
+	// Wait for the next check, as updating the status re-queues the verification
+	if next := verification.Status.NextCheck; next != nil && time.Until(next.Time) > 0 {
+		c.Queue.AddAfter(key, time.Until(next.Time))
+		return nil
+	}
+
+	now := metav1.Now()
+	verification.Status.LastChecked = &now
+
+	interval := recheckInterval
+	txt, err := c.hostResolver.LookupTXT(ctx, recordName)
+	switch {
+	case err == nil && slice.ContainsString(txt, token):
+		if !verification.Status.Verified {
+			c.Logger.Info("Domain verified", "cluster", logicalcluster.From(verification), "domain", verification.Spec.Domain)
+		}
+		verification.Status.Verified = true
+		verification.Status.Message = "domain successfully verified"
+		interval = verifiedRecheckInterval
+	case err != nil && !errors.Is(err, net.ErrNoRecords):
+		// The ownership of a verified domain is kept until the TXT record is
+		// found not to contain the token
+		verification.Status.Message = fmt.Sprintf("failed to look up TXT record %s: %v", recordName, err)
+	default:
+		if verification.Status.Verified {
+			c.Logger.Info("Domain no longer verified", "cluster", logicalcluster.From(verification), "domain", verification.Spec.Domain)
+		}
+		verification.Status.Verified = false
+		verification.Status.Message = fmt.Sprintf("TXT record %s does not contain the verification token", recordName)
+	}
+	if err := c.verifiedDomains.set(ctx, verification, verification.Status.Verified); err != nil {
+		return err
+	}
+
+	next := metav1.NewTime(now.Add(interval))
+	verification.Status.NextCheck = &next
+	c.Queue.AddAfter(key, interval)
+	return nil
+}
@@ -0,0 +1,107 @@
+package domainverification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/net"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+type txtResolver struct {
+	net.HostResolver
+	records map[string][]string
+}
+
+func (r *txtResolver) LookupTXT(_ context.Context, host string) ([]string, error) {
+	txt, ok := r.records[host]
+	if !ok {
+		return nil, net.ErrNoRecords
+	}
+	return txt, nil
+}
+
+func TestReconcile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	verifiedDomains := NewVerifiedDomains(client, informerFactory, "kcp-glbc")
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+
+	resolver := &txtResolver{records: map[string][]string{}}
+	c := &Controller{
+		Controller:      reconciler.NewController(controllerName, workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())),
+		hostResolver:    resolver,
+		verifiedDomains: verifiedDomains,
+		secret:          "secret",
+	}
+	defer c.Queue.ShutDown()
+
+	verification := &v1.DomainVerification{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.com", ClusterName: "root:org:ws"},
+		Spec:       v1.DomainVerificationSpec{Domain: "Example.com."},
+	}
+	token := Token("secret", logicalcluster.New("root:org:ws"), "example.com")
+	g.Expect(token).NotTo(gomega.Equal(Token("secret", logicalcluster.New("root:org:other"), "example.com")))
+	g.Expect(token).NotTo(gomega.Equal(Token("other", logicalcluster.New("root:org:ws"), "example.com")))
+
+	// The token is not published yet
+	g.Expect(c.reconcile(ctx, "example.com", verification)).To(gomega.Succeed())
+	g.Expect(verification.Status.Token).To(gomega.Equal(token))
+	g.Expect(verification.Status.RecordName).To(gomega.Equal("_kuadrant-verification.example.com"))
+	g.Expect(verification.Status.Verified).To(gomega.BeFalse())
+	g.Expect(verification.Status.NextCheck).NotTo(gomega.BeNil())
+
+	// The domain is not checked again before the next check
+	resolver.records["_kuadrant-verification.example.com"] = []string{"other", token}
+	g.Expect(c.reconcile(ctx, "example.com", verification)).To(gomega.Succeed())
+	g.Expect(verification.Status.Verified).To(gomega.BeFalse())
+
+	verification.Status.NextCheck = nil
+	g.Expect(c.reconcile(ctx, "example.com", verification)).To(gomega.Succeed())
+	g.Expect(verification.Status.Verified).To(gomega.BeTrue())
+	g.Expect(verification.Status.NextCheck.Time).To(gomega.BeTemporally("~", time.Now().Add(verifiedRecheckInterval), time.Minute))
+	g.Eventually(func() bool { return verifiedDomains.IsVerified(verification) }).Should(gomega.BeTrue())
+
+	// A verification whose status is written in the workspace is checked right away
+	forged := &v1.DomainVerification{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.net", ClusterName: "root:org:ws"},
+		Spec:       v1.DomainVerificationSpec{Domain: "example.net"},
+	}
+	forged.Status = v1.DomainVerificationStatus{
+		Token:      Token("secret", logicalcluster.New("root:org:ws"), "example.net"),
+		RecordName: "_kuadrant-verification.example.net",
+		Verified:   true,
+		NextCheck:  &metav1.Time{Time: time.Now().Add(time.Hour)},
+	}
+	g.Expect(c.reconcile(ctx, "example.net", forged)).To(gomega.Succeed())
+	g.Expect(forged.Status.Verified).To(gomega.BeFalse())
+	g.Expect(verifiedDomains.IsVerified(forged)).To(gomega.BeFalse())
+
+	// The verified domain is checked again, and no longer verified once its TXT record is removed
+	delete(resolver.records, "_kuadrant-verification.example.com")
+	verification.Status.NextCheck = nil
+	g.Expect(c.reconcile(ctx, "example.com", verification)).To(gomega.Succeed())
+	g.Expect(verification.Status.Verified).To(gomega.BeFalse())
+	g.Eventually(func() bool { return verifiedDomains.IsVerified(verification) }).Should(gomega.BeFalse())
+
+	// Changing the domain resets the verification
+	verification.Spec.Domain = "example.org"
+	g.Expect(c.reconcile(ctx, "example.com", verification)).To(gomega.Succeed())
+	g.Expect(verification.Status.Verified).To(gomega.BeFalse())
+	g.Expect(verification.Status.RecordName).To(gomega.Equal("_kuadrant-verification.example.org"))
+}
@@ -0,0 +1,149 @@
+package domainverification
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+// verifiedDomainsConfigMap is the name of the ConfigMap recording the verified
+// domains, in the GLBC namespace.
+const verifiedDomainsConfigMap = "kcp-glbc-verified-domains"
+
+// VerifiedDomains records the domains whose ownership has been verified by
+// GLBC, in a ConfigMap of the GLBC namespace. The custom hosts are trusted
+// from this record, rather than from the status of the DomainVerifications,
+// which the workspaces can write.
+type VerifiedDomains struct {
+	client    kubernetes.Interface
+	informer  cache.SharedIndexInformer
+	lister    corev1listers.ConfigMapLister
+	namespace string
+}
+
+func NewVerifiedDomains(client kubernetes.Interface, informerFactory informers.SharedInformerFactory, namespace string) *VerifiedDomains {
+	return &VerifiedDomains{
+		client:    client,
+		informer:  informerFactory.Core().V1().ConfigMaps().Informer(),
+		lister:    informerFactory.Core().V1().ConfigMaps().Lister(),
+		namespace: namespace,
+	}
+}
+
+// IsVerified returns whether GLBC has verified the ownership of the domain of
+// the verification.
+func (v *VerifiedDomains) IsVerified(verification *v1.DomainVerification) bool {
+	configMap, err := v.lister.ConfigMaps(v.namespace).Get(verifiedDomainsConfigMap)
+	if err != nil {
+		return false
+	}
+	domain, ok := configMap.Data[entryKey(verification)]
+	return ok && domain == normalizeDomain(verification.Spec.Domain)
+}
+
+// AddEventHandler calls the handler with the workspaces whose verified domains
+// change.
+func (v *VerifiedDomains) AddEventHandler(handler func(cluster logicalcluster.Name)) {
+	v.informer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			configMap, ok := obj.(*corev1.ConfigMap)
+			return ok && configMap.Name == verifiedDomainsConfigMap
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				for cluster := range changedClusters(nil, obj.(*corev1.ConfigMap).Data) {
+					handler(cluster)
+				}
+			},
+			UpdateFunc: func(old, obj interface{}) {
+				for cluster := range changedClusters(old.(*corev1.ConfigMap).Data, obj.(*corev1.ConfigMap).Data) {
+					handler(cluster)
+				}
+			},
+		},
+	})
+}
+
+// set records whether the domain of the verification is verified.
+func (v *VerifiedDomains) set(ctx context.Context, verification *v1.DomainVerification, verified bool) error {
+	key := entryKey(verification)
+	domain := normalizeDomain(verification.Spec.Domain)
+	configMap, err := v.lister.ConfigMaps(v.namespace).Get(verifiedDomainsConfigMap)
+	if k8serrors.IsNotFound(err) {
+		if !verified {
+			return nil
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: verifiedDomainsConfigMap, Namespace: v.namespace},
+			Data:       map[string]string{key: domain},
+		}
+		_, err = v.client.CoreV1().ConfigMaps(v.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	current, ok := configMap.Data[key]
+	if verified && ok && current == domain || !verified && !ok {
+		return nil
+	}
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	if verified {
+		configMap.Data[key] = domain
+	} else {
+		delete(configMap.Data, key)
+	}
+	_, err = v.client.CoreV1().ConfigMaps(v.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// remove forgets the domain of the deleted verification of the workspace.
+func (v *VerifiedDomains) remove(ctx context.Context, cluster logicalcluster.Name, name string) error {
+	verification := &v1.DomainVerification{ObjectMeta: metav1.ObjectMeta{Name: name, ClusterName: cluster.String()}}
+	return v.set(ctx, verification, false)
+}
+
+// entryKey returns the key of the verification in the ConfigMap. The colons of
+// the workspace, which are not valid in keys, are replaced with underscores,
+// which are not valid in workspace names.
+func entryKey(verification *v1.DomainVerification) string {
+	return strings.ReplaceAll(logicalcluster.From(verification).String(), ":", "_") + "." + verification.Name
+}
+
+func clusterOf(key string) logicalcluster.Name {
+	cluster := strings.SplitN(key, ".", 2)[0]
+	return logicalcluster.New(strings.ReplaceAll(cluster, "_", ":"))
+}
+
+func changedClusters(old, new map[string]string) map[logicalcluster.Name]struct{} {
+	clusters := map[logicalcluster.Name]struct{}{}
+	for key, domain := range new {
+		if old[key] != domain {
+			clusters[clusterOf(key)] = struct{}{}
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			clusters[clusterOf(key)] = struct{}{}
+		}
+	}
+	return clusters
+}
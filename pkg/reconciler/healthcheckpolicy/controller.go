@@ -0,0 +1,133 @@
+package healthcheckpolicy
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	kuadrantv1 "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/clientset/versioned"
+	"github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/informers/externalversions"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+const controllerName = "kcp-glbc-health-check-policy"
+
+// NewController returns a new Controller which reconciles HealthCheckPolicy.
+func NewController(config *ControllerConfig) (*Controller, error) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), controllerName)
+	c := &Controller{
+		Controller:            reconciler.NewController(controllerName, queue),
+		policyClient:          config.HealthCheckPolicyClient,
+		sharedInformerFactory: config.SharedInformerFactory,
+	}
+	c.Process = c.process
+
+	// The policies and Ingresses are looked up by workspace and namespace
+	for _, informer := range []cache.SharedIndexInformer{
+		c.sharedInformerFactory.Kuadrant().V1().HealthCheckPolicies().Informer(),
+		config.KubeSharedInformerFactory.Networking().V1().Ingresses().Informer(),
+	} {
+		if err := reconciler.AddClusterNamespaceIndex(informer); err != nil {
+			return nil, err
+		}
+	}
+
+	// The precedence between the policies of a namespace changes as any of them
+	// is created, deleted or targets other Ingresses
+	c.sharedInformerFactory.Kuadrant().V1().HealthCheckPolicies().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueuePoliciesOfNamespace(obj) },
+		UpdateFunc: func(old, obj interface{}) {
+			if old.(*v1.HealthCheckPolicy).Generation != obj.(*v1.HealthCheckPolicy).Generation {
+				c.enqueuePoliciesOfNamespace(obj)
+			} else if old.(*v1.HealthCheckPolicy).ResourceVersion != obj.(*v1.HealthCheckPolicy).ResourceVersion {
+				c.Enqueue(obj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueuePoliciesOfNamespace(obj) },
+	})
+
+	config.KubeSharedInformerFactory.Networking().V1().Ingresses().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { c.enqueuePoliciesOfNamespace(obj) },
+		UpdateFunc: func(old, obj interface{}) {
+			if !equality.Semantic.DeepEqual(old.(*networkingv1.Ingress).Labels, obj.(*networkingv1.Ingress).Labels) {
+				c.enqueuePoliciesOfNamespace(obj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) { c.enqueuePoliciesOfNamespace(obj) },
+	})
+
+	c.indexer = c.sharedInformerFactory.Kuadrant().V1().HealthCheckPolicies().Informer().GetIndexer()
+	c.ingressIndexer = config.KubeSharedInformerFactory.Networking().V1().Ingresses().Informer().GetIndexer()
+
+	return c, nil
+}
+
+type ControllerConfig struct {
+	HealthCheckPolicyClient   kuadrantv1.ClusterInterface
+	SharedInformerFactory     externalversions.SharedInformerFactory
+	KubeSharedInformerFactory informers.SharedInformerFactory
+}
+
+type Controller struct {
+	*reconciler.Controller
+	sharedInformerFactory externalversions.SharedInformerFactory
+	policyClient          kuadrantv1.ClusterInterface
+	indexer               cache.Indexer
+	ingressIndexer        cache.Indexer
+}
+
+func (c *Controller) process(ctx context.Context, key string) error {
+	object, exists, err := c.indexer.GetByKey(key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	current := object.(*v1.HealthCheckPolicy)
+	target := current.DeepCopy()
+
+	if err = c.reconcile(ctx, target); err != nil {
+		return err
+	}
+
+	if !equality.Semantic.DeepEqual(current.Status, target.Status) {
+		_, err := c.policyClient.Cluster(logicalcluster.From(target)).KuadrantV1().HealthCheckPolicies(target.Namespace).UpdateStatus(ctx, target, metav1.UpdateOptions{})
+		return err
+	}
+
+	return nil
+}
+
+// enqueuePoliciesOfNamespace enqueues the policies of the workspace and
+// namespace of the object.
+func (c *Controller) enqueuePoliciesOfNamespace(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	policies, err := reconciler.ByClusterNamespace(c.indexer, object)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, policy := range policies {
+		c.Enqueue(policy)
+	}
+}
@@ -0,0 +1,153 @@
+package healthcheckpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kcp-dev/logicalcluster"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+// Validate returns why the spec of a policy is invalid, if so. It complements
+// the validation of the schema with the constraints between the fields.
+func Validate(spec v1.HealthCheckPolicySpec) error {
+	if (spec.TargetRef == nil) == (spec.Selector == nil) {
+		return errors.New("exactly one of targetRef and selector must be set")
+	}
+	if spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.Selector); err != nil {
+			return fmt.Errorf("invalid selector: %v", err)
+		}
+	}
+
+	protocol := v1.HealthCheckProtocolHTTP
+	if spec.Protocol != nil {
+		protocol = *spec.Protocol
+	}
+	if spec.SearchString != "" && protocol == v1.HealthCheckProtocolTCP {
+		return errors.New("searchString is only supported by the HTTP and HTTPS protocols")
+	}
+	if spec.EnableSNI != nil && *spec.EnableSNI && protocol != v1.HealthCheckProtocolHTTPS {
+		return fmt.Errorf("enableSNI is only supported by the HTTPS protocol, got %s", protocol)
+	}
+	return nil
+}
+
+// Targets returns whether the policy targets the Ingress, by name or by labels.
+// A policy whose targets are invalid targets no Ingress.
+func Targets(policy *v1.HealthCheckPolicy, ingress metav1.Object) bool {
+	if logicalcluster.From(policy) != logicalcluster.From(ingress) || policy.Namespace != ingress.GetNamespace() {
+		return false
+	}
+	spec := policy.Spec
+	switch {
+	case spec.TargetRef != nil && spec.Selector == nil:
+		return spec.TargetRef.Name == ingress.GetName()
+	case spec.Selector != nil && spec.TargetRef == nil:
+		selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+		return err == nil && selector.Matches(labels.Set(ingress.GetLabels()))
+	}
+	return false
+}
+
+// Effective returns the policy that applies to the Ingress among the policies,
+// or nil if none targets it. The policies targeting the Ingress by name take
+// precedence over the ones selecting it by labels, then the oldest policies,
+// then the first by name.
+func Effective(policies []*v1.HealthCheckPolicy, ingress metav1.Object) *v1.HealthCheckPolicy {
+	var effective *v1.HealthCheckPolicy
+	for _, policy := range policies {
+		if Targets(policy, ingress) && (effective == nil || precedes(policy, effective)) {
+			effective = policy
+		}
+	}
+	return effective
+}
+
+func precedes(a, b *v1.HealthCheckPolicy) bool {
+	if (a.Spec.TargetRef != nil) != (b.Spec.TargetRef != nil) {
+		return a.Spec.TargetRef != nil
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+func (c *Controller) reconcile(_ context.Context, policy *v1.HealthCheckPolicy) error {
+	policy.Status.ObservedGeneration = policy.Generation
+
+	accepted := metav1.Condition{
+		Type:               v1.HealthCheckPolicyAcceptedConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Valid",
+		Message:            "The policy is valid",
+		ObservedGeneration: policy.Generation,
+	}
+	if err := Validate(policy.Spec); err != nil {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = "Invalid"
+		accepted.Message = fmt.Sprintf("The policy is invalid: %v", err)
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, accepted)
+
+	ingresses, err := reconciler.ByClusterNamespace(c.ingressIndexer, policy)
+	if err != nil {
+		return err
+	}
+	objects, err := reconciler.ByClusterNamespace(c.indexer, policy)
+	if err != nil {
+		return err
+	}
+	policies := make([]*v1.HealthCheckPolicy, 0, len(objects))
+	for _, object := range objects {
+		policies = append(policies, object.(*v1.HealthCheckPolicy))
+	}
+
+	var enforced, overridden []string
+	for _, object := range ingresses {
+		ingress := object.(*networkingv1.Ingress)
+		if !Targets(policy, ingress) {
+			continue
+		}
+		if effective := Effective(policies, ingress); effective == nil || effective.Name == policy.Name {
+			enforced = append(enforced, ingress.Name)
+		} else {
+			overridden = append(overridden, fmt.Sprintf("%s (by %s)", ingress.Name, effective.Name))
+		}
+	}
+	sort.Strings(enforced)
+	sort.Strings(overridden)
+
+	condition := metav1.Condition{
+		Type:               v1.HealthCheckPolicyEnforcedConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Enforced",
+		Message:            fmt.Sprintf("The policy applies to the Ingresses: %s", strings.Join(enforced, ", ")),
+		ObservedGeneration: policy.Generation,
+	}
+	switch {
+	case len(enforced) > 0:
+	case len(overridden) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Overridden"
+		condition.Message = fmt.Sprintf("The Ingresses targeted by the policy are targeted by policies of higher precedence: %s", strings.Join(overridden, ", "))
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoTargets"
+		condition.Message = "The policy does not target any Ingress"
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, condition)
+
+	return nil
+}
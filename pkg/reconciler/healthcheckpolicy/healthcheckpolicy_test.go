@@ -0,0 +1,119 @@
+package healthcheckpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/gomega"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+)
+
+func TestEffective(t *testing.T) {
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := func(name string, age time.Duration, spec v1.HealthCheckPolicySpec) *v1.HealthCheckPolicy {
+		return &v1.HealthCheckPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ClusterName: "root:org:ws", CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec:       spec,
+		}
+	}
+	byName := v1.HealthCheckPolicySpec{TargetRef: &v1.HealthCheckPolicyTargetReference{Name: "abc"}}
+	byLabels := v1.HealthCheckPolicySpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "abc"}}}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: "default", ClusterName: "root:org:ws", Labels: map[string]string{"app": "abc"}},
+	}
+
+	otherNamespace := policy("other-namespace", time.Hour, byName)
+	otherNamespace.Namespace = "other"
+	otherCluster := policy("other-cluster", time.Hour, byName)
+	otherCluster.ClusterName = "root:org:other"
+
+	tests := []struct {
+		name     string
+		policies []*v1.HealthCheckPolicy
+		want     string
+	}{
+		{name: "no policies"},
+		{name: "by labels", policies: []*v1.HealthCheckPolicy{policy("labels", 0, byLabels)}, want: "labels"},
+		{name: "by name over labels", policies: []*v1.HealthCheckPolicy{policy("labels", time.Hour, byLabels), policy("name", 0, byName)}, want: "name"},
+		{name: "oldest", policies: []*v1.HealthCheckPolicy{policy("newer", 0, byLabels), policy("older", time.Hour, byLabels)}, want: "older"},
+		{name: "first by name", policies: []*v1.HealthCheckPolicy{policy("b", 0, byName), policy("a", 0, byName)}, want: "a"},
+		{name: "not selected", policies: []*v1.HealthCheckPolicy{policy("other", 0, v1.HealthCheckPolicySpec{TargetRef: &v1.HealthCheckPolicyTargetReference{Name: "def"}})}},
+		{name: "both targetRef and selector", policies: []*v1.HealthCheckPolicy{policy("invalid", 0, v1.HealthCheckPolicySpec{TargetRef: byName.TargetRef, Selector: byLabels.Selector})}},
+		{name: "other namespace", policies: []*v1.HealthCheckPolicy{otherNamespace}},
+		{name: "other workspace", policies: []*v1.HealthCheckPolicy{otherCluster}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Effective(tt.policies, ingress)
+			if got == nil && tt.want != "" || got != nil && got.Name != tt.want {
+				t.Errorf("Effective() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	indexers := cache.Indexers{reconciler.ClusterNamespaceIndex: reconciler.ClusterNamespaceIndexFunc}
+	ingresses := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	policies := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	c := &Controller{
+		Controller:     &reconciler.Controller{Logger: logr.Discard()},
+		indexer:        policies,
+		ingressIndexer: ingresses,
+	}
+
+	g.Expect(ingresses.Add(&networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc", Namespace: "default", Labels: map[string]string{"app": "abc"}},
+	})).To(gomega.Succeed())
+	tcp := v1.HealthCheckProtocolTCP
+	selector := &v1.HealthCheckPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "selector", Namespace: "default", Generation: 1},
+		Spec: v1.HealthCheckPolicySpec{
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "abc"}},
+			Protocol:     &tcp,
+			SearchString: "ok",
+		},
+	}
+	g.Expect(policies.Add(selector)).To(gomega.Succeed())
+
+	// The policy applies to the Ingress, despite being invalid
+	g.Expect(c.reconcile(context.Background(), selector)).To(gomega.Succeed())
+	g.Expect(selector.Status.ObservedGeneration).To(gomega.Equal(int64(1)))
+	accepted := meta.FindStatusCondition(selector.Status.Conditions, v1.HealthCheckPolicyAcceptedConditionType)
+	g.Expect(accepted.Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(accepted.Message).To(gomega.ContainSubstring("searchString is only supported by the HTTP and HTTPS protocols"))
+	enforced := meta.FindStatusCondition(selector.Status.Conditions, v1.HealthCheckPolicyEnforcedConditionType)
+	g.Expect(enforced.Status).To(gomega.Equal(metav1.ConditionTrue))
+	g.Expect(enforced.Message).To(gomega.ContainSubstring("abc"))
+
+	// Until a policy targets the Ingress by name
+	g.Expect(policies.Add(&v1.HealthCheckPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "default"},
+		Spec:       v1.HealthCheckPolicySpec{TargetRef: &v1.HealthCheckPolicyTargetReference{Name: "abc"}},
+	})).To(gomega.Succeed())
+	selector.Spec.SearchString = ""
+	g.Expect(c.reconcile(context.Background(), selector)).To(gomega.Succeed())
+	accepted = meta.FindStatusCondition(selector.Status.Conditions, v1.HealthCheckPolicyAcceptedConditionType)
+	g.Expect(accepted.Status).To(gomega.Equal(metav1.ConditionTrue))
+	enforced = meta.FindStatusCondition(selector.Status.Conditions, v1.HealthCheckPolicyEnforcedConditionType)
+	g.Expect(enforced.Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(enforced.Reason).To(gomega.Equal("Overridden"))
+	g.Expect(enforced.Message).To(gomega.ContainSubstring("abc (by name)"))
+
+	// Nor once it does not select any Ingress
+	selector.Spec.Selector.MatchLabels["app"] = "def"
+	g.Expect(c.reconcile(context.Background(), selector)).To(gomega.Succeed())
+	enforced = meta.FindStatusCondition(selector.Status.Conditions, v1.HealthCheckPolicyEnforcedConditionType)
+	g.Expect(enforced.Reason).To(gomega.Equal("NoTargets"))
+}
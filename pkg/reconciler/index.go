@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kcp-dev/logicalcluster"
+)
+
+// ClusterNamespaceIndex is the name of the index of the objects by logical
+// cluster and namespace.
+const ClusterNamespaceIndex = "clusterNamespace"
+
+// ClusterNamespaceIndexFunc indexes the objects by logical cluster and
+// namespace.
+func ClusterNamespaceIndexFunc(obj interface{}) ([]string, error) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return []string{clusterNamespaceKey(object)}, nil
+}
+
+// AddClusterNamespaceIndex adds the ClusterNamespaceIndex to the informer,
+// unless another controller sharing the informer already did.
+func AddClusterNamespaceIndex(informer cache.SharedIndexInformer) error {
+	if _, ok := informer.GetIndexer().GetIndexers()[ClusterNamespaceIndex]; ok {
+		return nil
+	}
+	return informer.AddIndexers(cache.Indexers{ClusterNamespaceIndex: ClusterNamespaceIndexFunc})
+}
+
+// ByClusterNamespace returns the objects of the indexer in the logical
+// cluster and namespace of the object.
+func ByClusterNamespace(indexer cache.Indexer, object metav1.Object) ([]interface{}, error) {
+	return indexer.ByIndex(ClusterNamespaceIndex, clusterNamespaceKey(object))
+}
+
+func clusterNamespaceKey(object metav1.Object) string {
+	return logicalcluster.From(object).String() + "/" + object.GetNamespace()
+}
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 )
@@ -29,7 +30,9 @@ type certificateReconciler struct {
 	getCertificateStatus func(ctx context.Context, request tls.CertificateRequest) (tls.CertStatus, error)
 	copySecret           func(ctx context.Context, workspace logicalcluster.Name, namespace string, s *corev1.Secret) error
 	deleteSecret         func(ctx context.Context, workspace logicalcluster.Name, namespace, name string) error
-	log                  logr.Logger
+	// listCustomHostCertificates returns the suffixes of the custom host certificate names of the ingress
+	listCustomHostCertificates func(ingress *networkingv1.Ingress) ([]string, error)
+	log                        logr.Logger
 }
 
 type enqueue bool
@@ -160,20 +163,52 @@ func (r *certificateReconciler) reconcile(ctx context.Context, ingress *networki
 	}
 	certReq.Labels[LABEL_HCG_MANAGED] = "true"
 
+	// the custom host certificates of the ingress, those of the hosts that are still verified being kept below
+	staleCustomHosts, err := r.listCustomHostCertificates(ingress)
+	if err != nil {
+		return reconcileStatusStop, err
+	}
+
 	if ingress.DeletionTimestamp != nil && !ingress.DeletionTimestamp.IsZero() {
-		if err := r.deleteCertificate(ctx, certReq); err != nil && !strings.Contains(err.Error(), "not found") {
-			r.log.Info("error deleting certificate")
+		if err := r.deleteCertificateAndSecret(ctx, ingress, certReq, tlsSecretName); err != nil {
 			return reconcileStatusStop, err
 		}
-		//TODO remove once owner refs work in kcp
-		if err := r.deleteSecret(ctx, logicalcluster.From(ingress), ingress.Namespace, tlsSecretName); err != nil && !strings.Contains(err.Error(), "not found") {
-			r.log.Info("error deleting certificate secret")
-			return reconcileStatusStop, err
+		for _, suffix := range staleCustomHosts {
+			if err := r.deleteCertificateAndSecret(ctx, ingress, customHostCertificateRequest(certReq, suffix), customHostTLSSecretName(ingress, suffix)); err != nil {
+				return reconcileStatusStop, err
+			}
 		}
 		return reconcileStatusContinue, nil
 	}
 
-	err = r.createCertificate(ctx, certReq)
+	status, err := r.ensureCertificate(ctx, ingress, certReq, tlsSecretName)
+	if err != nil {
+		return reconcileStatusStop, err
+	}
+	if status != "" {
+		ingress.Annotations[annotationCertificateState] = status
+	}
+
+	for _, host := range customHosts(ingress) {
+		staleCustomHosts = slice.RemoveString(staleCustomHosts, customHostCertificateSuffix(host))
+		if _, err := r.ensureCertificate(ctx, ingress, customHostCertificateRequest(certReq, host), customHostTLSSecretName(ingress, host)); err != nil {
+			return reconcileStatusStop, err
+		}
+	}
+	for _, suffix := range staleCustomHosts {
+		if err := r.deleteCertificateAndSecret(ctx, ingress, customHostCertificateRequest(certReq, suffix), customHostTLSSecretName(ingress, suffix)); err != nil {
+			return reconcileStatusStop, err
+		}
+	}
+
+	return reconcileStatusContinue, nil
+}
+
+// ensureCertificate creates the certificate, and copies its secret to the
+// ingress namespace once it is ready. It returns the status of the certificate,
+// if it already exists.
+func (r *certificateReconciler) ensureCertificate(ctx context.Context, ingress *networkingv1.Ingress, certReq tls.CertificateRequest, tlsSecretName string) (string, error) {
+	err := r.createCertificate(ctx, certReq)
 	if errors.IsAlreadyExists(err) {
 		// get certificate secret and copy
 		secret, err := r.getCertificateSecret(ctx, certReq)
@@ -182,14 +217,12 @@ func (r *certificateReconciler) reconcile(ctx context.Context, ingress *networki
 				// cetificate not ready so update the status and allow it continue reconcile. Will be requeued once certificate becomes ready
 				status, err := r.getCertificateStatus(ctx, certReq)
 				if err != nil {
-					return reconcileStatusStop, err
+					return "", err
 				}
-				ingress.Annotations[annotationCertificateState] = string(status)
-				return reconcileStatusContinue, nil
+				return string(status), nil
 			}
-			return reconcileStatusStop, err
+			return "", err
 		}
-		ingress.Annotations[annotationCertificateState] = "ready" // todo remote hardcoded string
 		//copy over the secret to the ingress namesapce
 		scopy := secret.DeepCopy()
 		scopy.SetOwnerReferences([]metav1.OwnerReference{
@@ -206,16 +239,69 @@ func (r *certificateReconciler) reconcile(ctx context.Context, ingress *networki
 		scopy.Namespace = ingress.Namespace
 		scopy.Name = tlsSecretName
 		if err := r.copySecret(ctx, logicalcluster.From(ingress), ingress.Namespace, scopy); err != nil {
-			return reconcileStatusStop, err
+			return "", err
 		}
+		// set tls setting on the ingress
+		upsertTLS(ingress, certReq.Host, tlsSecretName)
+		return "ready", nil // todo remote hardcoded string
 	}
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return reconcileStatusStop, err
+	if err != nil {
+		return "", err
 	}
 	// set tls setting on the ingress
 	upsertTLS(ingress, certReq.Host, tlsSecretName)
+	return "", nil
+}
 
-	return reconcileStatusContinue, nil
+func (r *certificateReconciler) deleteCertificateAndSecret(ctx context.Context, ingress *networkingv1.Ingress, certReq tls.CertificateRequest, tlsSecretName string) error {
+	if err := r.deleteCertificate(ctx, certReq); err != nil && !strings.Contains(err.Error(), "not found") {
+		r.log.Info("error deleting certificate")
+		return err
+	}
+	//TODO remove once owner refs work in kcp
+	if err := r.deleteSecret(ctx, logicalcluster.From(ingress), ingress.Namespace, tlsSecretName); err != nil && !strings.Contains(err.Error(), "not found") {
+		r.log.Info("error deleting certificate secret")
+		return err
+	}
+	return nil
+}
+
+// customHostCertificateSuffix returns the suffix of the names of the
+// certificate and secret of a custom host.
+func customHostCertificateSuffix(host string) string {
+	return strings.Replace(strings.ToLower(host), "*", "wildcard", 1)
+}
+
+func customHostCertificateRequest(certReq tls.CertificateRequest, host string) tls.CertificateRequest {
+	certReq.Name = fmt.Sprintf("%s-%s", certReq.Name, customHostCertificateSuffix(host))
+	certReq.Host = host
+	certReq.CustomHost = true
+	return certReq
+}
+
+func customHostTLSSecretName(ingress *networkingv1.Ingress, host string) string {
+	return fmt.Sprintf("%s-%s", TLSSecretName(ingress), customHostCertificateSuffix(host))
+}
+
+// listCustomHostCertificates returns the suffixes of the names of the custom
+// host certificates of the ingress.
+func (c *Controller) listCustomHostCertificates(ingress *networkingv1.Ingress) ([]string, error) {
+	key, err := cache.MetaNamespaceKeyFunc(ingress)
+	if err != nil {
+		return nil, err
+	}
+	certificates, err := c.certificateLister.List(labels.SelectorFromSet(labels.Set{LABEL_HCG_MANAGED: "true"}))
+	if err != nil {
+		return nil, err
+	}
+	prefix := CertificateName(ingress) + "-"
+	var suffixes []string
+	for _, certificate := range certificates {
+		if certificate.Annotations[annotationIngressKey] == key && strings.HasPrefix(certificate.Name, prefix) {
+			suffixes = append(suffixes, strings.TrimPrefix(certificate.Name, prefix))
+		}
+	}
+	return suffixes, nil
 }
 
 func removeHostsFromTLS(hostsToRemove []string, ingress *networkingv1.Ingress) {
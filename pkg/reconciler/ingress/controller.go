@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -17,6 +18,9 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	tenancyv1alpha1 "github.com/kcp-dev/kcp/pkg/apis/tenancy/v1alpha1"
+	kcpinformers "github.com/kcp-dev/kcp/pkg/client/informers/externalversions"
+	schedulinglister "github.com/kcp-dev/kcp/pkg/client/listers/scheduling/v1alpha1"
+	workloadlister "github.com/kcp-dev/kcp/pkg/client/listers/workload/v1alpha1"
 	"github.com/kcp-dev/logicalcluster"
 
 	certman "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
@@ -24,9 +28,12 @@ import (
 	certmanlister "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
 	kuadrantclientv1 "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/clientset/versioned"
 	dnsrecordinformer "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/informers/externalversions"
+	kuadrantlister "github.com/kuadrant/kcp-glbc/pkg/client/kuadrant/listers/kuadrant/v1"
 	"github.com/kuadrant/kcp-glbc/pkg/net"
 	basereconciler "github.com/kuadrant/kcp-glbc/pkg/reconciler"
+	"github.com/kuadrant/kcp-glbc/pkg/reconciler/domainverification"
 	"github.com/kuadrant/kcp-glbc/pkg/tls"
+	"github.com/kuadrant/kcp-glbc/pkg/util/workloadMigration"
 )
 
 const (
@@ -36,6 +43,8 @@ const (
 	ANNOTATION_HCG_HOST                 = "kuadrant.dev/host.generated"
 	ANNOTATION_HEALTH_CHECK_PREFIX      = "kuadrant.experimental/health-"
 	ANNOTATION_HCG_CUSTOM_HOST_REPLACED = "kuadrant.dev/custom-hosts.replaced"
+	ANNOTATION_HCG_CUSTOM_HOSTS         = "kuadrant.dev/custom-hosts"
+	ANNOTATION_HCG_CUSTOM_HOSTS_PENDING = "kuadrant.dev/custom-hosts.pending"
 	LABEL_HCG_MANAGED                   = "kuadrant.dev/hcg.managed"
 )
 
@@ -58,12 +67,20 @@ func NewController(config *ControllerConfig) *Controller {
 		sharedInformerFactory:    config.KCPSharedInformerFactory,
 		glbcInformerFactory:      config.GlbcInformerFactory,
 		dnsRecordClient:          config.DnsRecordClient,
-		domain:                   config.Domain,
+		domains:                  config.Domains,
 		hostResolver:             hostResolver,
 		hostsWatcher:             net.NewHostsWatcher(&base.Logger, hostResolver, net.DefaultInterval),
 		customHostsEnabled:       config.CustomHostsEnabled,
 		certInformerFactory:      config.CertificateInformer,
 		dnsRecordInformerFactory: config.DNSRecordInformer,
+		routingPolicy:            config.RoutingPolicy,
+		drainStrategy:            workloadMigration.DefaultDrainStrategy,
+	}
+	if c.routingPolicy == "" {
+		c.routingPolicy = RoutingPolicyWeighted
+	}
+	if config.DrainStrategy != nil {
+		c.drainStrategy = *config.DrainStrategy
 	}
 	c.Process = c.process
 	c.hostsWatcher.OnChange = c.Enqueue
@@ -197,14 +214,71 @@ func NewController(config *ControllerConfig) *Controller {
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			newdns := newObj.(*kuadrantv1.DNSRecord)
 			olddns := oldObj.(*kuadrantv1.DNSRecord)
+			// The status updates, e.g. of the health of the endpoints, requeue
+			// the ingress as well, so that its annotations reflect the status
 			if olddns.ResourceVersion != newdns.ResourceVersion {
-				ingressKey := newObj.(*kuadrantv1.DNSRecord).Annotations[annotationIngressKey]
-				c.Logger.V(3).Info("reqeuing ingress dns record deleted", "cluster", newdns.ClusterName, "namespace", newdns.Namespace, "name", newdns.Name, "ingresskey", ingressKey)
+				ingressKey, ok := newdns.Annotations[annotationIngressKey]
+				if !ok {
+					return
+				}
+				c.Logger.V(3).Info("reqeuing ingress dns record updated", "cluster", newdns.ClusterName, "namespace", newdns.Namespace, "name", newdns.Name, "ingresskey", ingressKey)
 				c.enqueueIngressByKey(ingressKey)
 			}
 		},
 	})
 
+	if c.customHostsEnabled {
+		// watch for domain verifications, to restore the custom hosts once verified
+		c.dnsRecordInformerFactory.Kuadrant().V1().DomainVerifications().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				verification := obj.(*kuadrantv1.DomainVerification)
+				if verification.Status.Verified {
+					c.enqueueIngressesOfCluster(logicalcluster.From(verification))
+				}
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldVerification := oldObj.(*kuadrantv1.DomainVerification)
+				newVerification := newObj.(*kuadrantv1.DomainVerification)
+				if oldVerification.Status.Verified != newVerification.Status.Verified {
+					c.Logger.V(3).Info("requeuing ingresses domain verification updated", "cluster", newVerification.ClusterName, "domain", newVerification.Spec.Domain)
+					c.enqueueIngressesOfCluster(logicalcluster.From(newVerification))
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if verification, ok := obj.(*kuadrantv1.DomainVerification); ok {
+					c.enqueueIngressesOfCluster(logicalcluster.From(verification))
+				}
+			},
+		})
+		c.domainVerificationLister = c.dnsRecordInformerFactory.Kuadrant().V1().DomainVerifications().Lister()
+
+		// the custom hosts are only trusted once their domain is verified by GLBC
+		c.verifiedDomains = config.VerifiedDomains
+		c.verifiedDomains.AddEventHandler(c.enqueueIngressesOfCluster)
+	}
+
+	if config.SyncTargetInformer != nil {
+		// watch for the locations of the sync targets changing, to route the traffic by location
+		handler := cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueueAllIngresses()
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if !equality.Semantic.DeepEqual(oldObj.(metav1.Object).GetLabels(), newObj.(metav1.Object).GetLabels()) {
+					c.Logger.V(3).Info("requeuing ingresses sync target location updated", "name", newObj.(metav1.Object).GetName())
+					c.enqueueAllIngresses()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				c.enqueueAllIngresses()
+			},
+		}
+		config.SyncTargetInformer.Workload().V1alpha1().SyncTargets().Informer().AddEventHandler(handler)
+		config.SyncTargetInformer.Scheduling().V1alpha1().Locations().Informer().AddEventHandler(handler)
+		c.syncTargetLister = config.SyncTargetInformer.Workload().V1alpha1().SyncTargets().Lister()
+		c.locationLister = config.SyncTargetInformer.Scheduling().V1alpha1().Locations().Lister()
+	}
+
 	return c
 }
 
@@ -216,10 +290,22 @@ type ControllerConfig struct {
 	CertificateInformer      certmaninformer.SharedInformerFactory
 	GlbcInformerFactory      informers.SharedInformerFactory
 	DNSRecordInformer        dnsrecordinformer.SharedInformerFactory
-	Domain                   string
+	Domains                  DomainMapping
 	CertProvider             tls.Provider
 	HostResolver             net.HostResolver
 	CustomHostsEnabled       bool
+	// VerifiedDomains records the domains verified by GLBC, required if the
+	// custom hosts are enabled.
+	VerifiedDomains *domainverification.VerifiedDomains
+	// SyncTargetInformer informs about the SyncTargets and Locations of the
+	// compute workspace. The traffic is only weighted if not set.
+	SyncTargetInformer kcpinformers.SharedInformerFactory
+	// RoutingPolicy is the routing policy of the Ingresses not selecting one
+	// with the kuadrant.dev/routing-policy annotation.
+	RoutingPolicy RoutingPolicy
+	// DrainStrategy drains the traffic from the clusters the workloads are
+	// migrated away from. The default drain strategy is used if not set.
+	DrainStrategy *workloadMigration.DrainStrategy
 }
 
 type Controller struct {
@@ -231,13 +317,19 @@ type Controller struct {
 	ingressLister            networkingv1lister.IngressLister
 	certificateLister        certmanlister.CertificateLister
 	certProvider             tls.Provider
-	domain                   string
+	domains                  DomainMapping
 	hostResolver             net.HostResolver
 	hostsWatcher             *net.HostsWatcher
 	customHostsEnabled       bool
 	certInformerFactory      certmaninformer.SharedInformerFactory
 	glbcInformerFactory      informers.SharedInformerFactory
 	dnsRecordInformerFactory dnsrecordinformer.SharedInformerFactory
+	domainVerificationLister kuadrantlister.DomainVerificationLister
+	verifiedDomains          *domainverification.VerifiedDomains
+	syncTargetLister         workloadlister.SyncTargetLister
+	locationLister           schedulinglister.LocationLister
+	routingPolicy            RoutingPolicy
+	drainStrategy            workloadMigration.DrainStrategy
 }
 
 func (c *Controller) enqueueIngressByKey(key string) {
@@ -253,6 +345,18 @@ func (c *Controller) enqueueIngressByKey(key string) {
 	c.Enqueue(ingress)
 }
 
+// enqueueAllIngresses enqueues the ingresses of all the workspaces.
+func (c *Controller) enqueueAllIngresses() {
+	ingresses, err := c.ingressLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, ingress := range ingresses {
+		c.Enqueue(ingress)
+	}
+}
+
 func (c *Controller) process(ctx context.Context, key string) error {
 	object, exists, err := c.indexer.GetByKey(key)
 	if err != nil {
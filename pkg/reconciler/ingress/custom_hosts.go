@@ -0,0 +1,226 @@
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+
+	kuadrantv1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+)
+
+// pendingCustomHostRules returns the rules whose custom host has been replaced
+// until the ownership of its domain is verified.
+func pendingCustomHostRules(ingress *networkingv1.Ingress) ([]networkingv1.IngressRule, error) {
+	value, ok := ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS_PENDING]
+	if !ok {
+		return nil, nil
+	}
+	var rules []networkingv1.IngressRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %v", ANNOTATION_HCG_CUSTOM_HOSTS_PENDING, err)
+	}
+	return rules, nil
+}
+
+func setPendingCustomHostRules(ingress *networkingv1.Ingress, rules []networkingv1.IngressRule) error {
+	if len(rules) == 0 {
+		delete(ingress.Annotations, ANNOTATION_HCG_CUSTOM_HOSTS_PENDING)
+		return nil
+	}
+	value, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS_PENDING] = string(value)
+	return nil
+}
+
+// upsertRule adds the rule to the list, replacing the rule with the same host.
+func upsertRule(rules []networkingv1.IngressRule, rule networkingv1.IngressRule) []networkingv1.IngressRule {
+	for i := range rules {
+		if rules[i].Host == rule.Host {
+			rules[i] = rule
+			return rules
+		}
+	}
+	return append(rules, rule)
+}
+
+func hasRuleForHost(ingress *networkingv1.Ingress, host string) bool {
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// customHosts returns the verified custom hosts of the ingress.
+func customHosts(ingress *networkingv1.Ingress) []string {
+	value := ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// domainOf returns the domain whose ownership is verified by default to use the
+// host, i.e., the host itself. A verification of a parent domain can be created
+// instead, to use all its sub-domains.
+func domainOf(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "*.")
+}
+
+// verificationFor returns the verification of the domain the host belongs to,
+// if any, preferring the verified ones. The sub-domains of a domain can be used
+// without additional verification.
+func verificationFor(host string, verifications []*kuadrantv1.DomainVerification, verified func(*kuadrantv1.DomainVerification) bool) *kuadrantv1.DomainVerification {
+	host = domainOf(host)
+	var match *kuadrantv1.DomainVerification
+	for _, verification := range verifications {
+		domain := strings.ToLower(strings.TrimSuffix(verification.Spec.Domain, "."))
+		if domain == "" || (host != domain && !strings.HasSuffix(host, "."+domain)) {
+			continue
+		}
+		if verified(verification) {
+			return verification
+		}
+		match = verification
+	}
+	return match
+}
+
+// isVerified returns whether the domain the host belongs to is verified. The
+// status of the verifications is not trusted, as the workspaces can write it.
+func isVerified(host string, verifications []*kuadrantv1.DomainVerification, verified func(*kuadrantv1.DomainVerification) bool) bool {
+	verification := verificationFor(host, verifications, verified)
+	return verification != nil && verified(verification)
+}
+
+// isDomainVerified returns whether GLBC has verified the domain of the
+// verification.
+func (c *Controller) isDomainVerified(verification *kuadrantv1.DomainVerification) bool {
+	return c.verifiedDomains.IsVerified(verification)
+}
+
+// getDomainVerifications returns the domain verifications of the ingress
+// workspace.
+func (c *Controller) getDomainVerifications(_ context.Context, ingress *networkingv1.Ingress) ([]*kuadrantv1.DomainVerification, error) {
+	all, err := c.domainVerificationLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cluster := logicalcluster.From(ingress)
+	var verifications []*kuadrantv1.DomainVerification
+	for _, verification := range all {
+		if logicalcluster.From(verification) == cluster {
+			verifications = append(verifications, verification)
+		}
+	}
+	return verifications, nil
+}
+
+// createDomainVerification creates the verification of the domain in the
+// ingress workspace, owned by the ingress, if it does not exist yet.
+func (c *Controller) createDomainVerification(ctx context.Context, ingress *networkingv1.Ingress, domain string) error {
+	verification := &kuadrantv1.DomainVerification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            domain,
+			OwnerReferences: []metav1.OwnerReference{ingressOwnerReference(ingress)},
+		},
+		Spec: kuadrantv1.DomainVerificationSpec{
+			Domain: domain,
+		},
+	}
+	_, err := c.dnsRecordClient.Cluster(logicalcluster.From(ingress)).KuadrantV1().DomainVerifications().Create(ctx, verification, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// ownDomainVerifications adds the ingress to the owners of the verifications
+// created by GLBC whose domain it uses, and removes it from the owners of the
+// others. As the namespaced owners of cluster-scoped objects are not supported
+// by the garbage collector, the verifications are deleted once they have no
+// owner left. The verifications created in the workspace have no owner, and
+// are left untouched.
+func (c *Controller) ownDomainVerifications(ctx context.Context, ingress *networkingv1.Ingress, used, verifications []*kuadrantv1.DomainVerification) error {
+	client := c.dnsRecordClient.Cluster(logicalcluster.From(ingress)).KuadrantV1().DomainVerifications()
+	for _, verification := range verifications {
+		if len(verification.OwnerReferences) == 0 {
+			continue
+		}
+		uses, owns := containsVerification(used, verification), false
+		owners := make([]metav1.OwnerReference, 0, len(verification.OwnerReferences)+1)
+		for _, owner := range verification.OwnerReferences {
+			if owner.UID == ingress.UID {
+				owns = true
+				if !uses {
+					continue
+				}
+			}
+			owners = append(owners, owner)
+		}
+		if uses == owns {
+			continue
+		}
+		if uses {
+			owners = append(owners, ingressOwnerReference(ingress))
+		}
+
+		if len(owners) == 0 {
+			c.Logger.Info("Deleting domain verification no longer used", "cluster", logicalcluster.From(verification), "domain", verification.Spec.Domain)
+			if err := client.Delete(ctx, verification.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		verification = verification.DeepCopy()
+		verification.OwnerReferences = owners
+		if _, err := client.Update(ctx, verification, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ingressOwnerReference(ingress *networkingv1.Ingress) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: networkingv1.SchemeGroupVersion.String(),
+		Kind:       "Ingress",
+		Name:       ingress.Name,
+		UID:        ingress.UID,
+	}
+}
+
+func containsVerification(verifications []*kuadrantv1.DomainVerification, verification *kuadrantv1.DomainVerification) bool {
+	for _, v := range verifications {
+		if v.Name == verification.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueueIngressesOfCluster enqueues all the ingresses of the workspace.
+func (c *Controller) enqueueIngressesOfCluster(cluster logicalcluster.Name) {
+	ingresses, err := c.ingressLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, ingress := range ingresses {
+		if logicalcluster.From(ingress) == cluster {
+			c.Enqueue(ingress)
+		}
+	}
+}
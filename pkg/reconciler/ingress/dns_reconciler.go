@@ -5,13 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	gonet "net"
 	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/kcp-dev/logicalcluster"
 	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
-	"github.com/kuadrant/kcp-glbc/pkg/dns/aws"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
 	"github.com/kuadrant/kcp-glbc/pkg/net"
 	"github.com/kuadrant/kcp-glbc/pkg/util/metadata"
 	"github.com/kuadrant/kcp-glbc/pkg/util/slice"
@@ -33,7 +34,10 @@ type dnsReconciler struct {
 	forgetHost       func(key interface{}, host string)
 	listHostWatchers func(key interface{}) []net.RecordWatcher
 	DNSLookup        func(ctx context.Context, host string) ([]net.HostAddress, error)
-	log              logr.Logger
+	// locate returns the location of a sync target, if known
+	locate               func(syncTarget string) (clusterLocation, error)
+	defaultRoutingPolicy RoutingPolicy
+	log                  logr.Logger
 }
 
 func (r *dnsReconciler) reconcile(ctx context.Context, ingress *networkingv1.Ingress) (reconcileStatus, error) {
@@ -128,6 +132,8 @@ func (r *dnsReconciler) reconcile(ctx context.Context, ingress *networkingv1.Ing
 
 	}
 	// If it does exist, update it
+	setFailoverServing(ingress, existing)
+	setHealthStatus(ingress, existing)
 	copyDNS := existing.DeepCopy()
 	if err := r.setDnsRecordFromIngress(ctx, ingress, existing); err != nil {
 		return reconcileStatusStop, err
@@ -168,7 +174,7 @@ func (r *dnsReconciler) setEndpointsFromIngress(ctx context.Context, ingress *ne
 
 	hostname := ingress.Annotations[ANNOTATION_HCG_HOST]
 
-	// Build a map[Address]Endpoint with the current endpoints to assist
+	// Build a map[Name/Address]Endpoint with the current endpoints to assist
 	// finding endpoints that match the targets
 	currentEndpoints := make(map[string]*v1.Endpoint, len(dnsRecord.Spec.Endpoints))
 	for _, endpoint := range dnsRecord.Spec.Endpoints {
@@ -177,31 +183,30 @@ func (r *dnsReconciler) setEndpointsFromIngress(ctx context.Context, ingress *ne
 			continue
 		}
 
-		currentEndpoints[address] = endpoint
+		currentEndpoints[endpoint.DNSName+"/"+address] = endpoint
 	}
 
 	var newEndpoints []*v1.Endpoint
 
-	for _, ingressTargets := range targets {
-		for _, target := range ingressTargets {
-			var endpoint *v1.Endpoint
-			ok := false
-
-			// If the endpoint for this target does not exist, add a new one
-			if endpoint, ok = currentEndpoints[target]; !ok {
-				endpoint = &v1.Endpoint{
-					SetIdentifier: target,
-				}
-			}
-
-			newEndpoints = append(newEndpoints, endpoint)
+	if hostname != "" {
+		newEndpoints, err = r.routedEndpoints(ingress, hostname, targets, currentEndpoints, dnsRecord.Spec.Endpoints)
+		if err != nil {
+			return err
+		}
+	} else {
+		newEndpoints = weightedClusterEndpoints(hostname, targets, r.clusterWeights(ingress, targets), currentEndpoints)
+	}
 
-			// Update the endpoint fields
-			endpoint.DNSName = hostname
-			endpoint.RecordType = "A"
-			endpoint.Targets = []string{target}
-			endpoint.RecordTTL = 60
-			endpoint.SetProviderSpecific(aws.ProviderSpecificWeight, awsEndpointWeight(len(ingressTargets)))
+	// Point the verified custom hosts at the managed host
+	if hostname != "" {
+		for _, host := range customHosts(ingress) {
+			newEndpoints = append(newEndpoints, &v1.Endpoint{
+				DNSName:    host,
+				RecordType: string(v1.CNAMERecordType),
+				Targets:    []string{hostname},
+				RecordTTL:  60,
+				Labels:     v1.Labels{v1.CustomHostLabel: "true"},
+			})
 		}
 	}
 
@@ -209,10 +214,83 @@ func (r *dnsReconciler) setEndpointsFromIngress(ctx context.Context, ingress *ne
 	return nil
 }
 
-// targetsFromIngress returns a map of all the IPs associated with a single ingress(cluster)
-func (r *dnsReconciler) targetsFromIngress(ctx context.Context, ingress *networkingv1.Ingress) (map[string][]string, error) {
-	targets := map[string][]string{}
-	deletingTargets := map[string][]string{}
+// weightedClusterEndpoints returns the weighted endpoints of the name, splitting
+// the traffic between the clusters as per their weights.
+func weightedClusterEndpoints(dnsName string, targets map[string]map[string][]string, weights map[string]int, currentEndpoints map[string]*v1.Endpoint) []*v1.Endpoint {
+	maxWeight := 0
+	for clusterName := range targets {
+		if weights[clusterName] > maxWeight {
+			maxWeight = weights[clusterName]
+		}
+	}
+
+	var endpoints []*v1.Endpoint
+	for clusterName, clusterTargets := range targets {
+		for _, ingressTargets := range clusterTargets {
+			endpoints = append(endpoints, weightedEndpoints(dnsName, ingressTargets, weights[clusterName], maxWeight, currentEndpoints)...)
+		}
+	}
+	return endpoints
+}
+
+// weightedEndpoints returns the weighted endpoints of the name, splitting the
+// traffic evenly between the IPs of an ingress(cluster), that is given a share
+// of the traffic proportional to the weight of the cluster.
+func weightedEndpoints(dnsName string, ingressTargets []string, weight, maxWeight int, currentEndpoints map[string]*v1.Endpoint) []*v1.Endpoint {
+	// A and AAAA records are distinct record sets, so the traffic is
+	// split evenly between the IPs of each family
+	numIPs := map[v1.DNSRecordType]int{}
+	for _, target := range ingressTargets {
+		numIPs[recordTypeForIP(target)]++
+	}
+
+	var endpoints []*v1.Endpoint
+	for _, target := range ingressTargets {
+		recordType := recordTypeForIP(target)
+		var endpoint *v1.Endpoint
+		ok := false
+
+		// If the endpoint for this target does not exist, add a new one
+		if endpoint, ok = currentEndpoints[dnsName+"/"+target]; !ok {
+			endpoint = &v1.Endpoint{
+				SetIdentifier: target,
+			}
+		}
+
+		endpoints = append(endpoints, endpoint)
+
+		// Update the endpoint fields
+		endpoint.DNSName = dnsName
+		endpoint.RecordType = string(recordType)
+		endpoint.Targets = []string{target}
+		endpoint.RecordTTL = 60
+		endpoint.SetProviderSpecific(dns.ProviderSpecificWeight, awsClusterEndpointWeight(numIPs[recordType], weight, maxWeight))
+	}
+	return endpoints
+}
+
+// recordTypeForIP returns the type of the records of the IP address, i.e., AAAA
+// for IPv6 addresses, and A otherwise.
+func recordTypeForIP(ip string) v1.DNSRecordType {
+	if parsed := gonet.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return v1.AAAARecordType
+	}
+	return v1.ARecordType
+}
+
+// normalizeIP returns the IPv4 form of the IPv4-mapped IPv6 addresses, e.g.
+// 1.2.3.4 for ::ffff:1.2.3.4, so that they are published as A record targets.
+func normalizeIP(ip string) string {
+	if parsed := gonet.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+		return parsed.To4().String()
+	}
+	return ip
+}
+
+// targetsFromIngress returns a map of all the IPs associated with a single ingress, per cluster
+func (r *dnsReconciler) targetsFromIngress(ctx context.Context, ingress *networkingv1.Ingress) (map[string]map[string][]string, error) {
+	targets := map[string]map[string][]string{}
+	deletingTargets := map[string]map[string][]string{}
 
 	ingressStatus := &networkingv1.IngressStatus{}
 	//find all annotations of a workload status (indicates a synctarget for this resource)
@@ -236,14 +314,13 @@ func (r *dnsReconciler) targetsFromIngress(ctx context.Context, ingress *network
 		}
 
 		if metadata.HasAnnotation(ingress, workloadMigration.WorkloadDeletingAnnotation+clusterName) {
-			for host, ips := range statusTargets {
-				deletingTargets[host] = append(deletingTargets[host], ips...)
+			// Keep routing to the cluster while its traffic is drained
+			if _, draining := workloadMigration.DrainPercent(ingress, clusterName); !draining {
+				deletingTargets[clusterName] = statusTargets
+				continue
 			}
-			continue
-		}
-		for host, ips := range statusTargets {
-			targets[host] = append(targets[host], ips...)
 		}
+		targets[clusterName] = statusTargets
 	}
 	//no non-deleting hosts have an IP yet, so continue using IPs of "losing" clusters
 	if len(targets) == 0 && len(deletingTargets) > 0 {
@@ -257,7 +334,8 @@ func (r *dnsReconciler) targetsFromIngressStatus(ctx context.Context, ingressSta
 	targets := map[string][]string{}
 	for _, lb := range ingressStatus.LoadBalancer.Ingress {
 		if lb.IP != "" {
-			targets[lb.IP] = []string{lb.IP}
+			ip := normalizeIP(lb.IP)
+			targets[ip] = []string{ip}
 		}
 		if lb.Hostname != "" {
 			ips, err := r.DNSLookup(ctx, lb.Hostname)
@@ -283,11 +361,25 @@ func (r *dnsReconciler) targetsFromIngressStatus(ctx context.Context, ingressSta
 // The aws weight value must be an integer between 0 and 255.
 // https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/resource-record-sets-values-weighted.html#rrsets-values-weighted-weight
 func awsEndpointWeight(numIPs int) string {
-	maxWeight := 120
-	if numIPs > maxWeight {
-		numIPs = maxWeight
+	return awsClusterEndpointWeight(numIPs, 1, 1)
+}
+
+// awsClusterEndpointWeight returns the weight value for a single AWS record of a cluster/ingress, given a share of the
+// weight allowance proportional to its weight relative to the maximum weight of the clusters, that is split evenly to
+// its number of IPs (numIPs)
+//
+// A cluster with a weight of 0 is drained, i.e., its records have a weight of 0, while the records of any other cluster
+// have a weight of at least 1, so that the traffic is not drained from a cluster with a low weight and many IPs.
+func awsClusterEndpointWeight(numIPs, weight, maxWeight int) string {
+	if weight <= 0 || maxWeight <= 0 {
+		return "0"
+	}
+	allowance := int64(maxEndpointWeight) * int64(weight) / int64(maxWeight)
+	value := allowance / int64(numIPs)
+	if value < 1 {
+		value = 1
 	}
-	return strconv.Itoa(maxWeight / numIPs)
+	return strconv.FormatInt(value, 10)
 }
 
 func (c *Controller) updateDNS(ctx context.Context, dns *v1.DNSRecord) error {
@@ -0,0 +1,99 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kcp-dev/logicalcluster"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kuadrant/kcp-glbc/pkg/util/slice"
+)
+
+// LABEL_HCG_MANAGED_DOMAIN selects, on the namespace of an Ingress, one of the
+// managed domains the generated host belongs to.
+const LABEL_HCG_MANAGED_DOMAIN = "kuadrant.dev/managed-domain"
+
+// DomainMapping assigns managed domains to workspaces.
+type DomainMapping struct {
+	// The domain of the workspaces that are not mapped
+	Default string
+	// The domains of the workspaces, by logical cluster, e.g. root:org:eu. A
+	// workspace also maps all its descendants that are not mapped themselves.
+	Workspaces map[string]string
+}
+
+// Domains returns all the managed domains, sorted.
+func (m DomainMapping) Domains() []string {
+	set := map[string]struct{}{}
+	if m.Default != "" {
+		set[m.Default] = struct{}{}
+	}
+	for _, domain := range m.Workspaces {
+		set[domain] = struct{}{}
+	}
+	domains := make([]string, 0, len(set))
+	for domain := range set {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// domainsOf returns the managed domains the given logical cluster can select,
+// i.e., the default domain and the domains of the workspace and its ancestors.
+func (m DomainMapping) domainsOf(cluster logicalcluster.Name) []string {
+	var domains []string
+	if m.Default != "" {
+		domains = append(domains, m.Default)
+	}
+	for name, ok := cluster, !cluster.Empty(); ok; name, ok = name.Parent() {
+		if domain, mapped := m.Workspaces[name.String()]; mapped && !slice.ContainsString(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// domainFor returns the managed domain of the given logical cluster and
+// namespace labels. The namespace label takes precedence, and must select the
+// default domain, or the domain of the workspace or of one of its ancestors.
+// Otherwise the domain of the closest mapped workspace is used, falling back to
+// the default domain.
+func (m DomainMapping) domainFor(cluster logicalcluster.Name, namespaceLabels map[string]string) (string, error) {
+	if domain, ok := namespaceLabels[LABEL_HCG_MANAGED_DOMAIN]; ok {
+		if slice.ContainsString(m.domainsOf(cluster), domain) {
+			return domain, nil
+		}
+		return "", fmt.Errorf("domain %s selected by label %s is not a managed domain of logical cluster %s", domain, LABEL_HCG_MANAGED_DOMAIN, cluster)
+	}
+
+	for name, ok := cluster, !cluster.Empty(); ok; name, ok = name.Parent() {
+		if domain, mapped := m.Workspaces[name.String()]; mapped {
+			return domain, nil
+		}
+	}
+
+	if m.Default == "" {
+		return "", fmt.Errorf("no managed domain for logical cluster %s", cluster)
+	}
+	return m.Default, nil
+}
+
+// managedDomain returns the managed domain of the ingress, using the labels of
+// its namespace.
+func (c *Controller) managedDomain(ctx context.Context, ingress *networkingv1.Ingress) (string, error) {
+	cluster := logicalcluster.From(ingress)
+	var namespaceLabels map[string]string
+	// Only look the namespace up when the domain can be selected by label
+	if len(c.domains.domainsOf(cluster)) > 1 {
+		namespace, err := c.kubeClient.Cluster(cluster).CoreV1().Namespaces().Get(ctx, ingress.Namespace, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		namespaceLabels = namespace.Labels
+	}
+	return c.domains.domainFor(cluster, namespaceLabels)
+}
@@ -0,0 +1,89 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster"
+)
+
+func TestDomainFor(t *testing.T) {
+	mapping := DomainMapping{
+		Default: "apps.example.com",
+		Workspaces: map[string]string{
+			"root:org:eu":    "eu.apps.example.com",
+			"root:org:eu:us": "us.apps.example.com",
+			"root:org:us":    "us.apps.example.com",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		cluster  string
+		labels   map[string]string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "workspace not mapped",
+			cluster:  "root:org:apac",
+			expected: "apps.example.com",
+		},
+		{
+			name:     "workspace mapped",
+			cluster:  "root:org:us",
+			expected: "us.apps.example.com",
+		},
+		{
+			name:     "descendant of a mapped workspace",
+			cluster:  "root:org:eu:team",
+			expected: "eu.apps.example.com",
+		},
+		{
+			name:     "closest mapped workspace",
+			cluster:  "root:org:eu:us:team",
+			expected: "us.apps.example.com",
+		},
+		{
+			name:     "namespace label",
+			cluster:  "root:org:eu",
+			labels:   map[string]string{LABEL_HCG_MANAGED_DOMAIN: "apps.example.com"},
+			expected: "apps.example.com",
+		},
+		{
+			name:     "namespace label selecting the domain of an ancestor",
+			cluster:  "root:org:eu:us:team",
+			labels:   map[string]string{LABEL_HCG_MANAGED_DOMAIN: "eu.apps.example.com"},
+			expected: "eu.apps.example.com",
+		},
+		{
+			name:    "namespace label selecting the domain of another workspace",
+			cluster: "root:org:us",
+			labels:  map[string]string{LABEL_HCG_MANAGED_DOMAIN: "eu.apps.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "namespace label selecting an unmanaged domain",
+			cluster: "root:org:eu",
+			labels:  map[string]string{LABEL_HCG_MANAGED_DOMAIN: "example.org"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain, err := mapping.domainFor(logicalcluster.New(tt.cluster), tt.labels)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("domainFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if domain != tt.expected {
+				t.Errorf("domainFor() = %s, expected %s", domain, tt.expected)
+			}
+		})
+	}
+
+	if _, err := (DomainMapping{}).domainFor(logicalcluster.New("root:org"), nil); err == nil {
+		t.Errorf("expected an error without default domain")
+	}
+	if domains := mapping.Domains(); len(domains) != 3 {
+		t.Errorf("expected 3 managed domains, got %v", domains)
+	}
+}
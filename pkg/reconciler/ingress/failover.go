@@ -0,0 +1,137 @@
+package ingress
+
+import (
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/util/metadata"
+)
+
+const (
+	// ANNOTATION_FAILOVER_PRIMARY selects the sync target the traffic to the
+	// host of an Ingress is routed to, as per the failover routing policy,
+	// while it is healthy
+	ANNOTATION_FAILOVER_PRIMARY = "kuadrant.dev/failover-primary"
+	// ANNOTATION_FAILOVER_SERVING reports the sync targets currently serving
+	// the traffic to the host of an Ingress, as per the failover routing policy
+	ANNOTATION_FAILOVER_SERVING = "kuadrant.dev/failover-serving"
+
+	failoverPrimarySetID   = "primary"
+	failoverSecondarySetID = "secondary"
+	failoverPrimary        = "PRIMARY"
+	failoverSecondary      = "SECONDARY"
+)
+
+// failoverEndpoints returns the PRIMARY failover records of the hostname to the
+// IPs of the primary sync target, and the SECONDARY ones to the IPs of the other
+// sync targets. The records are checked by the health checks of the DNSRecord,
+// so that Route53 resolves the hostname to the secondary IPs while the primary
+// ones are unhealthy. No records are returned if no record type has IPs on
+// both the primary and the secondary sync targets.
+func (r *dnsReconciler) failoverEndpoints(ingress *networkingv1.Ingress, hostname string, targets map[string]map[string][]string, previousEndpoints []*v1.Endpoint) []*v1.Endpoint {
+	clusterNames := make([]string, 0, len(targets))
+	for clusterName := range targets {
+		clusterNames = append(clusterNames, clusterName)
+	}
+	sort.Strings(clusterNames)
+
+	primary := clusterNames[0]
+	if annotated, ok := ingress.Annotations[ANNOTATION_FAILOVER_PRIMARY]; ok {
+		if _, found := targets[annotated]; found {
+			primary = annotated
+		} else {
+			r.log.Info("failover primary sync target not found, using the first sync target as primary", "ingress", ingress.Name, "annotated", annotated, "primary", primary)
+		}
+	}
+
+	var secondaries []string
+	for _, clusterName := range clusterNames {
+		if clusterName != primary {
+			secondaries = append(secondaries, clusterName)
+		}
+	}
+
+	// Reuse the previous failover records, so that the provider specific
+	// properties they have been published with, e.g. the health check, are kept
+	previous := map[string]*v1.Endpoint{}
+	for _, endpoint := range previousEndpoints {
+		previous[endpoint.DNSName+"/"+endpoint.RecordType+"/"+endpoint.SetIdentifier] = endpoint
+	}
+
+	var endpoints []*v1.Endpoint
+	for _, recordType := range []v1.DNSRecordType{v1.ARecordType, v1.AAAARecordType} {
+		primaryIPs := ipsOf(recordType, targets, primary)
+		secondaryIPs := ipsOf(recordType, targets, secondaries...)
+		if len(primaryIPs) == 0 || len(secondaryIPs) == 0 {
+			// Route53 only fails over between records of the same type
+			continue
+		}
+		endpoints = append(endpoints,
+			failoverEndpoint(hostname, recordType, failoverPrimarySetID, failoverPrimary, primaryIPs, []string{primary}, previous),
+			failoverEndpoint(hostname, recordType, failoverSecondarySetID, failoverSecondary, secondaryIPs, secondaries, previous),
+		)
+	}
+	return endpoints
+}
+
+func failoverEndpoint(hostname string, recordType v1.DNSRecordType, setID, failover string, ips, syncTargets []string, previous map[string]*v1.Endpoint) *v1.Endpoint {
+	endpoint, ok := previous[hostname+"/"+string(recordType)+"/"+setID]
+	if !ok {
+		endpoint = &v1.Endpoint{
+			DNSName:       hostname,
+			RecordType:    string(recordType),
+			SetIdentifier: setID,
+		}
+	}
+	endpoint.Targets = ips
+	endpoint.RecordTTL = 60
+	endpoint.SetProviderSpecific(dns.ProviderSpecificFailover, failover)
+	if endpoint.Labels == nil {
+		endpoint.Labels = map[string]string{}
+	}
+	endpoint.Labels[v1.SyncTargetsLabel] = strings.Join(syncTargets, ",")
+	return endpoint
+}
+
+// ipsOf returns the sorted IPs of the record type of the clusters.
+func ipsOf(recordType v1.DNSRecordType, targets map[string]map[string][]string, clusterNames ...string) []string {
+	var ips []string
+	for _, clusterName := range clusterNames {
+		for _, ingressTargets := range targets[clusterName] {
+			for _, ip := range ingressTargets {
+				if recordTypeForIP(ip) == recordType {
+					ips = append(ips, ip)
+				}
+			}
+		}
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// setFailoverServing annotates the ingress with the sync targets serving the
+// traffic, as reported by the FailedOver condition of the DNSRecord.
+func setFailoverServing(ingress *networkingv1.Ingress, record *v1.DNSRecord) {
+	for _, zone := range record.Status.Zones {
+		for _, condition := range zone.Conditions {
+			if condition.Type != v1.DNSRecordFailedOverConditionType {
+				continue
+			}
+			failover := failoverPrimary
+			if condition.Status == "True" {
+				failover = failoverSecondary
+			}
+			for _, endpoint := range record.Spec.Endpoints {
+				if prop, ok := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificFailover); ok && prop.Value == failover {
+					metadata.AddAnnotation(ingress, ANNOTATION_FAILOVER_SERVING, endpoint.Labels[v1.SyncTargetsLabel])
+					return
+				}
+			}
+		}
+	}
+	metadata.RemoveAnnotation(ingress, ANNOTATION_FAILOVER_SERVING)
+}
@@ -0,0 +1,65 @@
+package ingress
+
+import (
+	"strings"
+
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+const (
+	geoContinentPrefix = "continent-"
+	geoCountryPrefix   = "country-"
+	// geoDefault identifies the set serving the users outside the locations
+	// of all the clusters
+	geoDefault = "default"
+	// geoDefaultCountryCode is the Route53 country code of the default location
+	geoDefaultCountryCode = "*"
+)
+
+// geoSets returns the sets of the clusters in each country and continent, and
+// the default set of all the clusters. The hostname resolves to the clusters
+// in the country of the user, or else in their continent, or else to all the
+// clusters. If no cluster is located, no set is returned, so that the traffic
+// is weighted between all the clusters.
+func (r *dnsReconciler) geoSets(targets map[string]map[string][]string) ([]routedSet, error) {
+	sets := map[string]*routedSet{
+		geoDefault: {
+			id:               geoDefault,
+			providerSpecific: map[string]string{dns.ProviderSpecificGeolocationCountryCode: geoDefaultCountryCode},
+		},
+	}
+	addTo := func(id, property, code, clusterName string, clusterTargets map[string][]string) {
+		set, ok := sets[id]
+		if !ok {
+			set = &routedSet{
+				id:               id,
+				providerSpecific: map[string]string{property: code},
+			}
+			sets[id] = set
+		}
+		set.add(clusterName, clusterTargets)
+	}
+
+	for clusterName, clusterTargets := range targets {
+		location, err := r.locate(clusterName)
+		if err != nil {
+			return nil, err
+		}
+		sets[geoDefault].add(clusterName, clusterTargets)
+		if location.continent != "" {
+			addTo(geoContinentPrefix+strings.ToLower(location.continent), dns.ProviderSpecificGeolocationContinentCode, location.continent, clusterName, clusterTargets)
+		}
+		if location.country != "" {
+			addTo(geoCountryPrefix+strings.ToLower(location.country), dns.ProviderSpecificGeolocationCountryCode, location.country, clusterName, clusterTargets)
+		}
+	}
+
+	if len(sets) == 1 {
+		return nil, nil
+	}
+	var result []routedSet
+	for _, set := range sets {
+		result = append(result, *set)
+	}
+	return result, nil
+}
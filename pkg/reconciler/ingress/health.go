@@ -0,0 +1,42 @@
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/util/metadata"
+)
+
+// ANNOTATION_HEALTH_STATUS summarizes the health of the endpoints of the host of
+// an Ingress, as reported by the status of its DNSRecord, e.g. "2/3 endpoints
+// healthy, unhealthy: abc.example.com (primary)"
+const ANNOTATION_HEALTH_STATUS = "kuadrant.dev/health-status"
+
+// setHealthStatus annotates the ingress with the summary of the health of the
+// endpoints of the DNSRecord, if they have health checks.
+func setHealthStatus(ingress *networkingv1.Ingress, record *v1.DNSRecord) {
+	if len(record.Status.HealthChecks) == 0 {
+		metadata.RemoveAnnotation(ingress, ANNOTATION_HEALTH_STATUS)
+		return
+	}
+	var unhealthy []string
+	for _, status := range record.Status.HealthChecks {
+		if status.Healthy {
+			continue
+		}
+		if status.SetIdentifier != "" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", status.DNSName, status.SetIdentifier))
+		} else {
+			unhealthy = append(unhealthy, status.DNSName)
+		}
+	}
+	total := len(record.Status.HealthChecks)
+	summary := fmt.Sprintf("%d/%d endpoints healthy", total-len(unhealthy), total)
+	if len(unhealthy) > 0 {
+		summary += ", unhealthy: " + strings.Join(unhealthy, ", ")
+	}
+	metadata.AddAnnotation(ingress, ANNOTATION_HEALTH_STATUS, summary)
+}
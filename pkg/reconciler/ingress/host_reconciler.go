@@ -3,22 +3,34 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/rs/xid"
 	networkingv1 "k8s.io/api/networking/v1"
+
+	kuadrantv1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
 )
 
 type hostReconciler struct {
-	managedDomain string
-	log           logr.Logger
+	managedDomain            func(ctx context.Context, ingress *networkingv1.Ingress) (string, error)
+	customHostsEnabled       bool
+	getDomainVerifications   func(ctx context.Context, ingress *networkingv1.Ingress) ([]*kuadrantv1.DomainVerification, error)
+	isDomainVerified         func(verification *kuadrantv1.DomainVerification) bool
+	createDomainVerification func(ctx context.Context, ingress *networkingv1.Ingress, domain string) error
+	ownDomainVerifications   func(ctx context.Context, ingress *networkingv1.Ingress, used, verifications []*kuadrantv1.DomainVerification) error
+	log                      logr.Logger
 }
 
 func (r *hostReconciler) reconcile(ctx context.Context, ingress *networkingv1.Ingress) (reconcileStatus, error) {
 	if ingress.Annotations == nil || ingress.Annotations[ANNOTATION_HCG_HOST] == "" {
 
 		// Let's assign it a global hostname if any
-		generatedHost := fmt.Sprintf("%s.%s", xid.New(), r.managedDomain)
+		managedDomain, err := r.managedDomain(ctx, ingress)
+		if err != nil {
+			return reconcileStatusStop, err
+		}
+		generatedHost := fmt.Sprintf("%s.%s", xid.New(), managedDomain)
 		if ingress.Annotations == nil {
 			ingress.Annotations = map[string]string{}
 		}
@@ -29,6 +41,11 @@ func (r *hostReconciler) reconcile(ctx context.Context, ingress *networkingv1.In
 	}
 	//once the annotation is definintely saved continue on
 	managedHost := ingress.Annotations[ANNOTATION_HCG_HOST]
+
+	if r.customHostsEnabled {
+		return r.reconcileCustomHosts(ctx, ingress, managedHost)
+	}
+
 	var customHosts []string
 	for i, rule := range ingress.Spec.Rules {
 		if rule.Host != managedHost {
@@ -46,3 +63,90 @@ func (r *hostReconciler) reconcile(ctx context.Context, ingress *networkingv1.In
 
 	return reconcileStatusContinue, nil
 }
+
+// reconcileCustomHosts keeps the custom hosts whose domain ownership has been
+// verified by the ingress workspace. The rules of the other custom hosts are
+// moved to an annotation, and their host replaced with the managed host, until
+// their domain is verified.
+func (r *hostReconciler) reconcileCustomHosts(ctx context.Context, ingress *networkingv1.Ingress, managedHost string) (reconcileStatus, error) {
+	verifications, err := r.getDomainVerifications(ctx, ingress)
+	if err != nil {
+		return reconcileStatusStop, err
+	}
+	pendingRules, err := pendingCustomHostRules(ingress)
+	if err != nil {
+		return reconcileStatusStop, err
+	}
+
+	// Restore the rules whose custom host has been verified
+	var stillPending []networkingv1.IngressRule
+	for _, rule := range pendingRules {
+		if !isVerified(rule.Host, verifications, r.isDomainVerified) {
+			stillPending = append(stillPending, rule)
+			continue
+		}
+		if !hasRuleForHost(ingress, rule.Host) {
+			ingress.Spec.Rules = append(ingress.Spec.Rules, rule)
+		}
+	}
+
+	var verifiedHosts, replacedHosts, unverifiedDomains []string
+	for i, rule := range ingress.Spec.Rules {
+		switch {
+		case rule.Host == managedHost:
+		case rule.Host == "":
+			ingress.Spec.Rules[i].Host = managedHost
+		case isVerified(rule.Host, verifications, r.isDomainVerified):
+			verifiedHosts = append(verifiedHosts, rule.Host)
+		default:
+			stillPending = upsertRule(stillPending, *rule.DeepCopy())
+			ingress.Spec.Rules[i].Host = managedHost
+			replacedHosts = append(replacedHosts, rule.Host)
+			if verificationFor(rule.Host, verifications, r.isDomainVerified) == nil {
+				unverifiedDomains = append(unverifiedDomains, domainOf(rule.Host))
+			}
+		}
+	}
+	// clean up replaced hosts from the tls list
+	removeHostsFromTLS(replacedHosts, ingress)
+
+	for _, domain := range unverifiedDomains {
+		if err := r.createDomainVerification(ctx, ingress, domain); err != nil {
+			return reconcileStatusStop, err
+		}
+	}
+
+	// The ingress owns the verifications of the domains of its custom hosts,
+	// until it is deleted
+	var used []*kuadrantv1.DomainVerification
+	if ingress.DeletionTimestamp == nil {
+		for _, host := range verifiedHosts {
+			used = append(used, verificationFor(host, verifications, r.isDomainVerified))
+		}
+		for _, rule := range stillPending {
+			if verification := verificationFor(rule.Host, verifications, r.isDomainVerified); verification != nil {
+				used = append(used, verification)
+			}
+		}
+	}
+	if err := r.ownDomainVerifications(ctx, ingress, used, verifications); err != nil {
+		return reconcileStatusStop, err
+	}
+
+	if len(verifiedHosts) > 0 {
+		ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS] = strings.Join(verifiedHosts, ",")
+	} else {
+		delete(ingress.Annotations, ANNOTATION_HCG_CUSTOM_HOSTS)
+	}
+	if err := setPendingCustomHostRules(ingress, stillPending); err != nil {
+		return reconcileStatusStop, err
+	}
+	if len(replacedHosts) > 0 {
+		ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOST_REPLACED] = fmt.Sprintf(" replaced custom hosts %v to the glbc host until the ownership of their domain is verified",
+			replacedHosts)
+	} else if len(stillPending) == 0 {
+		delete(ingress.Annotations, ANNOTATION_HCG_CUSTOM_HOST_REPLACED)
+	}
+
+	return reconcileStatusContinue, nil
+}
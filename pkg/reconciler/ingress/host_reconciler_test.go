@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kuadrantv1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
 )
 
 type hostResult struct {
@@ -103,7 +106,9 @@ func TestReconcileHost(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
 			reconciler := &hostReconciler{
-				managedDomain: mangedDomain,
+				managedDomain: func(ctx context.Context, ingress *networkingv1.Ingress) (string, error) {
+					return mangedDomain, nil
+				},
 			}
 
 			if err := tc.Validate(buildResult(reconciler, tc.Ingress())); err != nil {
@@ -113,3 +118,107 @@ func TestReconcileHost(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileCustomHosts(t *testing.T) {
+	verifications := []*kuadrantv1.DomainVerification{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+			Spec:       kuadrantv1.DomainVerificationSpec{Domain: "example.com"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "example.org"},
+			Spec:       kuadrantv1.DomainVerificationSpec{Domain: "example.org"},
+			// The status written in the workspace is not trusted
+			Status: kuadrantv1.DomainVerificationStatus{Verified: true},
+		},
+	}
+	verified := map[string]bool{"example.com": true}
+	var created, owned []string
+	reconciler := &hostReconciler{
+		customHostsEnabled: true,
+		getDomainVerifications: func(ctx context.Context, ingress *networkingv1.Ingress) ([]*kuadrantv1.DomainVerification, error) {
+			return verifications, nil
+		},
+		isDomainVerified: func(verification *kuadrantv1.DomainVerification) bool {
+			return verified[verification.Spec.Domain]
+		},
+		createDomainVerification: func(ctx context.Context, ingress *networkingv1.Ingress, domain string) error {
+			created = append(created, domain)
+			return nil
+		},
+		ownDomainVerifications: func(ctx context.Context, ingress *networkingv1.Ingress, used, _ []*kuadrantv1.DomainVerification) error {
+			owned = nil
+			for _, verification := range used {
+				owned = append(owned, verification.Name)
+			}
+			return nil
+		},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{ANNOTATION_HCG_HOST: "123.test.com"},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{Host: "api.example.com"},
+				{Host: "api.example.org"},
+				{Host: "api.example.net"},
+			},
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"api.example.org"}}},
+		},
+	}
+
+	status, err := reconciler.reconcile(context.TODO(), ingress)
+	if err != nil || status != reconcileStatusContinue {
+		t.Fatalf("unexpected result from reconcile: %v, %v", status, err)
+	}
+	// The verified host is kept, and the sub-domains of a verified domain need no verification
+	if hosts := []string{ingress.Spec.Rules[0].Host, ingress.Spec.Rules[1].Host, ingress.Spec.Rules[2].Host}; hosts[0] != "api.example.com" || hosts[1] != "123.test.com" || hosts[2] != "123.test.com" {
+		t.Fatalf("unexpected hosts %v", hosts)
+	}
+	if ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS] != "api.example.com" {
+		t.Fatalf("expected the verified custom host in annotation %s", ANNOTATION_HCG_CUSTOM_HOSTS)
+	}
+	if len(ingress.Spec.TLS) != 0 {
+		t.Fatalf("expected the TLS section of the unverified host to be removed")
+	}
+	// A verification is only created for the domains without one
+	if len(created) != 1 || created[0] != "api.example.net" {
+		t.Fatalf("unexpected domain verifications created %v", created)
+	}
+	pending, err := pendingCustomHostRules(ingress)
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("expected 2 pending rules, got %v, %v", pending, err)
+	}
+
+	// The ingress owns the verifications of its verified and pending custom hosts
+	if len(owned) != 2 || owned[0] != "example.com" || owned[1] != "example.org" {
+		t.Fatalf("unexpected owned domain verifications %v", owned)
+	}
+
+	// The pending rules are restored once the domain is verified
+	verified["example.org"] = true
+	if _, err := reconciler.reconcile(context.TODO(), ingress); err != nil {
+		t.Fatalf("unexpected error from reconcile: %v", err)
+	}
+	if !hasRuleForHost(ingress, "api.example.org") {
+		t.Fatalf("expected the rule of the verified host to be restored")
+	}
+	if ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS] != "api.example.com,api.example.org" {
+		t.Fatalf("unexpected verified custom hosts %s", ingress.Annotations[ANNOTATION_HCG_CUSTOM_HOSTS])
+	}
+	pending, _ = pendingCustomHostRules(ingress)
+	if len(pending) != 1 || pending[0].Host != "api.example.net" {
+		t.Fatalf("unexpected pending rules %v", pending)
+	}
+
+	// The ingress no longer owns any verification once deleted
+	ingress.DeletionTimestamp = &metav1.Time{}
+	if _, err := reconciler.reconcile(context.TODO(), ingress); err != nil {
+		t.Fatalf("unexpected error from reconcile: %v", err)
+	}
+	if len(owned) != 0 {
+		t.Fatalf("unexpected owned domain verifications %v", owned)
+	}
+}
@@ -30,34 +30,48 @@ func (c *Controller) reconcile(ctx context.Context, ingress *networkingv1.Ingres
 		metadata.AddFinalizer(ingress, cascadeCleanupFinalizer)
 	}
 	//TODO evaluate where this actually belongs
-	workloadMigration.Process(ingress, c.Queue, c.Logger)
+	workloadMigration.Process(ingress, c.Queue, c.drainStrategy, c.Logger)
+
+	// the sync targets are located if they are informed
+	var locate func(syncTarget string) (clusterLocation, error)
+	if c.syncTargetLister != nil {
+		locate = c.locationOf
+	}
 
 	reconcilers := []reconciler{
 		//hostReconciler is first as the others depends on it for the host to be set on the ingress
 		&hostReconciler{
-			managedDomain: c.domain,
-			log:           c.Logger,
+			managedDomain:            c.managedDomain,
+			customHostsEnabled:       c.customHostsEnabled,
+			getDomainVerifications:   c.getDomainVerifications,
+			isDomainVerified:         c.isDomainVerified,
+			createDomainVerification: c.createDomainVerification,
+			ownDomainVerifications:   c.ownDomainVerifications,
+			log:                      c.Logger,
 		},
 		&certificateReconciler{
-			createCertificate:    c.certProvider.Create,
-			deleteCertificate:    c.certProvider.Delete,
-			getCertificateSecret: c.certProvider.GetCertificateSecret,
-			updateCertificate:    c.certProvider.Update,
-			getCertificateStatus: c.certProvider.GetCertificateStatus,
-			copySecret:           c.copySecret,
-			deleteSecret:         c.deleteTLSSecret,
-			log:                  c.Logger,
+			createCertificate:          c.certProvider.Create,
+			deleteCertificate:          c.certProvider.Delete,
+			getCertificateSecret:       c.certProvider.GetCertificateSecret,
+			updateCertificate:          c.certProvider.Update,
+			getCertificateStatus:       c.certProvider.GetCertificateStatus,
+			copySecret:                 c.copySecret,
+			deleteSecret:               c.deleteTLSSecret,
+			listCustomHostCertificates: c.listCustomHostCertificates,
+			log:                        c.Logger,
 		},
 		&dnsReconciler{
-			deleteDNS:        c.deleteDNS,
-			DNSLookup:        c.hostResolver.LookupIPAddr,
-			getDNS:           c.getDNS,
-			createDNS:        c.createDNS,
-			updateDNS:        c.updateDNS,
-			watchHost:        c.hostsWatcher.StartWatching,
-			forgetHost:       c.hostsWatcher.StopWatching,
-			listHostWatchers: c.hostsWatcher.ListHostRecordWatchers,
-			log:              c.Logger,
+			deleteDNS:            c.deleteDNS,
+			DNSLookup:            c.hostResolver.LookupIPAddr,
+			getDNS:               c.getDNS,
+			createDNS:            c.createDNS,
+			updateDNS:            c.updateDNS,
+			watchHost:            c.hostsWatcher.StartWatching,
+			forgetHost:           c.hostsWatcher.StopWatching,
+			listHostWatchers:     c.hostsWatcher.ListHostRecordWatchers,
+			locate:               locate,
+			defaultRoutingPolicy: c.routingPolicy,
+			log:                  c.Logger,
 		},
 	}
 	var errs []error
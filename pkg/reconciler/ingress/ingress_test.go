@@ -1,6 +1,14 @@
 package ingress
 
-import "testing"
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func Test_awsEndpointWeight(t *testing.T) {
 	type args struct {
@@ -76,3 +84,188 @@ func Test_awsEndpointWeight(t *testing.T) {
 		})
 	}
 }
+
+func Test_recordTypeForIP(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "IPv4", ip: "172.32.0.1", want: "A"},
+		{name: "IPv4-mapped IPv6", ip: "::ffff:172.32.0.1", want: "A"},
+		{name: "IPv6", ip: "2001:db8::1", want: "AAAA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recordTypeForIP(tt.ip); string(got) != tt.want {
+				t.Errorf("recordTypeForIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_normalizeIP(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "IPv4", ip: "172.32.0.1", want: "172.32.0.1"},
+		{name: "IPv4-mapped IPv6", ip: "::ffff:172.32.0.1", want: "172.32.0.1"},
+		{name: "IPv6", ip: "2001:db8::1", want: "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeIP(tt.ip); got != tt.want {
+				t.Errorf("normalizeIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_routedEndpoints(t *testing.T) {
+	locations := map[string]clusterLocation{
+		"eu-1": {continent: "EU", country: "DE", region: "eu-central-1"},
+		"eu-2": {continent: "EU", region: "eu-west-1"},
+		"us-1": {region: "us-east-1"},
+		"xx-1": {},
+	}
+	targets := map[string]map[string][]string{
+		"eu-1": {"172.32.0.1": {"172.32.0.1"}},
+		"eu-2": {"172.32.0.2": {"172.32.0.2"}},
+		"us-1": {"172.32.0.3": {"172.32.0.3"}},
+	}
+	tests := []struct {
+		name    string
+		policy  string
+		primary string
+		targets map[string]map[string][]string
+		want    []string
+	}{
+		{
+			name:    "weighted",
+			targets: targets,
+			want: []string{
+				"A abc.example.com [172.32.0.1]",
+				"A abc.example.com [172.32.0.2]",
+				"A abc.example.com [172.32.0.3]",
+			},
+		},
+		{
+			name:    "geo",
+			policy:  "geo",
+			targets: targets,
+			want: []string{
+				"CNAME abc.example.com [continent-eu.abc.example.com]",
+				"CNAME abc.example.com [country-de.abc.example.com]",
+				"CNAME abc.example.com [default.abc.example.com]",
+				"A continent-eu.abc.example.com [172.32.0.1]",
+				"A continent-eu.abc.example.com [172.32.0.2]",
+				"A country-de.abc.example.com [172.32.0.1]",
+				"A default.abc.example.com [172.32.0.1]",
+				"A default.abc.example.com [172.32.0.2]",
+				"A default.abc.example.com [172.32.0.3]",
+			},
+		},
+		{
+			name:    "geo without located cluster",
+			policy:  "geo",
+			targets: map[string]map[string][]string{"us-1": targets["us-1"]},
+			want:    []string{"A abc.example.com [172.32.0.3]"},
+		},
+		{
+			name:    "latency",
+			policy:  "latency",
+			targets: targets,
+			want: []string{
+				"CNAME abc.example.com [eu-central-1.abc.example.com]",
+				"CNAME abc.example.com [eu-west-1.abc.example.com]",
+				"CNAME abc.example.com [us-east-1.abc.example.com]",
+				"A eu-central-1.abc.example.com [172.32.0.1]",
+				"A eu-west-1.abc.example.com [172.32.0.2]",
+				"A us-east-1.abc.example.com [172.32.0.3]",
+			},
+		},
+		{
+			name:    "latency with a cluster without region",
+			policy:  "latency",
+			targets: map[string]map[string][]string{"us-1": targets["us-1"], "xx-1": {"172.32.0.4": {"172.32.0.4"}}},
+			want: []string{
+				"A abc.example.com [172.32.0.3]",
+				"A abc.example.com [172.32.0.4]",
+			},
+		},
+		{
+			name:    "failover",
+			policy:  "failover",
+			targets: targets,
+			want: []string{
+				"A abc.example.com [172.32.0.1]",
+				"A abc.example.com [172.32.0.2 172.32.0.3]",
+			},
+		},
+		{
+			name:    "failover with annotated primary",
+			policy:  "failover",
+			primary: "us-1",
+			targets: targets,
+			want: []string{
+				"A abc.example.com [172.32.0.3]",
+				"A abc.example.com [172.32.0.1 172.32.0.2]",
+			},
+		},
+		{
+			name:    "failover with a secondary cluster without address",
+			policy:  "failover",
+			targets: map[string]map[string][]string{"eu-1": targets["eu-1"], "eu-2": {}},
+			want:    []string{"A abc.example.com [172.32.0.1]"},
+		},
+		{
+			name:    "failover without records of the same type",
+			policy:  "failover",
+			targets: map[string]map[string][]string{"eu-1": targets["eu-1"], "eu-2": {"2001:db8::2": {"2001:db8::2"}}},
+			want: []string{
+				"A abc.example.com [172.32.0.1]",
+				"AAAA abc.example.com [2001:db8::2]",
+			},
+		},
+		{
+			name:    "failover with a single cluster",
+			policy:  "failover",
+			targets: map[string]map[string][]string{"us-1": targets["us-1"]},
+			want:    []string{"A abc.example.com [172.32.0.3]"},
+		},
+	}
+	r := &dnsReconciler{
+		locate: func(syncTarget string) (clusterLocation, error) {
+			return locations[syncTarget], nil
+		},
+		defaultRoutingPolicy: RoutingPolicyWeighted,
+		log:                  logr.Discard(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+			if tt.policy != "" {
+				ingress.Annotations[ANNOTATION_ROUTING_POLICY] = tt.policy
+			}
+			if tt.primary != "" {
+				ingress.Annotations[ANNOTATION_FAILOVER_PRIMARY] = tt.primary
+			}
+			endpoints, err := r.routedEndpoints(ingress, "abc.example.com", tt.targets, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, endpoint := range endpoints {
+				got = append(got, fmt.Sprintf("%s %s %v", endpoint.RecordType, endpoint.DNSName, []string(endpoint.Targets)))
+			}
+			sort.Strings(got)
+			want := append([]string{}, tt.want...)
+			sort.Strings(want)
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("routedEndpoints() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package ingress
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kcp-dev/logicalcluster"
+)
+
+const (
+	// LABEL_GEO_CONTINENT is the label of the SyncTargets, or of the Locations
+	// selecting them, holding the two-letter code of the continent of the
+	// cluster, e.g. EU
+	LABEL_GEO_CONTINENT = "kuadrant.dev/geo-continent"
+	// LABEL_GEO_COUNTRY is the label of the SyncTargets, or of the Locations
+	// selecting them, holding the ISO 3166 two-letter code of the country of
+	// the cluster, e.g. DE
+	LABEL_GEO_COUNTRY = "kuadrant.dev/geo-country"
+	// LABEL_REGION is the label of the SyncTargets, or of the Locations
+	// selecting them, holding the cloud region of the cluster, e.g. eu-west-1
+	LABEL_REGION = "topology.kubernetes.io/region"
+)
+
+// clusterLocation is the location of a cluster.
+type clusterLocation struct {
+	continent string
+	country   string
+	region    string
+}
+
+// locationOf returns the location of the sync target, as labelled on the
+// SyncTarget, or else on the Locations selecting it. It returns an empty
+// location if the sync target is unknown.
+func (c *Controller) locationOf(syncTarget string) (clusterLocation, error) {
+	syncTargets, err := c.syncTargetLister.List(labels.Everything())
+	if err != nil {
+		return clusterLocation{}, err
+	}
+	for _, target := range syncTargets {
+		if target.Name != syncTarget {
+			continue
+		}
+		location := clusterLocation{
+			continent: target.Labels[LABEL_GEO_CONTINENT],
+			country:   target.Labels[LABEL_GEO_COUNTRY],
+			region:    target.Labels[LABEL_REGION],
+		}
+		if location.continent != "" && location.country != "" && location.region != "" {
+			return location.normalized(), nil
+		}
+
+		locations, err := c.locationLister.List(labels.Everything())
+		if err != nil {
+			return clusterLocation{}, err
+		}
+		for _, l := range locations {
+			if l.Spec.InstanceSelector == nil || logicalcluster.From(l) != logicalcluster.From(target) {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(l.Spec.InstanceSelector)
+			if err != nil || !selector.Matches(labels.Set(target.Labels)) {
+				continue
+			}
+			if location.continent == "" {
+				location.continent = l.Labels[LABEL_GEO_CONTINENT]
+			}
+			if location.country == "" {
+				location.country = l.Labels[LABEL_GEO_COUNTRY]
+			}
+			if location.region == "" {
+				location.region = l.Labels[LABEL_REGION]
+			}
+		}
+		return location.normalized(), nil
+	}
+	return clusterLocation{}, nil
+}
+
+func (l clusterLocation) normalized() clusterLocation {
+	return clusterLocation{
+		continent: strings.ToUpper(strings.TrimSpace(l.continent)),
+		country:   strings.ToUpper(strings.TrimSpace(l.country)),
+		region:    strings.ToLower(strings.TrimSpace(l.region)),
+	}
+}
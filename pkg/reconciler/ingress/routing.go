@@ -0,0 +1,177 @@
+package ingress
+
+import (
+	"fmt"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	v1 "github.com/kuadrant/kcp-glbc/pkg/apis/kuadrant/v1"
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+)
+
+// ANNOTATION_ROUTING_POLICY selects the policy routing the traffic to the
+// host of an Ingress between its clusters.
+const ANNOTATION_ROUTING_POLICY = "kuadrant.dev/routing-policy"
+
+// RoutingPolicy is a policy routing the traffic between the clusters of an
+// Ingress.
+type RoutingPolicy string
+
+const (
+	// RoutingPolicyWeighted splits the traffic evenly between the clusters.
+	RoutingPolicyWeighted RoutingPolicy = "weighted"
+	// RoutingPolicyLatency routes the traffic to the clusters in the cloud
+	// region with the lowest latency for the users.
+	RoutingPolicyLatency RoutingPolicy = "latency"
+	// RoutingPolicyGeo routes the traffic to the clusters in the country, or
+	// else in the continent, of the users.
+	RoutingPolicyGeo RoutingPolicy = "geo"
+	// RoutingPolicyFailover routes the traffic to the primary cluster, and to
+	// the other clusters while it is unhealthy.
+	RoutingPolicyFailover RoutingPolicy = "failover"
+)
+
+// ParseRoutingPolicy returns the routing policy of the value.
+func ParseRoutingPolicy(value string) (RoutingPolicy, error) {
+	switch policy := RoutingPolicy(value); policy {
+	case RoutingPolicyWeighted, RoutingPolicyLatency, RoutingPolicyGeo, RoutingPolicyFailover:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown routing policy %q, must be one of [%s, %s, %s, %s]", value,
+		RoutingPolicyWeighted, RoutingPolicyLatency, RoutingPolicyGeo, RoutingPolicyFailover)
+}
+
+// routingPolicy returns the routing policy selected by the annotation of the
+// ingress, or else the default one.
+func (r *dnsReconciler) routingPolicy(ingress *networkingv1.Ingress) RoutingPolicy {
+	value, ok := ingress.Annotations[ANNOTATION_ROUTING_POLICY]
+	if !ok {
+		return r.defaultRoutingPolicy
+	}
+	policy, err := ParseRoutingPolicy(value)
+	if err != nil {
+		r.log.Error(err, "invalid routing policy annotation, using the default routing policy", "ingress", ingress.Name, "default", r.defaultRoutingPolicy)
+		return r.defaultRoutingPolicy
+	}
+	return policy
+}
+
+// routedEndpoints returns the endpoints of the hostname routing the traffic
+// between the clusters as per the routing policy of the ingress.
+func (r *dnsReconciler) routedEndpoints(ingress *networkingv1.Ingress, hostname string, targets map[string]map[string][]string, currentEndpoints map[string]*v1.Endpoint, previousEndpoints []*v1.Endpoint) ([]*v1.Endpoint, error) {
+	policy := r.routingPolicy(ingress)
+	weights := r.clusterWeights(ingress, targets)
+	if policy != RoutingPolicyWeighted {
+		// The other routing policies do not weight the traffic between all the
+		// clusters, so the drained clusters are not routed to at all
+		targets = withoutDrained(targets, weights)
+	}
+	if policy == RoutingPolicyFailover && len(targets) > 1 {
+		if endpoints := r.failoverEndpoints(ingress, hostname, targets, previousEndpoints); len(endpoints) > 0 {
+			return endpoints, nil
+		}
+		// No failover records can be paired yet, e.g. the secondary clusters
+		// have no address, or none of the record type of the primary one
+		r.log.Info("no failover records of the same type for the primary and secondary sync targets, using the weighted routing policy", "ingress", ingress.Name)
+		policy = RoutingPolicyWeighted
+	}
+	if r.locate == nil {
+		// The locations of the clusters are unknown
+		policy = RoutingPolicyWeighted
+	}
+
+	var sets []routedSet
+	var err error
+	switch policy {
+	case RoutingPolicyLatency:
+		sets, err = r.latencySets(targets)
+	case RoutingPolicyGeo:
+		sets, err = r.geoSets(targets)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return weightedClusterEndpoints(hostname, targets, weights, currentEndpoints), nil
+	}
+	return routedSetEndpoints(hostname, sets, weights, currentEndpoints), nil
+}
+
+// routedSet is a set of clusters served under a name of its own, i.e.,
+// <id>.<hostname>, where the traffic is weighted between them. As Route53 does
+// not permit mixing routing policies within a record set, the hostname is a
+// CNAME record to the name of each set, routed as per the provider specific
+// properties of the set.
+type routedSet struct {
+	id               string
+	providerSpecific map[string]string
+	targets          map[string]map[string][]string
+}
+
+func (s *routedSet) add(clusterName string, clusterTargets map[string][]string) {
+	if s.targets == nil {
+		s.targets = map[string]map[string][]string{}
+	}
+	s.targets[clusterName] = clusterTargets
+}
+
+// routedSetEndpoints returns the CNAME records of the hostname to the names
+// of the sets, and the weighted records of each set.
+func routedSetEndpoints(hostname string, sets []routedSet, weights map[string]int, currentEndpoints map[string]*v1.Endpoint) []*v1.Endpoint {
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].id < sets[j].id
+	})
+
+	var endpoints []*v1.Endpoint
+	for _, set := range sets {
+		setHost := set.id + "." + hostname
+		endpoint := &v1.Endpoint{
+			DNSName:       hostname,
+			RecordType:    string(v1.CNAMERecordType),
+			SetIdentifier: set.id,
+			Targets:       []string{setHost},
+			RecordTTL:     60,
+		}
+		for name, value := range set.providerSpecific {
+			endpoint.SetProviderSpecific(name, value)
+		}
+		endpoints = append(endpoints, endpoint)
+
+		endpoints = append(endpoints, weightedClusterEndpoints(setHost, set.targets, weights, currentEndpoints)...)
+	}
+	return endpoints
+}
+
+// latencySets returns the sets of the clusters of each cloud region. If any
+// of the clusters is not located in a region, no set is returned, so that the
+// traffic is weighted between all the clusters, rather than not routed to
+// that cluster.
+func (r *dnsReconciler) latencySets(targets map[string]map[string][]string) ([]routedSet, error) {
+	regions := map[string]*routedSet{}
+	for clusterName, clusterTargets := range targets {
+		location, err := r.locate(clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if location.region == "" {
+			r.log.Info("cluster has no region, weighting the traffic between all the clusters", "cluster", clusterName)
+			return nil, nil
+		}
+		set, ok := regions[location.region]
+		if !ok {
+			set = &routedSet{
+				id:               location.region,
+				providerSpecific: map[string]string{dns.ProviderSpecificRegion: location.region},
+			}
+			regions[location.region] = set
+		}
+		set.add(clusterName, clusterTargets)
+	}
+
+	var sets []routedSet
+	for _, set := range regions {
+		sets = append(sets, *set)
+	}
+	return sets, nil
+}
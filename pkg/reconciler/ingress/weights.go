@@ -0,0 +1,107 @@
+package ingress
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/kuadrant/kcp-glbc/pkg/util/workloadMigration"
+)
+
+const (
+	// ANNOTATION_CLUSTER_WEIGHTS assigns relative weights to the sync targets
+	// of an Ingress, as a comma-separated list of <sync target>=<weight>, e.g.
+	// old=90,new=10. The traffic is split between the sync targets in
+	// proportion to their weights, and a sync target with a weight of 0 is
+	// drained. The weight of the sync targets that are not listed is the one
+	// of *, e.g. *=50, or else defaultClusterWeight.
+	ANNOTATION_CLUSTER_WEIGHTS = "kuadrant.dev/cluster-weights"
+
+	clusterWeightsDefaultKey = "*"
+	defaultClusterWeight     = 100
+	// maxEndpointWeight is the weight allowance of the cluster with the
+	// highest weight, that is split evenly between its IPs
+	maxEndpointWeight = 120
+)
+
+// parseClusterWeights returns the weights of the sync targets listed in the
+// value, and the weight of the other sync targets.
+func parseClusterWeights(value string) (map[string]int, int, error) {
+	weights := map[string]int{}
+	defaultWeight := defaultClusterWeight
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, 0, fmt.Errorf("invalid cluster weight %q, must be <sync target>=<weight>", entry)
+		}
+		name := strings.TrimSpace(parts[0])
+		weight, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 16)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid weight of cluster %s %q, must be an integer between 0 and 65535", name, parts[1])
+		}
+		if name == clusterWeightsDefaultKey {
+			defaultWeight = int(weight)
+			continue
+		}
+		if _, ok := weights[name]; ok {
+			return nil, 0, fmt.Errorf("duplicate weight of cluster %s", name)
+		}
+		weights[name] = int(weight)
+	}
+	return weights, defaultWeight, nil
+}
+
+// clusterWeights returns the weights of the clusters of the ingress, as
+// assigned by its annotation, and stepped down for the clusters being drained.
+// An invalid annotation is ignored, so that the traffic is split evenly between
+// the clusters.
+func (r *dnsReconciler) clusterWeights(ingress *networkingv1.Ingress, targets map[string]map[string][]string) map[string]int {
+	assigned := map[string]int{}
+	defaultWeight := defaultClusterWeight
+	if value, ok := ingress.Annotations[ANNOTATION_CLUSTER_WEIGHTS]; ok {
+		var err error
+		if assigned, defaultWeight, err = parseClusterWeights(value); err != nil {
+			r.log.Error(err, "invalid cluster weights annotation, splitting the traffic evenly", "ingress", ingress.Name)
+			assigned, defaultWeight = map[string]int{}, defaultClusterWeight
+		}
+	}
+
+	weights := make(map[string]int, len(targets))
+	for clusterName := range targets {
+		weight, ok := assigned[clusterName]
+		if !ok {
+			weight = defaultWeight
+		}
+		// Step the weight of a cluster the workload is migrated away from down
+		// as its traffic is drained
+		if percent, draining := workloadMigration.DrainPercent(ingress, clusterName); draining && weight > 0 {
+			weight = weight * percent / 100
+			if weight == 0 && percent > 0 {
+				weight = 1
+			}
+		}
+		weights[clusterName] = weight
+	}
+	return weights
+}
+
+// withoutDrained returns the targets of the clusters that are not drained, or
+// all the targets if all the clusters are drained.
+func withoutDrained(targets map[string]map[string][]string, weights map[string]int) map[string]map[string][]string {
+	result := map[string]map[string][]string{}
+	for clusterName, clusterTargets := range targets {
+		if weights[clusterName] > 0 {
+			result[clusterName] = clusterTargets
+		}
+	}
+	if len(result) == 0 {
+		return targets
+	}
+	return result
+}
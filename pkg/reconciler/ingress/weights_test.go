@@ -0,0 +1,125 @@
+package ingress
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kuadrant/kcp-glbc/pkg/dns"
+	"github.com/kuadrant/kcp-glbc/pkg/util/workloadMigration"
+)
+
+func Test_parseClusterWeights(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		want        map[string]int
+		wantDefault int
+		wantErr     bool
+	}{
+		{name: "empty", value: "", want: map[string]int{}, wantDefault: defaultClusterWeight},
+		{name: "weights", value: "old=90, new=10", want: map[string]int{"old": 90, "new": 10}, wantDefault: defaultClusterWeight},
+		{name: "default weight", value: "new=0,*=50", want: map[string]int{"new": 0}, wantDefault: 50},
+		{name: "missing weight", value: "old", wantErr: true},
+		{name: "negative weight", value: "old=-1", wantErr: true},
+		{name: "duplicate cluster", value: "old=1,old=2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotDefault, err := parseClusterWeights(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClusterWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || gotDefault != tt.wantDefault {
+				t.Errorf("parseClusterWeights() = %v, %v, want %v, %v", got, gotDefault, tt.want, tt.wantDefault)
+			}
+		})
+	}
+}
+
+func Test_awsClusterEndpointWeight(t *testing.T) {
+	tests := []struct {
+		name      string
+		numIPs    int
+		weight    int
+		maxWeight int
+		want      string
+	}{
+		{name: "highest weight", numIPs: 2, weight: 90, maxWeight: 90, want: "60"},
+		{name: "lower weight", numIPs: 1, weight: 10, maxWeight: 90, want: "13"},
+		{name: "lower weight split between IPs", numIPs: 2, weight: 10, maxWeight: 90, want: "6"},
+		{name: "lower weight with many IPs", numIPs: 100, weight: 10, maxWeight: 90, want: "1"},
+		{name: "drained", numIPs: 1, weight: 0, maxWeight: 90, want: "0"},
+		{name: "all drained", numIPs: 1, weight: 0, maxWeight: 0, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := awsClusterEndpointWeight(tt.numIPs, tt.weight, tt.maxWeight); got != tt.want {
+				t.Errorf("awsClusterEndpointWeight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_weightedClusterEndpoints(t *testing.T) {
+	targets := map[string]map[string][]string{
+		"old": {"lb.old.example.com": {"172.32.0.1", "172.32.0.2"}},
+		"new": {"172.32.0.3": {"172.32.0.3"}},
+		"xxx": {"172.32.0.4": {"172.32.0.4"}},
+	}
+	tests := []struct {
+		name    string
+		weights string
+		drain   string
+		want    []string
+	}{
+		{
+			name: "even",
+			want: []string{"172.32.0.1=60", "172.32.0.2=60", "172.32.0.3=120", "172.32.0.4=120"},
+		},
+		{
+			name:    "canary",
+			weights: "old=90,new=10,xxx=0",
+			want:    []string{"172.32.0.1=60", "172.32.0.2=60", "172.32.0.3=13", "172.32.0.4=0"},
+		},
+		{
+			name:  "draining",
+			drain: `{"percent":25,"nextStepAt":"2022-01-01T00:00:00Z"}`,
+			want:  []string{"172.32.0.1=60", "172.32.0.2=60", "172.32.0.3=30", "172.32.0.4=120"},
+		},
+		{
+			name:    "invalid",
+			weights: "old=ninety",
+			want:    []string{"172.32.0.1=60", "172.32.0.2=60", "172.32.0.3=120", "172.32.0.4=120"},
+		},
+	}
+	r := &dnsReconciler{log: logr.Discard()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+			if tt.weights != "" {
+				ingress.Annotations[ANNOTATION_CLUSTER_WEIGHTS] = tt.weights
+			}
+			if tt.drain != "" {
+				ingress.Annotations[workloadMigration.DrainAnnotation+"-new"] = tt.drain
+			}
+			endpoints := weightedClusterEndpoints("abc.example.com", targets, r.clusterWeights(ingress, targets), nil)
+			var got []string
+			for _, endpoint := range endpoints {
+				weight, _ := endpoint.GetProviderSpecificProperty(dns.ProviderSpecificWeight)
+				got = append(got, endpoint.Targets[0]+"="+weight.Value)
+			}
+			sort.Strings(got)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("weightedClusterEndpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
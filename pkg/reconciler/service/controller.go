@@ -15,6 +15,7 @@ import (
 	"github.com/kcp-dev/logicalcluster"
 
 	"github.com/kuadrant/kcp-glbc/pkg/reconciler"
+	"github.com/kuadrant/kcp-glbc/pkg/util/workloadMigration"
 )
 
 const controllerName = "kcp-glbc-service"
@@ -26,6 +27,10 @@ func NewController(config *ControllerConfig) (*Controller, error) {
 		Controller:            reconciler.NewController(controllerName, queue),
 		coreClient:            config.ServicesClient,
 		sharedInformerFactory: config.SharedInformerFactory,
+		drainStrategy:         workloadMigration.DefaultDrainStrategy,
+	}
+	if config.DrainStrategy != nil {
+		c.drainStrategy = *config.DrainStrategy
 	}
 	c.Process = c.process
 
@@ -44,6 +49,9 @@ func NewController(config *ControllerConfig) (*Controller, error) {
 type ControllerConfig struct {
 	ServicesClient        kubernetes.ClusterInterface
 	SharedInformerFactory informers.SharedInformerFactory
+	// DrainStrategy delays the release of the Services migrated away from a
+	// cluster, defaults to workloadMigration.DefaultDrainStrategy
+	DrainStrategy *workloadMigration.DrainStrategy
 }
 
 type Controller struct {
@@ -52,6 +60,7 @@ type Controller struct {
 	coreClient            kubernetes.ClusterInterface
 	indexer               cache.Indexer
 	serviceLister         corev1listers.ServiceLister
+	drainStrategy         workloadMigration.DrainStrategy
 }
 
 func (c *Controller) process(ctx context.Context, key string) error {
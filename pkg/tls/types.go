@@ -35,10 +35,13 @@ type Provider interface {
 }
 
 type CertificateRequest struct {
-	Name             string
-	Labels           map[string]string
-	Annotations      map[string]string
-	Host             string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	Host        string
+	// CustomHost is set for hosts that do not belong to a managed domain, and
+	// whose domain ownership has been verified
+	CustomHost       bool
 	cleanUpFinalizer bool
 }
 
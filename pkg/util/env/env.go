@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 const namespaceEnvVariable = "NAMESPACE"
@@ -27,6 +28,18 @@ func GetEnvBool(key string, fallback bool) bool {
 	return value
 }
 
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	strValue, found := os.LookupEnv(key)
+	if !found {
+		return fallback
+	}
+	value, err := time.ParseDuration(strValue)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 func GetNamespace() string {
 	return GetEnvString(namespaceEnvVariable, "")
 }
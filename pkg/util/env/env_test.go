@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // These tests cannot be run in parallel and should be updated to use testing.SetEnv if/when we update to go 1.17+ https://pkg.go.dev/testing#B.Setenv
@@ -92,14 +93,63 @@ func TestGetEnvString(t *testing.T) {
 	}
 }
 
+func TestGetEnvDuration(t *testing.T) {
+	setupTestEnv(t)
+	defer teardownTestEnv(t)
+
+	type args struct {
+		key      string
+		fallback time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Duration
+	}{
+		{
+			name: "returns fallback",
+			args: args{
+				key:      "GLBC_TST_NO_ENVAR",
+				fallback: time.Minute,
+			},
+			want: time.Minute,
+		},
+		{
+			name: "returns env var value",
+			args: args{
+				key:      "GLBC_TST_DURATION",
+				fallback: time.Minute,
+			},
+			want: 5 * time.Minute,
+		},
+		{
+			name: "returns fallback for non duration env var value",
+			args: args{
+				key:      "GLBC_TST_FOO_STR",
+				fallback: time.Minute,
+			},
+			want: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetEnvDuration(tt.args.key, tt.args.fallback); got != tt.want {
+				t.Errorf("GetEnvDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func setupTestEnv(t *testing.T) {
 	_ = os.Setenv("GLBC_TST_FALSE_BOOL", "false")
 	_ = os.Setenv("GLBC_TST_NOT_BOOL", "notabool")
 	_ = os.Setenv("GLBC_TST_FOO_STR", "foo")
+	_ = os.Setenv("GLBC_TST_DURATION", "5m")
 }
 
 func teardownTestEnv(t *testing.T) {
 	_ = os.Unsetenv("GLBC_TST_FALSE_BOOL")
 	_ = os.Unsetenv("GLBC_TST_NOT_BOOL")
 	_ = os.Unsetenv("GLBC_TST_FOO_STR")
+	_ = os.Unsetenv("GLBC_TST_DURATION")
 }
@@ -0,0 +1,110 @@
+package workloadMigration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kuadrant/kcp-glbc/pkg/util/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DrainAnnotation records the progress of the drain of the traffic from a
+// cluster the workload is migrated away from, suffixed by -<cluster name>
+const DrainAnnotation = "kuadrant.dev/glbc-drain"
+
+// DefaultDrainStrategy steps the share of the traffic of a leaving cluster
+// down to 75%, 50% and 25% of its weight, every 30 seconds.
+var DefaultDrainStrategy = DrainStrategy{
+	Schedule:     []int{75, 50, 25},
+	StepInterval: 30 * time.Second,
+}
+
+// DrainStrategy is the strategy draining the traffic from the clusters the
+// workloads are migrated away from. The share of the traffic of a leaving
+// cluster steps down through the percentages of its weight in the schedule,
+// each held for the step interval, and then to zero. Its soft finalizer is
+// released once the records with a zero weight have been published for the
+// record TTL.
+type DrainStrategy struct {
+	Schedule     []int
+	StepInterval time.Duration
+}
+
+// DrainProgress is the progress of the drain of a cluster.
+type DrainProgress struct {
+	// Percent is the percentage of the weight of the cluster
+	Percent int `json:"percent"`
+	// NextStepAt is the time of the next step of the drain, or of the
+	// release of the soft finalizer once the percentage is zero
+	NextStepAt metav1.Time `json:"nextStepAt"`
+}
+
+// ParseDrainSchedule returns the schedule of the comma separated list of
+// strictly decreasing percentages between 1 and 99.
+func ParseDrainSchedule(value string) ([]int, error) {
+	var schedule []int
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		percent, err := strconv.Atoi(strings.TrimSuffix(entry, "%"))
+		if err != nil || percent < 1 || percent > 99 {
+			return nil, fmt.Errorf("invalid drain percentage %q, must be between 1 and 99", entry)
+		}
+		if len(schedule) > 0 && percent >= schedule[len(schedule)-1] {
+			return nil, fmt.Errorf("invalid drain schedule %q, the percentages must be decreasing", value)
+		}
+		schedule = append(schedule, percent)
+	}
+	return schedule, nil
+}
+
+// start returns the progress of a drain starting at the time.
+func (s DrainStrategy) start(now time.Time) DrainProgress {
+	return s.step(DrainProgress{Percent: 100}, now)
+}
+
+// step returns the progress of the drain after the next step at the time.
+func (s DrainStrategy) step(progress DrainProgress, now time.Time) DrainProgress {
+	for _, percent := range s.Schedule {
+		if percent < progress.Percent {
+			return DrainProgress{Percent: percent, NextStepAt: metav1.NewTime(now.Add(s.StepInterval))}
+		}
+	}
+	return DrainProgress{Percent: 0, NextStepAt: metav1.NewTime(now.Add(TTL * time.Second))}
+}
+
+// DrainPercent returns the percentage of the weight of the cluster the
+// traffic is drained to, if the cluster is being drained.
+func DrainPercent(obj metav1.Object, clusterName string) (int, bool) {
+	progress, ok := drainProgress(obj, clusterName)
+	if !ok {
+		return 0, false
+	}
+	return progress.Percent, true
+}
+
+func drainProgress(obj metav1.Object, clusterName string) (DrainProgress, bool) {
+	value, ok := obj.GetAnnotations()[DrainAnnotation+"-"+clusterName]
+	if !ok {
+		return DrainProgress{}, false
+	}
+	progress := DrainProgress{}
+	if err := json.Unmarshal([]byte(value), &progress); err != nil {
+		return DrainProgress{}, false
+	}
+	return progress, true
+}
+
+func setDrainProgress(obj metav1.Object, clusterName string, progress DrainProgress) error {
+	value, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	metadata.AddAnnotation(obj, DrainAnnotation+"-"+clusterName, string(value))
+	return nil
+}
@@ -0,0 +1,97 @@
+package workloadMigration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestParseDrainSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: nil},
+		{name: "percentages", value: "75, 50%,25", want: []int{75, 50, 25}},
+		{name: "not decreasing", value: "50,75", wantErr: true},
+		{name: "out of range", value: "100", wantErr: true},
+		{name: "not a number", value: "half", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDrainSchedule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDrainSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ParseDrainSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGracefulRemoveSoftFinalizers(t *testing.T) {
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	strategy := DrainStrategy{Schedule: []int{50}, StepInterval: time.Minute}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ingress",
+			Namespace: "default",
+			Annotations: map[string]string{
+				WorkloadClusterSoftFinalizer + "/cluster": SoftFinalizer,
+				WorkloadDeletingAnnotation + "cluster":    "2022-01-01T00:00:00Z",
+			},
+		},
+	}
+	// Elapses the current step of the drain
+	elapse := func() {
+		progress, ok := drainProgress(ingress, "cluster")
+		if !ok {
+			t.Fatal("expected drain progress")
+		}
+		progress.NextStepAt = metav1.NewTime(time.Now().Add(-time.Second))
+		if err := setDrainProgress(ingress, "cluster", progress); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	steps := []struct {
+		elapse      bool
+		wantPercent int
+	}{
+		{elapse: false, wantPercent: 50},
+		{elapse: false, wantPercent: 50},
+		{elapse: true, wantPercent: 0},
+		{elapse: false, wantPercent: 0},
+	}
+	for i, step := range steps {
+		if step.elapse {
+			elapse()
+		}
+		gracefulRemoveSoftFinalizers(ingress, queue, strategy, logr.Discard())
+		if percent, ok := DrainPercent(ingress, "cluster"); !ok || percent != step.wantPercent {
+			t.Fatalf("step %d: DrainPercent() = %v, %v, want %v", i, percent, ok, step.wantPercent)
+		}
+		if _, ok := ingress.Annotations[WorkloadClusterSoftFinalizer+"/cluster"]; !ok {
+			t.Fatalf("step %d: soft finalizer released before the traffic is drained", i)
+		}
+	}
+
+	elapse()
+	gracefulRemoveSoftFinalizers(ingress, queue, strategy, logr.Discard())
+	if _, ok := ingress.Annotations[WorkloadClusterSoftFinalizer+"/cluster"]; ok {
+		t.Error("soft finalizer not released after the traffic is drained")
+	}
+	if _, ok := DrainPercent(ingress, "cluster"); ok {
+		t.Error("drain progress not removed after the traffic is drained")
+	}
+}
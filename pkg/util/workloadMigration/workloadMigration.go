@@ -2,7 +2,6 @@ package workloadMigration
 
 import (
 	"errors"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,13 +19,12 @@ const (
 	WorkloadStatusAnnotation     = "experimental.status.workload.kcp.dev/"
 	WorkloadDeletingAnnotation   = "deletion.internal.workload.kcp.dev/"
 	SoftFinalizer                = "kuadrant.dev/glbc-migration"
-	DeleteAtAnnotation           = "kuadrant.dev/glbc-delete-at"
 	TTL                          = 60
 )
 
-func Process(obj metav1.Object, queue workqueue.RateLimitingInterface, logger logr.Logger) {
+func Process(obj metav1.Object, queue workqueue.RateLimitingInterface, strategy DrainStrategy, logger logr.Logger) {
 	ensureSoftFinalizers(obj, logger)
-	gracefulRemoveSoftFinalizers(obj, queue, logger)
+	gracefulRemoveSoftFinalizers(obj, queue, strategy, logger)
 }
 
 //ensureSoftFinalizers ensure all active workload clusters have a soft finalizer set
@@ -43,16 +41,15 @@ func ensureSoftFinalizers(obj metav1.Object, logger logr.Logger) {
 		if !deleting {
 			softFinalizer := WorkloadClusterSoftFinalizer + "/" + clusterName
 			metadata.AddAnnotation(obj, softFinalizer, SoftFinalizer)
-			//if delayed delete is active on this object, remove it
-			metadata.RemoveAnnotation(obj, DeleteAtAnnotation+"-"+clusterName)
+			//if a drain is active on this object, remove it
+			metadata.RemoveAnnotation(obj, DrainAnnotation+"-"+clusterName)
 		}
 	}
 }
 
-//gracefulRemoveSoftFinalizers any soft finalizers with no active workload cluster should trigger a delayed delete
-func gracefulRemoveSoftFinalizers(obj metav1.Object, queue workqueue.RateLimitingInterface, logger logr.Logger) {
-	at := time.Now()
-	at = at.Add((TTL * time.Second) * 2)
+// gracefulRemoveSoftFinalizers any soft finalizers with no active workload cluster should trigger a drain of its traffic,
+// and are removed once it is complete
+func gracefulRemoveSoftFinalizers(obj metav1.Object, queue workqueue.RateLimitingInterface, strategy DrainStrategy, logger logr.Logger) {
 	_, annotations := metadata.HasAnnotationsContaining(obj, WorkloadClusterSoftFinalizer)
 	for annotation := range annotations {
 		finalizerParts := strings.Split(annotation, "/")
@@ -61,39 +58,33 @@ func gracefulRemoveSoftFinalizers(obj metav1.Object, queue workqueue.RateLimitin
 			continue
 		}
 		clusterName := finalizerParts[1]
-		//finalizer on a cluster waiting to delete, set up graceful delete
+		//finalizer on a cluster waiting to delete, drain its traffic
 		if !metadata.HasAnnotation(obj, WorkloadDeletingAnnotation+clusterName) {
 			continue
 		}
-		clusterDeleteAtAnnotation := DeleteAtAnnotation + "-" + clusterName
-		//delete delay annotation not yet set, set it
-		if !metadata.HasAnnotation(obj, clusterDeleteAtAnnotation) {
-			metadata.AddAnnotation(obj, clusterDeleteAtAnnotation, strconv.FormatInt(at.Unix(), 10))
-			// requeue object
-			key, err := cache.MetaNamespaceKeyFunc(obj)
-			if err != nil {
-				return
-			}
-			queue.AddAfter(key, TTL*2*time.Second)
-		} else {
-			deleteAt, err := strconv.Atoi(obj.GetAnnotations()[clusterDeleteAtAnnotation])
-			if err != nil {
-				//badly formed deleteAt annotation, remove it, so it will be regenerated
-				metadata.RemoveAnnotation(obj, clusterDeleteAtAnnotation)
-			}
-
-			if int64(deleteAt) <= time.Now().Unix() {
+		now := time.Now()
+		progress, ok := drainProgress(obj, clusterName)
+		if !ok {
+			//drain not yet started, or badly formed progress annotation, (re)start it
+			progress = strategy.start(now)
+		} else if !now.Before(progress.NextStepAt.Time) {
+			if progress.Percent == 0 {
+				//traffic drained for the record TTL, release the cluster
 				metadata.RemoveAnnotation(obj, WorkloadClusterSoftFinalizer+"/"+clusterName)
-				metadata.RemoveAnnotation(obj, clusterDeleteAtAnnotation)
-			} else {
-				//requeue object
-				queueFor := int64(deleteAt) - time.Now().Unix()
-				key, err := cache.MetaNamespaceKeyFunc(obj)
-				if err != nil {
-					return
-				}
-				queue.AddAfter(key, time.Duration(queueFor)*time.Second)
+				metadata.RemoveAnnotation(obj, DrainAnnotation+"-"+clusterName)
+				continue
 			}
+			progress = strategy.step(progress, now)
+		}
+		if err := setDrainProgress(obj, clusterName, progress); err != nil {
+			logger.Error(err, "cannot record drain progress", "cluster", clusterName)
+			continue
+		}
+		// requeue object for the next step
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return
 		}
+		queue.AddAfter(key, progress.NextStepAt.Sub(now))
 	}
 }